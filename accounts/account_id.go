@@ -0,0 +1,73 @@
+package accounts
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// AccountID is the id of an account resource, it is the single id type of the account operations. A uuid.UUID
+// converts to it directly, AccountID(id), and a string is validated by ParseAccountID
+type AccountID uuid.UUID
+
+// NewAccountID returns a new random account id
+func NewAccountID() AccountID {
+	return AccountID(uuid.New())
+}
+
+// ParseAccountID parses and validates the string form of an account id
+func ParseAccountID(s string) (AccountID, error) {
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return AccountID{}, fmt.Errorf("%w; invalid account id", err)
+	}
+
+	return AccountID(id), nil
+}
+
+// MustParseAccountID parses the string form of an account id, it panics when the string is not valid so it is meant
+// for constants and tests
+func MustParseAccountID(s string) AccountID {
+	id, err := ParseAccountID(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return id
+}
+
+// UUID returns the account id as a uuid.UUID
+func (id AccountID) UUID() uuid.UUID {
+	return uuid.UUID(id)
+}
+
+// String returns the canonical string form of the account id
+func (id AccountID) String() string {
+	return uuid.UUID(id).String()
+}
+
+// IsZero tells if the account id is the nil uuid
+func (id AccountID) IsZero() bool {
+	return uuid.UUID(id) == uuid.Nil
+}
+
+// MarshalText encodes the account id in its string form
+func (id AccountID) MarshalText() ([]byte, error) {
+	return uuid.UUID(id).MarshalText()
+}
+
+// UnmarshalText decodes and validates the string form of an account id
+func (id *AccountID) UnmarshalText(data []byte) error {
+	parsed, err := ParseAccountID(string(data))
+	if err != nil {
+		return err
+	}
+
+	*id = parsed
+	return nil
+}
+
+// AccountID returns the id of the account data as an AccountID, failing when it is not a valid account id
+func (accountData *AccountData) AccountID() (AccountID, error) {
+	return ParseAccountID(accountData.ID)
+}
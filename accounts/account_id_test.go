@@ -0,0 +1,75 @@
+package accounts
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAccountID(t *testing.T) {
+	tests := []struct {
+		name       string
+		id         string
+		want       AccountID
+		wantErr    bool
+		wantErrMsg string
+	}{
+		{
+			name: "Successfully parses an account id",
+			id:   "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc",
+			want: AccountID(uuid.MustParse("ad27e265-9605-4b4b-a0e5-3003ea9cc4dc")),
+		},
+		{
+			name:       "Failed to parse an invalid account id",
+			id:         "invalid account id",
+			wantErr:    true,
+			wantErrMsg: "invalid UUID length: 18; invalid account id",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseAccountID(tt.id)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.wantErrMsg)
+				assert.True(t, got.IsZero())
+				assert.Panics(t, func() { MustParseAccountID(tt.id) })
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+				assert.Equal(t, tt.id, got.String())
+				assert.Equal(t, uuid.MustParse(tt.id), got.UUID())
+			}
+		})
+	}
+}
+
+func TestAccountIDJSON(t *testing.T) {
+	type ref struct {
+		ID AccountID `json:"id"`
+	}
+
+	accountID := NewAccountID()
+	encoded, err := json.Marshal(ref{ID: accountID})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id": "`+accountID.String()+`"}`, string(encoded))
+
+	var decoded ref
+	require.NoError(t, json.Unmarshal(encoded, &decoded))
+	assert.Equal(t, accountID, decoded.ID)
+
+	assert.Error(t, json.Unmarshal([]byte(`{"id": "invalid"}`), &decoded))
+}
+
+func TestAccountDataAccountID(t *testing.T) {
+	accountID, err := (&AccountData{ID: "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc"}).AccountID()
+	require.NoError(t, err)
+	assert.Equal(t, MustParseAccountID("ad27e265-9605-4b4b-a0e5-3003ea9cc4dc"), accountID)
+
+	_, err = (&AccountData{ID: "invalid account id"}).AccountID()
+	assert.Error(t, err)
+}
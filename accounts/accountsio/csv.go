@@ -0,0 +1,146 @@
+package accountsio
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+
+	"renatoaraujo/form3-account-api-client/accounts"
+)
+
+// CSVWriter writes the account data as CSV rows, the header row is written before the first account
+type CSVWriter struct {
+	writer        *csv.Writer
+	columns       []Column
+	headerWritten bool
+}
+
+// NewCSVWriter creates a CSV writer of the columns given, the default columns when none are given
+func NewCSVWriter(w io.Writer, columns ...Column) (*CSVWriter, error) {
+	if len(columns) == 0 {
+		columns = DefaultColumns()
+	}
+
+	if err := validateColumns(columns); err != nil {
+		return nil, fmt.Errorf("%w; unable to create the csv writer", err)
+	}
+
+	return &CSVWriter{writer: csv.NewWriter(w), columns: columns}, nil
+}
+
+// Write writes the account as a row
+func (w *CSVWriter) Write(accountData *accounts.AccountData) error {
+	if !w.headerWritten {
+		if err := w.writeHeader(); err != nil {
+			return err
+		}
+	}
+
+	row := make([]string, len(w.columns))
+	for i, column := range w.columns {
+		row[i] = accessors[column.Field].get(accountData)
+	}
+
+	if err := w.writer.Write(row); err != nil {
+		return fmt.Errorf("%w; unable to write the account %s", err, accountData.ID)
+	}
+
+	return nil
+}
+
+// Flush writes the buffered rows, the header row is written even without any account
+func (w *CSVWriter) Flush() error {
+	if !w.headerWritten {
+		if err := w.writeHeader(); err != nil {
+			return err
+		}
+	}
+
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+func (w *CSVWriter) writeHeader() error {
+	header := make([]string, len(w.columns))
+	for i, column := range w.columns {
+		header[i] = column.Header
+	}
+
+	if err := w.writer.Write(header); err != nil {
+		return fmt.Errorf("%w; unable to write the header", err)
+	}
+
+	w.headerWritten = true
+	return nil
+}
+
+// CSVReader reads the account data from CSV rows, the columns are found by the headers of the first row and the
+// columns not mapped are ignored
+type CSVReader struct {
+	reader  *csv.Reader
+	columns map[int]Field
+	row     int
+}
+
+// NewCSVReader creates a CSV reader of the columns given, the default columns when none are given, reading the
+// header row
+func NewCSVReader(r io.Reader, columns ...Column) (*CSVReader, error) {
+	if len(columns) == 0 {
+		columns = DefaultColumns()
+	}
+
+	if err := validateColumns(columns); err != nil {
+		return nil, fmt.Errorf("%w; unable to create the csv reader", err)
+	}
+
+	reader := csv.NewReader(r)
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w; unable to read the header", err)
+	}
+
+	fields := make(map[string]Field, len(columns))
+	for _, column := range columns {
+		fields[column.Header] = column.Field
+	}
+
+	mapped := make(map[int]Field)
+	for i, name := range header {
+		if field, ok := fields[name]; ok {
+			mapped[i] = field
+		}
+	}
+
+	if len(mapped) == 0 {
+		return nil, errors.New("no column of the header is mapped; unable to create the csv reader")
+	}
+
+	return &CSVReader{reader: reader, columns: mapped, row: 1}, nil
+}
+
+// Read reads the account of the next row, io.EOF is returned once there are no rows left, the empty cells leave
+// their field unset
+func (r *CSVReader) Read() (*accounts.AccountData, error) {
+	record, err := r.reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+		return nil, fmt.Errorf("%w; unable to read the row %d", err, r.row+1)
+	}
+	r.row++
+
+	accountData := &accounts.AccountData{Type: "accounts"}
+	for i, field := range r.columns {
+		if record[i] == "" {
+			continue
+		}
+
+		if err := accessors[field].set(accountData, record[i]); err != nil {
+			return nil, fmt.Errorf("%w; invalid %s of the row %d", err, field, r.row)
+		}
+	}
+
+	return accountData, nil
+}
@@ -0,0 +1,112 @@
+package accountsio
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"renatoaraujo/form3-account-api-client/accounts"
+	"renatoaraujo/form3-account-api-client/accounts/accountstest"
+)
+
+func TestCSVRoundTrip(t *testing.T) {
+	written := []*accounts.AccountData{
+		accountstest.ValidGBAccount(accountstest.WithID(accounts.NewAccountID())),
+		accountstest.ValidGBAccount(accountstest.WithID(accounts.NewAccountID())),
+	}
+
+	var buf bytes.Buffer
+	writer, err := NewCSVWriter(&buf)
+	require.NoError(t, err)
+	for _, accountData := range written {
+		require.NoError(t, writer.Write(accountData))
+	}
+	require.NoError(t, writer.Flush())
+
+	reader, err := NewCSVReader(&buf)
+	require.NoError(t, err)
+
+	for _, want := range written {
+		read, err := reader.Read()
+		require.NoError(t, err)
+		assert.Equal(t, want, read)
+	}
+
+	_, err = reader.Read()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestCSVColumnMapping(t *testing.T) {
+	columns := []Column{
+		{Header: "Account ID", Field: FieldID},
+		{Header: "Holder", Field: FieldName},
+		{Header: "Sort Code", Field: FieldBankID},
+		{Header: "Joint", Field: FieldJointAccount},
+	}
+
+	var buf bytes.Buffer
+	writer, err := NewCSVWriter(&buf, columns...)
+	require.NoError(t, err)
+	require.NoError(t, writer.Write(accountstest.ValidGBAccount(accountstest.WithID(accounts.MustParseAccountID("ad27e265-9605-4b4b-a0e5-3003ea9cc4dc")))))
+	require.NoError(t, writer.Flush())
+
+	assert.Equal(t, "Account ID,Holder,Sort Code,Joint\nad27e265-9605-4b4b-a0e5-3003ea9cc4dc,Samantha Holder,400300,false\n", buf.String())
+
+	reader, err := NewCSVReader(strings.NewReader("Holder,Ignored,Sort Code,Joint\nSamantha Holder|Sam Holder,x,400300,\n"), columns...)
+	require.NoError(t, err)
+
+	read, err := reader.Read()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Samantha Holder", "Sam Holder"}, read.Attributes.Name)
+	assert.Equal(t, "400300", read.Attributes.BankID)
+	assert.Nil(t, read.Attributes.JointAccount)
+}
+
+func TestCSVErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		run        func() error
+		wantErrMsg string
+	}{
+		{
+			name: "Failed to create the writer with an unknown field",
+			run: func() error {
+				_, err := NewCSVWriter(io.Discard, Column{Header: "Colour", Field: "colour"})
+				return err
+			},
+			wantErrMsg: `invalid field "colour" of column "Colour"; unable to create the csv writer`,
+		},
+		{
+			name: "Failed to create the reader without any mapped column",
+			run: func() error {
+				_, err := NewCSVReader(strings.NewReader("a,b\n1,2\n"))
+				return err
+			},
+			wantErrMsg: "no column of the header is mapped; unable to create the csv reader",
+		},
+		{
+			name: "Failed to read a row with an invalid boolean",
+			run: func() error {
+				reader, err := NewCSVReader(strings.NewReader("id,joint_account\n1,maybe\n"))
+				if err != nil {
+					return err
+				}
+				_, err = reader.Read()
+				return err
+			},
+			wantErrMsg: `strconv.ParseBool: parsing "maybe": invalid syntax; invalid joint_account of the row 2`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.run()
+			require.Error(t, err)
+			assert.EqualError(t, err, tt.wantErrMsg)
+		})
+	}
+}
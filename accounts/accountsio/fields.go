@@ -0,0 +1,261 @@
+// Package accountsio streams the account data to and from CSV and JSON Lines, such as to export the account inventory
+// or to bulk-load accounts from a spreadsheet through the account client
+package accountsio
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"renatoaraujo/form3-account-api-client/accounts"
+)
+
+// ListSeparator separates the values of the list fields, such as the names, in a single column
+const ListSeparator = "|"
+
+// Field is a field of the account data a column is mapped to, named after its json name
+type Field string
+
+// The fields of the account data which can be mapped to a column
+const (
+	FieldID                      Field = "id"
+	FieldOrganisationID          Field = "organisation_id"
+	FieldVersion                 Field = "version"
+	FieldCountry                 Field = "country"
+	FieldBaseCurrency            Field = "base_currency"
+	FieldBankID                  Field = "bank_id"
+	FieldBankIDCode              Field = "bank_id_code"
+	FieldBic                     Field = "bic"
+	FieldAccountNumber           Field = "account_number"
+	FieldIban                    Field = "iban"
+	FieldCustomerID              Field = "customer_id"
+	FieldName                    Field = "name"
+	FieldAlternativeNames        Field = "alternative_names"
+	FieldAccountClassification   Field = "account_classification"
+	FieldJointAccount            Field = "joint_account"
+	FieldAccountMatchingOptOut   Field = "account_matching_opt_out"
+	FieldSecondaryIdentification Field = "secondary_identification"
+	FieldSwitched                Field = "switched"
+	FieldStatus                  Field = "status"
+	FieldNameMatchingStatus      Field = "name_matching_status"
+)
+
+// Column maps a column, by its header, to a field of the account data
+type Column struct {
+	Header string
+	Field  Field
+}
+
+// DefaultColumns returns a column for every field, the headers are the names of the fields
+func DefaultColumns() []Column {
+	columns := make([]Column, len(fieldOrder))
+	for i, field := range fieldOrder {
+		columns[i] = Column{Header: string(field), Field: field}
+	}
+
+	return columns
+}
+
+// fieldOrder is the order of the default columns
+var fieldOrder = []Field{
+	FieldID, FieldOrganisationID, FieldVersion, FieldCountry, FieldBaseCurrency, FieldBankID, FieldBankIDCode,
+	FieldBic, FieldAccountNumber, FieldIban, FieldCustomerID, FieldName, FieldAlternativeNames,
+	FieldAccountClassification, FieldJointAccount, FieldAccountMatchingOptOut, FieldSecondaryIdentification,
+	FieldSwitched, FieldStatus, FieldNameMatchingStatus,
+}
+
+// accessor reads and writes a field of the account data as text
+type accessor struct {
+	get func(accountData *accounts.AccountData) string
+	set func(accountData *accounts.AccountData, value string) error
+}
+
+var accessors = map[Field]accessor{
+	FieldID: {
+		get: func(accountData *accounts.AccountData) string { return accountData.ID },
+		set: func(accountData *accounts.AccountData, value string) error { accountData.ID = value; return nil },
+	},
+	FieldOrganisationID: {
+		get: func(accountData *accounts.AccountData) string { return accountData.OrganisationID },
+		set: func(accountData *accounts.AccountData, value string) error {
+			accountData.OrganisationID = value
+			return nil
+		},
+	},
+	FieldVersion: {
+		get: func(accountData *accounts.AccountData) string { return strconv.Itoa(int(accountData.Version)) },
+		set: func(accountData *accounts.AccountData, value string) error {
+			version, err := strconv.Atoi(value)
+			accountData.Version = accounts.Version(version)
+			return err
+		},
+	},
+	FieldCountry: {
+		get: func(accountData *accounts.AccountData) string {
+			if country := attributes(accountData).Country; country != nil {
+				return string(*country)
+			}
+			return ""
+		},
+		set: func(accountData *accounts.AccountData, value string) error {
+			country := accounts.CountryCode(value)
+			setAttributes(accountData).Country = &country
+			return nil
+		},
+	},
+	FieldBaseCurrency: stringAttribute(func(attributes *accounts.AccountAttributes) *string {
+		return (*string)(&attributes.BaseCurrency)
+	}),
+	FieldBankID: stringAttribute(func(attributes *accounts.AccountAttributes) *string {
+		return &attributes.BankID
+	}),
+	FieldBankIDCode: stringAttribute(func(attributes *accounts.AccountAttributes) *string {
+		return &attributes.BankIDCode
+	}),
+	FieldBic: stringAttribute(func(attributes *accounts.AccountAttributes) *string {
+		return &attributes.Bic
+	}),
+	FieldAccountNumber: stringAttribute(func(attributes *accounts.AccountAttributes) *string {
+		return &attributes.AccountNumber
+	}),
+	FieldIban: stringAttribute(func(attributes *accounts.AccountAttributes) *string {
+		return &attributes.Iban
+	}),
+	FieldCustomerID: optionalStringAttribute(func(attributes *accounts.AccountAttributes) **string {
+		return &attributes.CustomerID
+	}),
+	FieldSecondaryIdentification: optionalStringAttribute(func(attributes *accounts.AccountAttributes) **string {
+		return &attributes.SecondaryIdentification
+	}),
+	FieldNameMatchingStatus: stringAttribute(func(attributes *accounts.AccountAttributes) *string {
+		return (*string)(&attributes.NameMatchingStatus)
+	}),
+	FieldName: listAttribute(func(attributes *accounts.AccountAttributes) *[]string {
+		return &attributes.Name
+	}),
+	FieldAlternativeNames: listAttribute(func(attributes *accounts.AccountAttributes) *[]string {
+		return &attributes.AlternativeNames
+	}),
+	FieldJointAccount: boolAttribute(func(attributes *accounts.AccountAttributes) **bool {
+		return &attributes.JointAccount
+	}),
+	FieldAccountMatchingOptOut: boolAttribute(func(attributes *accounts.AccountAttributes) **bool {
+		return &attributes.AccountMatchingOptOut
+	}),
+	FieldSwitched: boolAttribute(func(attributes *accounts.AccountAttributes) **bool {
+		return &attributes.Switched
+	}),
+	FieldAccountClassification: {
+		get: func(accountData *accounts.AccountData) string {
+			if classification := attributes(accountData).AccountClassification; classification != nil {
+				return string(*classification)
+			}
+			return ""
+		},
+		set: func(accountData *accounts.AccountData, value string) error {
+			classification := accounts.Classification(value)
+			if err := classification.Validate(); err != nil {
+				return err
+			}
+			setAttributes(accountData).AccountClassification = &classification
+			return nil
+		},
+	},
+	FieldStatus: {
+		get: func(accountData *accounts.AccountData) string {
+			if status := attributes(accountData).Status; status != nil {
+				return string(*status)
+			}
+			return ""
+		},
+		set: func(accountData *accounts.AccountData, value string) error {
+			status := accounts.AccountStatus(value)
+			setAttributes(accountData).Status = &status
+			return nil
+		},
+	},
+}
+
+// validateColumns checks the fields of the columns are known
+func validateColumns(columns []Column) error {
+	for _, column := range columns {
+		if _, ok := accessors[column.Field]; !ok {
+			return fmt.Errorf("invalid field %q of column %q", column.Field, column.Header)
+		}
+	}
+
+	return nil
+}
+
+// attributes returns the attributes of the account to read from, empty ones when it has none
+func attributes(accountData *accounts.AccountData) *accounts.AccountAttributes {
+	if accountData.Attributes == nil {
+		return &accounts.AccountAttributes{}
+	}
+
+	return accountData.Attributes
+}
+
+// setAttributes returns the attributes of the account to write to, creating them when it has none
+func setAttributes(accountData *accounts.AccountData) *accounts.AccountAttributes {
+	if accountData.Attributes == nil {
+		accountData.Attributes = &accounts.AccountAttributes{}
+	}
+
+	return accountData.Attributes
+}
+
+func stringAttribute(field func(*accounts.AccountAttributes) *string) accessor {
+	return accessor{
+		get: func(accountData *accounts.AccountData) string {
+			return *field(attributes(accountData))
+		},
+		set: func(accountData *accounts.AccountData, value string) error {
+			*field(setAttributes(accountData)) = value
+			return nil
+		},
+	}
+}
+
+func optionalStringAttribute(field func(*accounts.AccountAttributes) **string) accessor {
+	return accessor{
+		get: func(accountData *accounts.AccountData) string {
+			return accounts.Value(*field(attributes(accountData)))
+		},
+		set: func(accountData *accounts.AccountData, value string) error {
+			*field(setAttributes(accountData)) = &value
+			return nil
+		},
+	}
+}
+
+func listAttribute(field func(*accounts.AccountAttributes) *[]string) accessor {
+	return accessor{
+		get: func(accountData *accounts.AccountData) string {
+			return strings.Join(*field(attributes(accountData)), ListSeparator)
+		},
+		set: func(accountData *accounts.AccountData, value string) error {
+			*field(setAttributes(accountData)) = strings.Split(value, ListSeparator)
+			return nil
+		},
+	}
+}
+
+func boolAttribute(field func(*accounts.AccountAttributes) **bool) accessor {
+	return accessor{
+		get: func(accountData *accounts.AccountData) string {
+			if value := *field(attributes(accountData)); value != nil {
+				return strconv.FormatBool(*value)
+			}
+			return ""
+		},
+		set: func(accountData *accounts.AccountData, value string) error {
+			parsed, err := strconv.ParseBool(value)
+			if err != nil {
+				return err
+			}
+			*field(setAttributes(accountData)) = &parsed
+			return nil
+		},
+	}
+}
@@ -0,0 +1,62 @@
+package accountsio
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"renatoaraujo/form3-account-api-client/accounts"
+)
+
+// JSONLWriter writes the account data as JSON Lines, one account per line
+type JSONLWriter struct {
+	writer  *bufio.Writer
+	encoder *json.Encoder
+}
+
+// NewJSONLWriter creates a JSON Lines writer
+func NewJSONLWriter(w io.Writer) *JSONLWriter {
+	writer := bufio.NewWriter(w)
+	return &JSONLWriter{writer: writer, encoder: json.NewEncoder(writer)}
+}
+
+// Write writes the account as a line
+func (w *JSONLWriter) Write(accountData *accounts.AccountData) error {
+	if err := w.encoder.Encode(accountData); err != nil {
+		return fmt.Errorf("%w; unable to write the account %s", err, accountData.ID)
+	}
+
+	return nil
+}
+
+// Flush writes the buffered lines
+func (w *JSONLWriter) Flush() error {
+	return w.writer.Flush()
+}
+
+// JSONLReader reads the account data from JSON Lines, one account per line
+type JSONLReader struct {
+	decoder *json.Decoder
+	line    int
+}
+
+// NewJSONLReader creates a JSON Lines reader
+func NewJSONLReader(r io.Reader) *JSONLReader {
+	return &JSONLReader{decoder: json.NewDecoder(r)}
+}
+
+// Read reads the account of the next line, io.EOF is returned once there are no lines left
+func (r *JSONLReader) Read() (*accounts.AccountData, error) {
+	accountData := &accounts.AccountData{}
+	if err := r.decoder.Decode(accountData); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+		return nil, fmt.Errorf("%w; unable to read the line %d", err, r.line+1)
+	}
+	r.line++
+
+	return accountData, nil
+}
@@ -0,0 +1,50 @@
+package accountsio
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"renatoaraujo/form3-account-api-client/accounts"
+	"renatoaraujo/form3-account-api-client/accounts/accountstest"
+)
+
+func TestJSONLRoundTrip(t *testing.T) {
+	written := []*accounts.AccountData{
+		accountstest.ValidGBAccount(accountstest.WithID(accounts.NewAccountID())),
+		accountstest.RandomAccount(),
+	}
+
+	var buf bytes.Buffer
+	writer := NewJSONLWriter(&buf)
+	for _, accountData := range written {
+		require.NoError(t, writer.Write(accountData))
+	}
+	require.NoError(t, writer.Flush())
+	assert.Equal(t, 2, strings.Count(buf.String(), "\n"))
+
+	reader := NewJSONLReader(&buf)
+	for _, want := range written {
+		read, err := reader.Read()
+		require.NoError(t, err)
+		assert.True(t, want.Equal(read))
+	}
+
+	_, err := reader.Read()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestJSONLReaderWithAnInvalidLine(t *testing.T) {
+	reader := NewJSONLReader(strings.NewReader("{\"id\":\"1\"}\n{\"id\":\n"))
+
+	_, err := reader.Read()
+	require.NoError(t, err)
+
+	_, err = reader.Read()
+	require.Error(t, err)
+	assert.EqualError(t, err, "unexpected EOF; unable to read the line 2")
+}
@@ -0,0 +1,76 @@
+package accountsio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"renatoaraujo/form3-account-api-client/accounts"
+)
+
+// exportPageSize is the page size of the lists of an export
+const exportPageSize = 100
+
+// Writer writes the account data, such as a CSVWriter or a JSONLWriter
+type Writer interface {
+	Write(accountData *accounts.AccountData) error
+	Flush() error
+}
+
+// Reader reads the account data, such as a CSVReader or a JSONLReader, it returns io.EOF once there are none left
+type Reader interface {
+	Read() (*accounts.AccountData, error)
+}
+
+// Export lists every page of the accounts matching the filters of the list options and writes them, it returns the
+// number of accounts written
+func Export(ctx context.Context, client accounts.AccountLister, listOpts accounts.ListOptions, w Writer, opts ...accounts.CallOption) (int, error) {
+	listOpts.PageSize = exportPageSize
+
+	exported := 0
+	for page := 0; ; page++ {
+		listOpts.PageNumber = page
+		data, err := client.ListResources(ctx, listOpts, opts...)
+		if err != nil {
+			return exported, fmt.Errorf("%w; unable to export the page %d", err, page)
+		}
+
+		for _, accountData := range data {
+			if err := w.Write(accountData); err != nil {
+				return exported, err
+			}
+			exported++
+		}
+
+		if len(data) < exportPageSize {
+			break
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return exported, fmt.Errorf("%w; unable to flush the export", err)
+	}
+
+	return exported, nil
+}
+
+// Import reads the accounts and creates them one by one, it stops at the first failure and returns the number of
+// accounts created
+func Import(ctx context.Context, client accounts.AccountCreator, r Reader, opts ...accounts.CallOption) (int, error) {
+	imported := 0
+	for {
+		accountData, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			return imported, nil
+		}
+		if err != nil {
+			return imported, err
+		}
+
+		if _, err := client.CreateResource(ctx, accountData, opts...); err != nil {
+			return imported, fmt.Errorf("%w; unable to import the account %d", err, imported+1)
+		}
+		imported++
+	}
+}
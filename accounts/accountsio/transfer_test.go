@@ -0,0 +1,71 @@
+package accountsio
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"renatoaraujo/form3-account-api-client/accounts"
+	"renatoaraujo/form3-account-api-client/accounts/accountsmock"
+	"renatoaraujo/form3-account-api-client/accounts/accountstest"
+)
+
+func TestExport(t *testing.T) {
+	page := func(size int) []*accounts.AccountData {
+		data := make([]*accounts.AccountData, size)
+		for i := range data {
+			data[i] = accountstest.RandomAccount()
+		}
+		return data
+	}
+
+	accountsMock := &accountsmock.AccountsAPI{}
+	accountsMock.On("ListResources", mock.Anything, accounts.ListOptions{PageNumber: 0, PageSize: 100}).Return(page(100), nil).Once()
+	accountsMock.On("ListResources", mock.Anything, accounts.ListOptions{PageNumber: 1, PageSize: 100}).Return(page(1), nil).Once()
+
+	var buf bytes.Buffer
+	exported, err := Export(context.Background(), accountsMock, accounts.ListOptions{}, NewJSONLWriter(&buf))
+	require.NoError(t, err)
+	assert.Equal(t, 101, exported)
+	assert.Equal(t, 101, strings.Count(buf.String(), "\n"))
+
+	mock.AssertExpectationsForObjects(t, accountsMock)
+}
+
+func TestExportFailsToList(t *testing.T) {
+	accountsMock := &accountsmock.AccountsAPI{}
+	accountsMock.On("ListResources", mock.Anything, mock.Anything).Return(nil, errors.New("api failure")).Once()
+
+	_, err := Export(context.Background(), accountsMock, accounts.ListOptions{}, NewJSONLWriter(&bytes.Buffer{}))
+	require.Error(t, err)
+	assert.EqualError(t, err, "api failure; unable to export the page 0")
+}
+
+func TestImport(t *testing.T) {
+	csvData := "id,country,name\n" +
+		"ad27e265-9605-4b4b-a0e5-3003ea9cc4dc,GB,Samantha Holder\n" +
+		"f199fe08-90b4-4756-9c1f-3a2352ea4933,GB,Jeff Page\n" +
+		"4c3c2bb9-0ef4-4b30-8f25-0b0c6f1e5a3e,GB,Jane Doe\n"
+
+	accountsMock := &accountsmock.AccountsAPI{}
+	accountsMock.On("CreateResource", mock.Anything, mock.MatchedBy(func(accountData *accounts.AccountData) bool {
+		return accountData.ID == "4c3c2bb9-0ef4-4b30-8f25-0b0c6f1e5a3e"
+	})).Return(nil, errors.New("api failure")).Once()
+	accountsMock.On("CreateResource", mock.Anything, mock.Anything).Return(&accounts.AccountData{}, nil).Twice()
+
+	reader, err := NewCSVReader(strings.NewReader(csvData))
+	require.NoError(t, err)
+
+	imported, err := Import(context.Background(), accountsMock, reader)
+	require.Error(t, err)
+	assert.EqualError(t, err, "api failure; unable to import the account 3")
+	assert.Equal(t, 2, imported)
+
+	mock.AssertExpectationsForObjects(t, accountsMock)
+}
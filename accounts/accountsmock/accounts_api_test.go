@@ -0,0 +1,53 @@
+package accountsmock
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"renatoaraujo/form3-account-api-client/accounts"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	_ accounts.AccountsAPI    = (*AccountsAPI)(nil)
+	_ accounts.AccountCreator = (*AccountsAPI)(nil)
+	_ accounts.AccountLister  = (*AccountsAPI)(nil)
+)
+
+func TestAccountsAPI(t *testing.T) {
+	accountID := accounts.NewAccountID()
+	accountData := &accounts.AccountData{ID: accountID.String()}
+
+	accountsMock := &AccountsAPI{}
+	accountsMock.On("CreateResource", mock.Anything, accountData).Return(accountData, nil)
+	accountsMock.On("FetchResource", mock.Anything, accountID, mock.Anything).Return(accountData, nil)
+	accountsMock.On("UpdateResource", mock.Anything, accountData).Return(nil, errors.New("conflict"))
+	accountsMock.On("DeleteResource", mock.Anything, accountID, accounts.Version(1)).Return(nil)
+	accountsMock.On("ListResources", mock.Anything, accounts.ListOptions{}).Return([]*accounts.AccountData{accountData}, nil)
+
+	var api accounts.AccountsAPI = accountsMock
+	ctx := context.Background()
+
+	created, err := api.CreateResource(ctx, accountData)
+	assert.NoError(t, err)
+	assert.Equal(t, accountData, created)
+
+	fetched, err := api.FetchResource(ctx, accountID, accounts.WithSLOClass(accounts.SLOBatch))
+	assert.NoError(t, err)
+	assert.Equal(t, accountData, fetched)
+
+	updated, err := api.UpdateResource(ctx, accountData)
+	assert.EqualError(t, err, "conflict")
+	assert.Nil(t, updated)
+
+	assert.NoError(t, api.DeleteResource(ctx, accountID, 1))
+
+	listed, err := api.ListResources(ctx, accounts.ListOptions{})
+	assert.NoError(t, err)
+	assert.Len(t, listed, 1)
+
+	accountsMock.AssertExpectations(t)
+}
@@ -0,0 +1,202 @@
+// Package accountstest provides factories of valid account data for the tests of the consumers, so they don't depend on
+// brittle json files
+package accountstest
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand"
+	"strings"
+
+	"renatoaraujo/form3-account-api-client/accounts"
+
+	"github.com/google/uuid"
+)
+
+// OrganisationID is the organisation of the accounts created by the factories unless WithOrganisationID is given
+const OrganisationID = "eb0bd6f5-c3f5-44b2-b677-acd23cdde73c"
+
+// Option customises the account data created by the factories
+type Option func(*accounts.AccountData)
+
+// WithID sets the account id
+func WithID(accountID accounts.AccountID) Option {
+	return func(accountData *accounts.AccountData) {
+		accountData.ID = accountID.String()
+	}
+}
+
+// WithOrganisationID sets the organisation owning the account
+func WithOrganisationID(organisationID uuid.UUID) Option {
+	return func(accountData *accounts.AccountData) {
+		accountData.OrganisationID = organisationID.String()
+	}
+}
+
+// WithAttributes changes the attributes of the account, applied after the factory filled them
+func WithAttributes(f func(*accounts.AccountAttributes)) Option {
+	return func(accountData *accounts.AccountData) {
+		f(accountData.Attributes)
+	}
+}
+
+// ValidGBAccount returns a valid personal GB account with a new id
+func ValidGBAccount(opts ...Option) *accounts.AccountData {
+	country := accounts.CountryUnitedKingdom
+	classification := accounts.ClassificationPersonal
+	jointAccount, matchingOptOut := false, false
+
+	return newAccountData(&accounts.AccountAttributes{
+		AccountClassification:   &classification,
+		AccountMatchingOptOut:   &matchingOptOut,
+		AccountNumber:           "41426819",
+		AlternativeNames:        []string{"Sam Holder"},
+		BankID:                  "400300",
+		BankIDCode:              "GBDSC",
+		BaseCurrency:            accounts.CurrencyGBP,
+		Bic:                     "NWBKGB22",
+		Country:                 &country,
+		Iban:                    "GB16NWBK40030041426819",
+		JointAccount:            &jointAccount,
+		Name:                    []string{"Samantha Holder"},
+		SecondaryIdentification: accounts.Ptr("A1B2C3D4"),
+	}, opts)
+}
+
+// randomCountries are the countries RandomAccount picks from, with the generators of their valid attributes
+var randomCountries = map[accounts.CountryCode]func(*accounts.AccountAttributes){
+	accounts.CountryUnitedKingdom: func(attributes *accounts.AccountAttributes) {
+		attributes.BankID = digits(6)
+		attributes.BankIDCode = "GBDSC"
+		attributes.BaseCurrency = accounts.CurrencyGBP
+		attributes.Bic = letters(4) + "GB22"
+		attributes.AccountNumber = digits(8)
+		attributes.Iban = iban("GB", attributes.Bic[:4]+attributes.BankID+attributes.AccountNumber)
+	},
+	accounts.CountryNetherlands: func(attributes *accounts.AccountAttributes) {
+		attributes.BaseCurrency = accounts.CurrencyEUR
+		attributes.Bic = letters(4) + "NL2A"
+		attributes.AccountNumber = digits(10)
+		attributes.Iban = iban("NL", attributes.Bic[:4]+attributes.AccountNumber)
+	},
+	accounts.CountryGermany: func(attributes *accounts.AccountAttributes) {
+		attributes.BankID = digits(8)
+		attributes.BankIDCode = "DEBLZ"
+		attributes.BaseCurrency = accounts.CurrencyEUR
+		attributes.Bic = letters(4) + "DEFF"
+		attributes.AccountNumber = digits(7)
+	},
+	accounts.CountryAustralia: func(attributes *accounts.AccountAttributes) {
+		attributes.BankID = digits(6)
+		attributes.BankIDCode = "AUBSB"
+		attributes.BaseCurrency = accounts.CurrencyAUD
+		attributes.Bic = letters(4) + "AU2S"
+		attributes.AccountNumber = digits(6 + rand.Intn(5))
+	},
+	accounts.CountryUnitedStates: func(attributes *accounts.AccountAttributes) {
+		attributes.BankID = digits(9)
+		attributes.BankIDCode = "USABA"
+		attributes.BaseCurrency = accounts.CurrencyUSD
+		attributes.Bic = letters(4) + "US33"
+		attributes.AccountNumber = digits(6 + rand.Intn(12))
+	},
+}
+
+// RandomCountries returns the countries RandomAccount picks from
+func RandomCountries() []accounts.CountryCode {
+	return []accounts.CountryCode{
+		accounts.CountryAustralia,
+		accounts.CountryGermany,
+		accounts.CountryNetherlands,
+		accounts.CountryUnitedKingdom,
+		accounts.CountryUnitedStates,
+	}
+}
+
+// RandomAccount returns a valid account of a random country with random identifiers and names
+func RandomAccount(opts ...Option) *accounts.AccountData {
+	countries := RandomCountries()
+	return RandomAccountOf(countries[rand.Intn(len(countries))], opts...)
+}
+
+// RandomAccountOf returns a valid account of the country with random identifiers and names, the country must be one
+// of RandomCountries
+func RandomAccountOf(country accounts.CountryCode, opts ...Option) *accounts.AccountData {
+	generate, ok := randomCountries[country]
+	if !ok {
+		panic(fmt.Sprintf("accountstest: no generator for country %s", country))
+	}
+
+	classification := accounts.ClassificationPersonal
+	if rand.Intn(2) == 0 {
+		classification = accounts.ClassificationBusiness
+	}
+
+	attributes := &accounts.AccountAttributes{
+		AccountClassification: &classification,
+		Country:               &country,
+		Name:                  []string{randomName()},
+	}
+	generate(attributes)
+
+	return newAccountData(attributes, opts)
+}
+
+func newAccountData(attributes *accounts.AccountAttributes, opts []Option) *accounts.AccountData {
+	accountData := &accounts.AccountData{
+		Attributes:     attributes,
+		ID:             accounts.NewAccountID().String(),
+		OrganisationID: OrganisationID,
+		Type:           "accounts",
+	}
+
+	for _, opt := range opts {
+		opt(accountData)
+	}
+
+	return accountData
+}
+
+var (
+	firstNames = []string{"Samantha", "John", "Jane", "Oliver", "Amelia", "Noah", "Isla", "Leo"}
+	lastNames  = []string{"Holder", "Doe", "Smith", "Jones", "Taylor", "Brown", "Wilson", "Evans"}
+)
+
+func randomName() string {
+	return firstNames[rand.Intn(len(firstNames))] + " " + lastNames[rand.Intn(len(lastNames))]
+}
+
+func digits(n int) string {
+	var builder strings.Builder
+	for i := 0; i < n; i++ {
+		builder.WriteByte(byte('0' + rand.Intn(10)))
+	}
+
+	return builder.String()
+}
+
+func letters(n int) string {
+	var builder strings.Builder
+	for i := 0; i < n; i++ {
+		builder.WriteByte(byte('A' + rand.Intn(26)))
+	}
+
+	return builder.String()
+}
+
+// iban computes the mod-97 check digits of the basic bank account number of the country
+func iban(country, bban string) string {
+	var numeric strings.Builder
+	for _, r := range bban + country + "00" {
+		if r >= 'A' && r <= 'Z' {
+			numeric.WriteString(fmt.Sprint(r - 'A' + 10))
+		} else {
+			numeric.WriteRune(r)
+		}
+	}
+
+	value, _ := new(big.Int).SetString(numeric.String(), 10)
+	checkDigits := 98 - new(big.Int).Mod(value, big.NewInt(97)).Int64()
+
+	return fmt.Sprintf("%s%02d%s", country, checkDigits, bban)
+}
@@ -0,0 +1,54 @@
+package accountstest
+
+import (
+	"testing"
+
+	"renatoaraujo/form3-account-api-client/accounts"
+	"renatoaraujo/form3-account-api-client/validation"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidGBAccount(t *testing.T) {
+	accountID := accounts.NewAccountID()
+
+	accountData := ValidGBAccount(WithID(accountID), WithAttributes(func(attributes *accounts.AccountAttributes) {
+		attributes.CustomerID = accounts.Ptr("customer-1")
+	}))
+
+	require.NoError(t, accountData.Validate())
+	assert.NoError(t, validation.IBAN(accountData.Attributes.Iban))
+	assert.Equal(t, accountID.String(), accountData.ID)
+	assert.Equal(t, OrganisationID, accountData.OrganisationID)
+	assert.Equal(t, "customer-1", accounts.Value(accountData.Attributes.CustomerID))
+	assert.NotEqual(t, ValidGBAccount().ID, ValidGBAccount().ID)
+}
+
+func TestRandomAccount(t *testing.T) {
+	organisationID := uuid.New()
+
+	for _, country := range RandomCountries() {
+		t.Run(string(country), func(t *testing.T) {
+			for i := 0; i < 50; i++ {
+				accountData := RandomAccountOf(country, WithOrganisationID(organisationID))
+
+				require.NoError(t, accountData.Validate())
+				assert.Equal(t, country, *accountData.Attributes.Country)
+				assert.Equal(t, organisationID.String(), accountData.OrganisationID)
+				if accountData.Attributes.Iban != "" {
+					assert.NoError(t, validation.IBAN(accountData.Attributes.Iban))
+				}
+			}
+		})
+	}
+
+	require.NoError(t, RandomAccount().Validate())
+}
+
+func TestRandomAccountOfUnknownCountry(t *testing.T) {
+	assert.PanicsWithValue(t, "accountstest: no generator for country BR", func() {
+		RandomAccountOf("BR")
+	})
+}
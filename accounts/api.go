@@ -0,0 +1,41 @@
+package accounts
+
+import "context"
+
+// AccountCreator creates the account resources, the consumers only creating accounts can depend on it alone
+type AccountCreator interface {
+	CreateResource(ctx context.Context, accountData *AccountData, opts ...CallOption) (*AccountData, error)
+}
+
+// AccountFetcher fetches the account resources by their account id
+type AccountFetcher interface {
+	FetchResource(ctx context.Context, accountID AccountID, opts ...CallOption) (*AccountData, error)
+}
+
+// AccountUpdater updates the attributes of the account resources
+type AccountUpdater interface {
+	UpdateResource(ctx context.Context, accountData *AccountData, opts ...CallOption) (*AccountData, error)
+}
+
+// AccountDeleter deletes the account resources by their account id and version
+type AccountDeleter interface {
+	DeleteResource(ctx context.Context, accountID AccountID, version Version, opts ...CallOption) error
+}
+
+// AccountLister lists the pages of the account resources
+type AccountLister interface {
+	ListResources(ctx context.Context, listOpts ListOptions, opts ...CallOption) ([]*AccountData, error)
+}
+
+// AccountsAPI is the account client as seen by its consumers, so they can depend on it and replace the client with
+// accountsmock.AccountsAPI in their unit tests, the consumers using a single capability can depend on its smaller
+// interface instead, the mock implements every one of them
+type AccountsAPI interface {
+	AccountCreator
+	AccountFetcher
+	AccountUpdater
+	AccountDeleter
+	AccountLister
+}
+
+var _ AccountsAPI = (*Client)(nil)
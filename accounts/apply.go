@@ -0,0 +1,91 @@
+package accounts
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// ApplyAction is the action Apply took to converge an account to its desired state
+type ApplyAction string
+
+const (
+	// ApplyCreated is an account created as it did not exist
+	ApplyCreated ApplyAction = "created"
+	// ApplyUnchanged is an account already in its desired state
+	ApplyUnchanged ApplyAction = "unchanged"
+	// ApplyReplaced is an account deleted and created again as it drifted from its desired state, the accounts are
+	// immutable so a change is a replacement
+	ApplyReplaced ApplyAction = "replaced"
+)
+
+// ApplyResult is the outcome of Apply, the account is the one form3 holds after it and the changes are the drift
+// which led to the replacement, if any
+type ApplyResult struct {
+	Action  ApplyAction
+	Account *AccountData
+	Changes []Change
+}
+
+// Apply converges an account to its desired state, it creates the account when it does not exist and replaces it
+// when it drifted, the attributes form3 sets which the desired state leaves out, such as the status, are not a drift
+// so applying the same desired state again is a no-op, the building block of a terraform provider, the current state
+// is always fetched from form3, never from the cache
+func (client *Client) Apply(ctx context.Context, desired *AccountData, opts ...CallOption) (*ApplyResult, error) {
+	accountID, err := desired.AccountID()
+	if err != nil {
+		return nil, fmt.Errorf("%w; unable to apply resource", err)
+	}
+
+	current, err := client.FetchResource(ctx, accountID, uncached(opts)...)
+	if err != nil {
+		if !isStatus(err, http.StatusNotFound) {
+			return nil, fmt.Errorf("%w; unable to apply resource", err)
+		}
+
+		created, err := client.CreateResource(ctx, desired, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("%w; unable to apply resource", err)
+		}
+
+		return &ApplyResult{Action: ApplyCreated, Account: created}, nil
+	}
+
+	changes, err := drift(desired, current)
+	if err != nil {
+		return nil, fmt.Errorf("%w; unable to apply resource", err)
+	}
+
+	if len(changes) == 0 {
+		return &ApplyResult{Action: ApplyUnchanged, Account: current}, nil
+	}
+
+	if err := client.DeleteResource(ctx, accountID, current.Version, opts...); err != nil {
+		return nil, fmt.Errorf("%w; unable to replace resource", err)
+	}
+
+	replaced, err := client.CreateResource(ctx, desired, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("%w; unable to replace resource", err)
+	}
+
+	return &ApplyResult{Action: ApplyReplaced, Account: replaced, Changes: changes}, nil
+}
+
+// drift returns the changes of the desired account the current one does not hold, the removed attributes are the ones
+// form3 sets so they are left out
+func drift(desired, current *AccountData) ([]Change, error) {
+	changes, err := Diff(desired, current, IgnoreServerAssigned())
+	if err != nil {
+		return nil, err
+	}
+
+	drifted := changes[:0]
+	for _, change := range changes {
+		if change.Kind != ChangeRemoved {
+			drifted = append(drifted, change)
+		}
+	}
+
+	return drifted, nil
+}
@@ -0,0 +1,154 @@
+package accounts
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"renatoaraujo/form3-account-api-client/httputils"
+)
+
+func TestClientApply(t *testing.T) {
+	accountID := NewAccountID()
+	country := CountryCode("GB")
+	desired := &AccountData{
+		ID:   accountID.String(),
+		Type: "accounts",
+		Attributes: &AccountAttributes{
+			Country: &country,
+			BankID:  "400300",
+			Bic:     "NWBKGB22",
+		},
+	}
+
+	remotePayload := func(version Version, modify func(*AccountData)) []byte {
+		remote := desired.Clone()
+		remote.Version = version
+		status := StatusConfirmed
+		remote.Attributes.Status = &status
+		if modify != nil {
+			modify(remote)
+		}
+
+		payload, err := json.Marshal(&Payload{Data: remote})
+		require.NoError(t, err)
+		return payload
+	}
+	notFound := &httputils.ResponseError{StatusCode: 404, ErrorMessage: "not found"}
+
+	tests := []struct {
+		name           string
+		httpUtilsSetup func(*mockHttpUtils)
+		wantAction     ApplyAction
+		wantChanges    []Change
+		wantErr        bool
+		wantErrMsg     string
+	}{
+		{
+			name: "Successfully creates the account when it does not exist",
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(nil, notFound).Once()
+				client.On("Post", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(remotePayload(0, nil), nil).Once()
+			},
+			wantAction: ApplyCreated,
+		},
+		{
+			name: "Successfully leaves the account unchanged when only form3 set attributes differ",
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(remotePayload(2, nil), nil).Once()
+			},
+			wantAction: ApplyUnchanged,
+		},
+		{
+			name: "Successfully replaces the account when it drifted",
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(remotePayload(2, func(remote *AccountData) {
+					remote.Attributes.Bic = "NWBKGB33"
+				}), nil).Once()
+				client.On("Delete", mock.Anything, mock.Anything, map[string]string{"version": "2"}).Return(nil).Once()
+				client.On("Post", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(remotePayload(0, nil), nil).Once()
+			},
+			wantAction: ApplyReplaced,
+			wantChanges: []Change{
+				{Path: "attributes.bic", Kind: ChangeModified, Local: "NWBKGB22", Remote: "NWBKGB33"},
+			},
+		},
+		{
+			name: "Failed to apply when the fetch fails",
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("api failure")).Once()
+			},
+			wantErr:    true,
+			wantErrMsg: "api failure; unable to fetch resource; unable to apply resource",
+		},
+		{
+			name: "Failed to apply when the create fails",
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(nil, notFound).Once()
+				client.On("Post", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("api failure")).Once()
+			},
+			wantErr:    true,
+			wantErrMsg: "api failure; unable to create resource; unable to apply resource",
+		},
+		{
+			name: "Failed to replace when the delete fails",
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(remotePayload(2, func(remote *AccountData) {
+					remote.Attributes.Bic = "NWBKGB33"
+				}), nil).Once()
+				client.On("Delete", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("api failure")).Once()
+			},
+			wantErr:    true,
+			wantErrMsg: "api failure; unable to delete resource; unable to replace resource",
+		},
+		{
+			name: "Failed to replace when the create fails after the delete",
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(remotePayload(2, func(remote *AccountData) {
+					remote.Attributes.Bic = "NWBKGB33"
+				}), nil).Once()
+				client.On("Delete", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+				client.On("Post", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("api failure")).Once()
+			},
+			wantErr:    true,
+			wantErrMsg: "api failure; unable to create resource; unable to replace resource",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpUtilsMock := &mockHttpUtils{}
+			tt.httpUtilsSetup(httpUtilsMock)
+
+			accountsClient, err := NewClient(httpUtilsMock)
+			require.NoError(t, err)
+
+			result, err := accountsClient.Apply(context.Background(), desired)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.wantErrMsg)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantAction, result.Action)
+				assert.Equal(t, desired.ID, result.Account.ID)
+				assert.Equal(t, tt.wantChanges, result.Changes)
+			}
+
+			mock.AssertExpectationsForObjects(t, httpUtilsMock)
+		})
+	}
+}
+
+func TestClientApplyWithInvalidAccountID(t *testing.T) {
+	accountsClient, err := NewClient(&mockHttpUtils{})
+	require.NoError(t, err)
+
+	_, err = accountsClient.Apply(context.Background(), &AccountData{ID: "invalid"})
+	require.Error(t, err)
+	assert.EqualError(t, err, "invalid UUID length: 7; invalid account id; unable to apply resource")
+}
@@ -0,0 +1,40 @@
+package accounts
+
+import "context"
+
+// AsyncResult is the outcome of an asynchronous operation
+type AsyncResult struct {
+	Data *AccountData
+	Err  error
+}
+
+// CreateResourceAsync creates a new account resource without blocking, the returned channel receives the outcome
+// once and is closed
+func (client *Client) CreateResourceAsync(ctx context.Context, accountData *AccountData, opts ...CallOption) <-chan AsyncResult {
+	return async(func() (*AccountData, error) {
+		return client.CreateResource(ctx, accountData, opts...)
+	})
+}
+
+// FetchResourceAsync fetches an account resource without blocking, the returned channel receives the outcome once
+// and is closed
+func (client *Client) FetchResourceAsync(ctx context.Context, accountID AccountID, opts ...CallOption) <-chan AsyncResult {
+	return async(func() (*AccountData, error) {
+		return client.FetchResource(ctx, accountID, opts...)
+	})
+}
+
+// async runs the operation in a goroutine, the channel is buffered so the goroutine never leaks when the outcome is
+// not received
+func async(operation func() (*AccountData, error)) <-chan AsyncResult {
+	result := make(chan AsyncResult, 1)
+
+	go func() {
+		defer close(result)
+
+		data, err := operation()
+		result <- AsyncResult{Data: data, Err: err}
+	}()
+
+	return result
+}
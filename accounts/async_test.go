@@ -0,0 +1,80 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAsync(t *testing.T) {
+	tests := []struct {
+		name           string
+		httpUtilsSetup func(*mockHttpUtils)
+		call           func(*Client) <-chan AsyncResult
+		wantErr        bool
+	}{
+		{
+			name: "Successfully creates an account asynchronously",
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Post", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
+					loadTestFile("./testdata/api_response.json"),
+					nil,
+				)
+			},
+			call: func(c *Client) <-chan AsyncResult {
+				return c.CreateResourceAsync(context.Background(), &AccountData{})
+			},
+		},
+		{
+			name: "Successfully fetches an account asynchronously",
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(
+					loadTestFile("./testdata/api_response.json"),
+					nil,
+				)
+			},
+			call: func(c *Client) <-chan AsyncResult {
+				return c.FetchResourceAsync(context.Background(), NewAccountID())
+			},
+		},
+		{
+			name: "Failed to fetch an account asynchronously",
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("not found"))
+			},
+			call: func(c *Client) <-chan AsyncResult {
+				return c.FetchResourceAsync(context.Background(), NewAccountID())
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpUtilsMock := &mockHttpUtils{}
+			tt.httpUtilsSetup(httpUtilsMock)
+
+			accountsClient, err := NewClient(httpUtilsMock)
+			require.NoError(t, err)
+
+			results := tt.call(&accountsClient)
+			result := <-results
+			if tt.wantErr {
+				require.Error(t, result.Err)
+				assert.Nil(t, result.Data)
+			} else {
+				require.NoError(t, result.Err)
+				assert.IsType(t, &AccountData{}, result.Data)
+			}
+
+			_, open := <-results
+			assert.False(t, open)
+
+			mock.AssertExpectationsForObjects(t, httpUtilsMock)
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const headerRequestID = "X-Request-Id"
+
+// AuditOperation is the mutating operation of an audit record
+type AuditOperation string
+
+const (
+	// AuditCreate is the creation of an account
+	AuditCreate AuditOperation = "create"
+	// AuditUpdate is the update of the attributes of an account
+	AuditUpdate AuditOperation = "update"
+	// AuditDelete is the deletion of an account
+	AuditDelete AuditOperation = "delete"
+)
+
+// AuditOutcome is the outcome of the operation of an audit record
+type AuditOutcome string
+
+const (
+	// AuditSuccess is an operation form3 performed
+	AuditSuccess AuditOutcome = "success"
+	// AuditFailure is an operation that failed, the error of the record tells why
+	AuditFailure AuditOutcome = "failure"
+)
+
+// AuditRecord is the structured record of a mutating operation sent to form3
+type AuditRecord struct {
+	Operation      AuditOperation
+	AccountID      AccountID
+	OrganisationID string
+	Outcome        AuditOutcome
+	// Err is the error of a failed operation
+	Err error
+	// RequestID is the id given with WithRequestID or the one generated for the operation, sent as the X-Request-Id
+	// header of the creates
+	RequestID string
+	// Provenance is the metadata given with WithProvenance, nil when the operation has none
+	Provenance *Provenance
+	Timestamp  time.Time
+}
+
+// Auditor receives the audit records of the client, such as to persist an audit log, it is invoked synchronously so
+// a slow auditor slows the operations down
+type Auditor interface {
+	Audit(ctx context.Context, record AuditRecord)
+}
+
+// AuditorFunc adapts a function to an Auditor
+type AuditorFunc func(ctx context.Context, record AuditRecord)
+
+// Audit calls the function with the record
+func (fn AuditorFunc) Audit(ctx context.Context, record AuditRecord) {
+	fn(ctx, record)
+}
+
+// WithAuditor invokes the auditor with a record of every create, update and delete sent to form3, including the failed ones,
+// a panic of the auditor is recovered so it neither fails nor crashes the operation
+func WithAuditor(auditor Auditor) Option {
+	return func(c *Client) error {
+		if auditor == nil {
+			return errors.New("invalid auditor, it must not be nil")
+		}
+
+		c.auditor = auditor
+		return nil
+	}
+}
+
+// WithRequestID sets the id of the request of the operation, it is sent as the X-Request-Id header of the creates and
+// updates and it is the request id of the audit record, one is generated when the client has an auditor
+func WithRequestID(requestID string) CallOption {
+	return func(cfg *callConfig) {
+		cfg.requestID = requestID
+	}
+}
+
+// auditing generates the request id of an audited operation not given one
+func (client *Client) auditing(cfg *callConfig) {
+	if client.auditor != nil && cfg.requestID == "" {
+		cfg.requestID = uuid.NewString()
+	}
+}
+
+// audit invokes the auditor, if any, with the record of the operation
+func (client *Client) audit(ctx context.Context, cfg callConfig, record AuditRecord, err error) {
+	if client.auditor == nil {
+		return
+	}
+
+	record.Outcome = AuditSuccess
+	if err != nil {
+		record.Outcome = AuditFailure
+		record.Err = err
+	}
+	record.RequestID = cfg.requestID
+	record.Provenance = cfg.provenance
+	record.Timestamp = client.now()
+
+	defer ignorePanic()
+	client.auditor.Audit(ctx, record)
+}
+
+// auditRecord returns the audit record of the operation on the account
+func (accountData *AccountData) auditRecord(operation AuditOperation) AuditRecord {
+	record := AuditRecord{Operation: operation}
+	if accountData == nil {
+		return record
+	}
+
+	record.AccountID, _ = accountData.AccountID()
+	record.OrganisationID = accountData.OrganisationID
+	return record
+}
+
+// requestOrganisationID returns the organisation id the request of the call is sent for, the one of the call and then
+// the one the client is scoped to, empty when there is none
+func (client *Client) requestOrganisationID(cfg callConfig) string {
+	organisationID := cfg.organisation(client.organisationID)
+	if organisationID == uuid.Nil {
+		return ""
+	}
+
+	return organisationID.String()
+}
@@ -0,0 +1,227 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientAuditor(t *testing.T) {
+	accountID := NewAccountID()
+	organisationID := uuid.New()
+	requestOrganisationID := uuid.New()
+	now := time.Date(2022, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		call           func(*Client) error
+		httpUtilsSetup func(*mockHttpUtils)
+		wantRecord     AuditRecord
+		wantErr        bool
+	}{
+		{
+			name: "Successfully audits the creation of an account",
+			call: func(c *Client) error {
+				_, err := c.CreateResource(context.Background(), &AccountData{ID: accountID.String()}, WithRequestID("req-1"))
+				return err
+			},
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Post", mock.Anything, mock.Anything, mock.Anything, mock.MatchedBy(func(header http.Header) bool {
+					return header.Get("X-Request-Id") == "req-1"
+				})).Return(loadTestFile("./testdata/api_response.json"), nil).Once()
+			},
+			wantRecord: AuditRecord{
+				Operation:      AuditCreate,
+				AccountID:      accountID,
+				OrganisationID: organisationID.String(),
+				Outcome:        AuditSuccess,
+				RequestID:      "req-1",
+				Timestamp:      now,
+			},
+		},
+		{
+			name: "Successfully audits the failed creation of an account",
+			call: func(c *Client) error {
+				_, err := c.CreateResource(context.Background(), &AccountData{ID: accountID.String()}, WithRequestID("req-2"))
+				return err
+			},
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Post", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("api failure")).Once()
+			},
+			wantRecord: AuditRecord{
+				Operation:      AuditCreate,
+				AccountID:      accountID,
+				OrganisationID: organisationID.String(),
+				Outcome:        AuditFailure,
+				Err:            errors.New("api failure"),
+				RequestID:      "req-2",
+				Timestamp:      now,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Successfully audits the deletion of an account",
+			call: func(c *Client) error {
+				return c.DeleteResource(context.Background(), accountID, 0, WithRequestID("req-3"))
+			},
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Delete", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+			},
+			wantRecord: AuditRecord{
+				Operation:      AuditDelete,
+				AccountID:      accountID,
+				OrganisationID: organisationID.String(),
+				Outcome:        AuditSuccess,
+				RequestID:      "req-3",
+				Timestamp:      now,
+			},
+		},
+		{
+			name: "Successfully audits the deletion of an account under the organisation of the call",
+			call: func(c *Client) error {
+				return c.DeleteResource(context.Background(), accountID, 0, WithRequestID("req-6"), WithRequestOrganisation(requestOrganisationID))
+			},
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Delete", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+			},
+			wantRecord: AuditRecord{
+				Operation:      AuditDelete,
+				AccountID:      accountID,
+				OrganisationID: requestOrganisationID.String(),
+				Outcome:        AuditSuccess,
+				RequestID:      "req-6",
+				Timestamp:      now,
+			},
+		},
+		{
+			name: "Successfully audits the update of an account under the organisation of the call",
+			call: func(c *Client) error {
+				_, err := c.UpdateResource(
+					context.Background(),
+					&AccountData{ID: accountID.String(), OrganisationID: organisationID.String(), Version: 1},
+					WithRequestID("req-7"),
+					WithRequestOrganisation(requestOrganisationID),
+				)
+				return err
+			},
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Patch", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(loadTestFile("./testdata/api_response.json"), nil).Once()
+			},
+			wantRecord: AuditRecord{
+				Operation:      AuditUpdate,
+				AccountID:      accountID,
+				OrganisationID: requestOrganisationID.String(),
+				Outcome:        AuditSuccess,
+				RequestID:      "req-7",
+				Timestamp:      now,
+			},
+		},
+		{
+			name: "Successfully audits the update of an account with its provenance",
+			call: func(c *Client) error {
+				_, err := c.UpdateResource(
+					context.Background(),
+					&AccountData{ID: accountID.String(), Version: 1},
+					WithRequestID("req-4"),
+					WithProvenance(Provenance{SourceSystem: "core-banking", BatchID: "batch-1"}),
+				)
+				return err
+			},
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Patch", mock.Anything, mock.Anything, mock.Anything, mock.MatchedBy(func(header http.Header) bool {
+					return header.Get("X-Source-System") == "core-banking"
+				})).Return(loadTestFile("./testdata/api_response.json"), nil).Once()
+			},
+			wantRecord: AuditRecord{
+				Operation:      AuditUpdate,
+				AccountID:      accountID,
+				OrganisationID: organisationID.String(),
+				Outcome:        AuditSuccess,
+				RequestID:      "req-4",
+				Provenance:     &Provenance{SourceSystem: "core-banking", BatchID: "batch-1"},
+				Timestamp:      now,
+			},
+		},
+		{
+			name: "Successfully audits the provenance of the creation of an account",
+			call: func(c *Client) error {
+				_, err := c.CreateResource(
+					context.Background(),
+					&AccountData{ID: accountID.String()},
+					WithRequestID("req-5"),
+					WithProvenance(Provenance{SourceSystem: "core-banking"}),
+				)
+				return err
+			},
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Post", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(loadTestFile("./testdata/api_response.json"), nil).Once()
+			},
+			wantRecord: AuditRecord{
+				Operation:      AuditCreate,
+				AccountID:      accountID,
+				OrganisationID: organisationID.String(),
+				Outcome:        AuditSuccess,
+				RequestID:      "req-5",
+				Provenance:     &Provenance{SourceSystem: "core-banking"},
+				Timestamp:      now,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpUtilsMock := &mockHttpUtils{}
+			tt.httpUtilsSetup(httpUtilsMock)
+
+			var records []AuditRecord
+			accountsClient, err := NewClient(
+				httpUtilsMock,
+				WithOrganisationID(organisationID),
+				WithAuditor(AuditorFunc(func(_ context.Context, record AuditRecord) {
+					records = append(records, record)
+				})),
+			)
+			require.NoError(t, err)
+			accountsClient.now = func() time.Time { return now }
+
+			err = tt.call(&accountsClient)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Len(t, records, 1)
+			assert.Equal(t, tt.wantRecord, records[0])
+
+			mock.AssertExpectationsForObjects(t, httpUtilsMock)
+		})
+	}
+}
+
+func TestClientAuditorGeneratesTheRequestID(t *testing.T) {
+	var sent string
+	httpUtilsMock := &mockHttpUtils{}
+	httpUtilsMock.On("Post", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		sent = args.Get(3).(http.Header).Get("X-Request-Id")
+	}).Return(loadTestFile("./testdata/api_response.json"), nil).Once()
+
+	var record AuditRecord
+	accountsClient, err := NewClient(httpUtilsMock, WithAuditor(AuditorFunc(func(_ context.Context, r AuditRecord) {
+		record = r
+	})))
+	require.NoError(t, err)
+
+	_, err = accountsClient.CreateResource(context.Background(), &AccountData{ID: NewAccountID().String()})
+	require.NoError(t, err)
+
+	assert.NotEmpty(t, record.RequestID)
+	assert.Equal(t, record.RequestID, sent)
+}
@@ -0,0 +1,62 @@
+package accounts
+
+import (
+	"math/rand"
+	"time"
+)
+
+// Backoff tells how long to wait before the next attempt of a polling or a retry loop
+type Backoff interface {
+	// Delay returns the wait before the attempt, the first attempt made after the initial one is attempt 1
+	Delay(attempt int) time.Duration
+}
+
+// BackoffFunc adapts a function to a Backoff
+type BackoffFunc func(attempt int) time.Duration
+
+// Delay calls the function with the attempt
+func (fn BackoffFunc) Delay(attempt int) time.Duration {
+	return fn(attempt)
+}
+
+// ConstantBackoff waits the same delay before every attempt
+func ConstantBackoff(delay time.Duration) Backoff {
+	return BackoffFunc(func(int) time.Duration {
+		return delay
+	})
+}
+
+// ExponentialBackoff doubles the delay from the base one at every attempt, up to the max delay
+func ExponentialBackoff(base, max time.Duration) Backoff {
+	return BackoffFunc(func(attempt int) time.Duration {
+		return growth(base, max, 2, attempt)
+	})
+}
+
+// DecorrelatedJitterBackoff waits a random delay between the base one and an upper bound tripling at every attempt, up
+// to the max delay, so the clients failing together don't retry together, it is the stateless form of the
+// decorrelated jitter so one backoff can be shared by concurrent operations
+func DecorrelatedJitterBackoff(base, max time.Duration) Backoff {
+	return BackoffFunc(func(attempt int) time.Duration {
+		upper := growth(base, max, 3, attempt)
+		if upper <= base {
+			return upper
+		}
+
+		return base + time.Duration(rand.Int63n(int64(upper-base)+1))
+	})
+}
+
+// growth returns the base delay multiplied by the factor for every attempt after the first one, up to the max delay
+func growth(base, max time.Duration, factor int64, attempt int) time.Duration {
+	delay := base
+	for i := 1; i < attempt && delay < max; i++ {
+		delay *= time.Duration(factor)
+	}
+
+	if delay > max {
+		return max
+	}
+
+	return delay
+}
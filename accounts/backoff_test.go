@@ -0,0 +1,78 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"renatoaraujo/form3-account-api-client/httputils"
+)
+
+func TestExponentialBackoff(t *testing.T) {
+	backoff := ExponentialBackoff(100*time.Millisecond, time.Second)
+
+	tests := []struct {
+		name      string
+		attempt   int
+		wantDelay time.Duration
+	}{
+		{name: "Successfully waits the base delay before the first retry", attempt: 1, wantDelay: 100 * time.Millisecond},
+		{name: "Successfully doubles the delay", attempt: 2, wantDelay: 200 * time.Millisecond},
+		{name: "Successfully doubles the delay again", attempt: 4, wantDelay: 800 * time.Millisecond},
+		{name: "Successfully caps the delay", attempt: 5, wantDelay: time.Second},
+		{name: "Successfully caps the delay of the late attempts", attempt: 100, wantDelay: time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantDelay, backoff.Delay(tt.attempt))
+		})
+	}
+}
+
+func TestDecorrelatedJitterBackoff(t *testing.T) {
+	backoff := DecorrelatedJitterBackoff(100*time.Millisecond, 2*time.Second)
+
+	for i := 0; i < 100; i++ {
+		assert.Equal(t, 100*time.Millisecond, backoff.Delay(1))
+
+		delay := backoff.Delay(2)
+		assert.GreaterOrEqual(t, delay, 100*time.Millisecond)
+		assert.LessOrEqual(t, delay, 300*time.Millisecond)
+
+		delay = backoff.Delay(10)
+		assert.GreaterOrEqual(t, delay, 100*time.Millisecond)
+		assert.LessOrEqual(t, delay, 2*time.Second)
+	}
+}
+
+func TestClientRetriesWithTheBackoffOfThePolicy(t *testing.T) {
+	unreachableErr := &url.Error{Op: "Get", URL: "https://api.form3.tech", Err: errors.New("connection refused")}
+	clock := httputils.NewManualClock(time.Date(2021, 10, 15, 19, 28, 58, 0, time.UTC))
+
+	httpUtilsMock := &mockHttpUtils{}
+	httpUtilsMock.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(nil, unreachableErr).Times(4)
+
+	accountsClient, err := NewClient(
+		httpUtilsMock,
+		WithSleeper(clock),
+		WithSLOPolicy(SLOBatch, SLOPolicy{
+			MaxRetries: 3,
+			RetryDelay: time.Hour,
+			Backoff:    ExponentialBackoff(time.Second, time.Minute),
+		}),
+	)
+	require.NoError(t, err)
+
+	_, err = accountsClient.FetchResource(context.Background(), NewAccountID(), WithSLOClass(SLOBatch))
+	require.Error(t, err)
+	assert.Equal(t, 7*time.Second, clock.Slept())
+
+	mock.AssertExpectationsForObjects(t, httpUtilsMock)
+}
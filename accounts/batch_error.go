@@ -0,0 +1,74 @@
+package accounts
+
+import (
+	"errors"
+	"fmt"
+)
+
+// BatchItemError is the failure of an item of a batch operation, the index is the position of the item in the input
+// and the account id is zero when the item has no valid one
+type BatchItemError struct {
+	Index     int
+	AccountID AccountID
+	Err       error
+}
+
+func (err *BatchItemError) Error() string {
+	if err.AccountID == (AccountID{}) {
+		return fmt.Sprintf("item %d: %s", err.Index, err.Err)
+	}
+
+	return fmt.Sprintf("item %d of account %s: %s", err.Index, err.AccountID.String(), err.Err)
+}
+
+func (err *BatchItemError) Unwrap() error {
+	return err.Err
+}
+
+// BatchError aggregates the failures of the items of a batch operation, errors.Is and errors.As look into every item
+// so a batch with a version conflict matches a VersionConflictError
+type BatchError struct {
+	Total int
+	Items []*BatchItemError
+}
+
+// BulkError aggregates the failures of a bulk operation.
+//
+// Deprecated: use BatchError, its items carry the index and the account id of the failures.
+type BulkError = BatchError
+
+func (err *BatchError) Error() string {
+	return fmt.Sprintf("%d of %d operations failed, first error: %s", len(err.Items), err.Total, err.Items[0].Err)
+}
+
+// Errors returns the failures of the items
+func (err *BatchError) Errors() []error {
+	errs := make([]error, len(err.Items))
+	for i, item := range err.Items {
+		errs[i] = item
+	}
+
+	return errs
+}
+
+// Is tells if the failure of any item matches the target
+func (err *BatchError) Is(target error) bool {
+	for _, item := range err.Items {
+		if errors.Is(item, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// As finds the first failure of the items matching the target
+func (err *BatchError) As(target interface{}) bool {
+	for _, item := range err.Items {
+		if errors.As(item, target) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,64 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchError(t *testing.T) {
+	accountID := NewAccountID()
+	conflictErr := &VersionConflictError{AccountID: accountID, Version: 1, Err: errors.New("conflict")}
+
+	batchErr := &BatchError{Total: 3, Items: []*BatchItemError{
+		{Index: 0, AccountID: accountID, Err: context.DeadlineExceeded},
+		{Index: 2, Err: conflictErr},
+	}}
+
+	assert.EqualError(t, batchErr, "2 of 3 operations failed, first error: context deadline exceeded")
+	assert.EqualError(t, batchErr.Items[0], "item 0 of account "+accountID.String()+": context deadline exceeded")
+	assert.EqualError(t, batchErr.Items[1], "item 2: "+conflictErr.Error())
+	assert.Len(t, batchErr.Errors(), 2)
+
+	wrapped := errors.New("unrelated")
+	assert.ErrorIs(t, batchErr, context.DeadlineExceeded)
+	assert.NotErrorIs(t, batchErr, wrapped)
+
+	var conflict *VersionConflictError
+	require.ErrorAs(t, batchErr, &conflict)
+	assert.Equal(t, accountID, conflict.AccountID)
+
+	var item *BatchItemError
+	require.ErrorAs(t, batchErr, &item)
+	assert.Equal(t, 0, item.Index)
+}
+
+func TestDeleteResourcesReportsTheFailedItems(t *testing.T) {
+	refs := []AccountRef{{ID: NewAccountID(), Version: 0}, {ID: NewAccountID(), Version: 1}}
+
+	httpUtilsMock := &mockHttpUtils{}
+	httpUtilsMock.On("Delete", mock.Anything, "/v1/organisation/accounts/"+refs[1].ID.String(), mock.Anything).Return(
+		errors.New("the api failed the request"),
+	)
+	httpUtilsMock.On("Delete", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	accountsClient, err := NewClient(httpUtilsMock)
+	require.NoError(t, err)
+
+	results, err := accountsClient.DeleteResources(context.Background(), refs, 2)
+	require.Error(t, err)
+
+	var batchErr *BatchError
+	require.ErrorAs(t, err, &batchErr)
+	require.Len(t, batchErr.Items, 1)
+	assert.Equal(t, 1, batchErr.Items[0].Index)
+	assert.Equal(t, refs[1].ID, batchErr.Items[0].AccountID)
+
+	for i, result := range results {
+		assert.Equal(t, refs[i].ID, result.AccountID)
+	}
+}
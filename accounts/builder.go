@@ -0,0 +1,148 @@
+package accounts
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// AccountDataBuilder builds an AccountData without the boilerplate of the nested attributes
+type AccountDataBuilder struct {
+	data       AccountData
+	attributes AccountAttributes
+}
+
+// NewAccountDataBuilder creates a builder, the id is generated and the type is accounts unless they are set
+func NewAccountDataBuilder() *AccountDataBuilder {
+	return &AccountDataBuilder{}
+}
+
+// WithID sets the account id
+func (builder *AccountDataBuilder) WithID(id AccountID) *AccountDataBuilder {
+	builder.data.ID = id.String()
+	return builder
+}
+
+// WithOrganisationID sets the organisation owning the account
+func (builder *AccountDataBuilder) WithOrganisationID(organisationID uuid.UUID) *AccountDataBuilder {
+	builder.data.OrganisationID = organisationID.String()
+	return builder
+}
+
+// WithCountry sets the country of the account
+func (builder *AccountDataBuilder) WithCountry(country CountryCode) *AccountDataBuilder {
+	builder.attributes.Country = &country
+	return builder
+}
+
+// WithBaseCurrency sets the base currency of the account
+func (builder *AccountDataBuilder) WithBaseCurrency(currency Currency) *AccountDataBuilder {
+	builder.attributes.BaseCurrency = currency
+	return builder
+}
+
+// WithClassification sets the classification of the account
+func (builder *AccountDataBuilder) WithClassification(classification Classification) *AccountDataBuilder {
+	builder.attributes.AccountClassification = &classification
+	return builder
+}
+
+// WithBankID sets the bank id and the bank id code
+func (builder *AccountDataBuilder) WithBankID(bankID, bankIDCode string) *AccountDataBuilder {
+	builder.attributes.BankID = bankID
+	builder.attributes.BankIDCode = bankIDCode
+	return builder
+}
+
+// WithBic sets the bic
+func (builder *AccountDataBuilder) WithBic(bic string) *AccountDataBuilder {
+	builder.attributes.Bic = bic
+	return builder
+}
+
+// WithIban sets the iban
+func (builder *AccountDataBuilder) WithIban(iban string) *AccountDataBuilder {
+	builder.attributes.Iban = iban
+	return builder
+}
+
+// WithAccountNumber sets the account number
+func (builder *AccountDataBuilder) WithAccountNumber(accountNumber string) *AccountDataBuilder {
+	builder.attributes.AccountNumber = accountNumber
+	return builder
+}
+
+// WithCustomerID sets the customer id
+func (builder *AccountDataBuilder) WithCustomerID(customerID string) *AccountDataBuilder {
+	builder.attributes.CustomerID = &customerID
+	return builder
+}
+
+// WithName sets the names of the account holder
+func (builder *AccountDataBuilder) WithName(name ...string) *AccountDataBuilder {
+	builder.attributes.Name = name
+	return builder
+}
+
+// WithAlternativeNames sets the alternative names of the account holder
+func (builder *AccountDataBuilder) WithAlternativeNames(alternativeNames ...string) *AccountDataBuilder {
+	builder.attributes.AlternativeNames = alternativeNames
+	return builder
+}
+
+// WithSecondaryIdentification sets the secondary identification
+func (builder *AccountDataBuilder) WithSecondaryIdentification(secondaryIdentification string) *AccountDataBuilder {
+	builder.attributes.SecondaryIdentification = &secondaryIdentification
+	return builder
+}
+
+// WithJointAccount sets if the account is held jointly
+func (builder *AccountDataBuilder) WithJointAccount(jointAccount bool) *AccountDataBuilder {
+	builder.attributes.JointAccount = &jointAccount
+	return builder
+}
+
+// WithAccountMatchingOptOut sets if the account opted out of the account matching
+func (builder *AccountDataBuilder) WithAccountMatchingOptOut(optOut bool) *AccountDataBuilder {
+	builder.attributes.AccountMatchingOptOut = &optOut
+	return builder
+}
+
+// WithNameMatchingStatus sets the Confirmation of Payee status of the name of a GB account
+func (builder *AccountDataBuilder) WithNameMatchingStatus(status NameMatchingStatus) *AccountDataBuilder {
+	builder.attributes.NameMatchingStatus = status
+	return builder
+}
+
+// WithSwitched sets if the account was switched
+func (builder *AccountDataBuilder) WithSwitched(switched bool) *AccountDataBuilder {
+	builder.attributes.Switched = &switched
+	return builder
+}
+
+// WithStatus sets the status of the account
+func (builder *AccountDataBuilder) WithStatus(status AccountStatus) *AccountDataBuilder {
+	builder.attributes.Status = &status
+	return builder
+}
+
+// Build fills the defaults and validates the account data, see AccountData.Validate
+func (builder *AccountDataBuilder) Build() (*AccountData, error) {
+	data := builder.data
+	attributes := builder.attributes
+	data.Attributes = &attributes
+
+	if data.ID == "" {
+		data.ID = NewAccountID().String()
+	}
+
+	if data.Type == "" {
+		data.Type = "accounts"
+	}
+
+	if err := data.Validate(); err != nil {
+		return nil, fmt.Errorf("%w; unable to build account data", err)
+	}
+
+	return &data, nil
+}
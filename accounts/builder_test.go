@@ -0,0 +1,54 @@
+package accounts
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountDataBuilder(t *testing.T) {
+	organisationID := uuid.New()
+
+	accountData, err := NewAccountDataBuilder().
+		WithOrganisationID(organisationID).
+		WithCountry("GB").
+		WithBaseCurrency(CurrencyGBP).
+		WithClassification(ClassificationPersonal).
+		WithBankID("400300", "GBDSC").
+		WithBic("NWBKGB22").
+		WithAccountNumber("41426819").
+		WithName("john doe").
+		WithJointAccount(false).
+		Build()
+	require.NoError(t, err)
+
+	_, err = uuid.Parse(accountData.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "accounts", accountData.Type)
+	assert.Equal(t, organisationID.String(), accountData.OrganisationID)
+	assert.Equal(t, CountryUnitedKingdom, *accountData.Attributes.Country)
+	assert.Equal(t, ClassificationPersonal, *accountData.Attributes.AccountClassification)
+	assert.Equal(t, "400300", accountData.Attributes.BankID)
+	assert.Equal(t, "GBDSC", accountData.Attributes.BankIDCode)
+	assert.Equal(t, []string{"john doe"}, accountData.Attributes.Name)
+	assert.False(t, *accountData.Attributes.JointAccount)
+}
+
+func TestAccountDataBuilderKeepsTheID(t *testing.T) {
+	accountID := NewAccountID()
+
+	accountData, err := NewAccountDataBuilder().WithID(accountID).WithCountry(CountryNetherlands).WithBic("ABNANL2A").Build()
+	require.NoError(t, err)
+	assert.Equal(t, accountID.String(), accountData.ID)
+}
+
+func TestAccountDataBuilderValidates(t *testing.T) {
+	_, err := NewAccountDataBuilder().WithCountry(CountryUnitedKingdom).WithBankID("400300", "GBDSC").Build()
+	assert.EqualError(t, err, "invalid account data: GB requires bic; unable to build account data")
+
+	var validationErr *ValidationError
+	assert.True(t, errors.As(err, &validationErr))
+}
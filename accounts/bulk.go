@@ -0,0 +1,143 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// AccountRef references an account resource at a given version
+type AccountRef struct {
+	ID      AccountID
+	Version Version
+}
+
+// BulkResult is the outcome of one item of a bulk operation, the index is the position of the item in the input
+type BulkResult struct {
+	Index     int
+	AccountID AccountID
+	Data      *AccountData
+	Err       error
+}
+
+// CreateResources creates many account resources in parallel with a bounded concurrency, the results are in the same
+// order as the given account data and a BatchError aggregates the failed ones
+func (client *Client) CreateResources(ctx context.Context, accountData []*AccountData, concurrency int, opts ...CallOption) ([]BulkResult, error) {
+	idOf := func(i int) AccountID {
+		if accountData[i] == nil {
+			return AccountID{}
+		}
+		accountID, _ := accountData[i].AccountID()
+		return accountID
+	}
+
+	return runBulk(ctx, len(accountData), concurrency, idOf, func(ctx context.Context, i int) BulkResult {
+		created, err := client.CreateResource(ctx, accountData[i], opts...)
+		return BulkResult{Index: i, Data: created, Err: err}
+	})
+}
+
+// DeleteResources deletes many account resources in parallel with a bounded concurrency, the results are in the same
+// order as the given references and a BatchError aggregates the failed ones
+func (client *Client) DeleteResources(ctx context.Context, refs []AccountRef, concurrency int, opts ...CallOption) ([]BulkResult, error) {
+	idOf := func(i int) AccountID {
+		return refs[i].ID
+	}
+
+	return runBulk(ctx, len(refs), concurrency, idOf, func(ctx context.Context, i int) BulkResult {
+		err := client.DeleteResource(ctx, refs[i].ID, refs[i].Version, opts...)
+		return BulkResult{Index: i, Err: err}
+	})
+}
+
+// runBulk performs the operation for every item with at most concurrency items in flight, the items not started
+// before the context is done fail with the context error, the results carry the account id of their item
+func runBulk(ctx context.Context, total int, concurrency int, idOf func(i int) AccountID, operation func(ctx context.Context, i int) BulkResult) ([]BulkResult, error) {
+	if concurrency <= 0 {
+		return nil, fmt.Errorf("invalid concurrency %d, it must be positive", concurrency)
+	}
+
+	results := make([]BulkResult, total)
+	items := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency && w < total; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range items {
+				results[i] = operation(ctx, i)
+			}
+		}()
+	}
+
+	dispatched := 0
+	for ; dispatched < total; dispatched++ {
+		select {
+		case <-ctx.Done():
+		case items <- dispatched:
+			continue
+		}
+		break
+	}
+	close(items)
+	wg.Wait()
+
+	for i := dispatched; i < total; i++ {
+		results[i] = BulkResult{Index: i, Err: ctx.Err()}
+	}
+
+	batchErr := &BatchError{Total: total}
+	for i := range results {
+		results[i].AccountID = idOf(i)
+		if results[i].Err != nil {
+			batchErr.Items = append(batchErr.Items, &BatchItemError{Index: i, AccountID: results[i].AccountID, Err: results[i].Err})
+		}
+	}
+
+	if len(batchErr.Items) > 0 {
+		return results, batchErr
+	}
+
+	return results, nil
+}
+
+// FetchResources fetches many account resources in parallel with a bounded concurrency, the fetched accounts and the
+// errors of the ones that failed are keyed by id, a repeated id is fetched once
+func (client *Client) FetchResources(ctx context.Context, ids []AccountID, concurrency int, opts ...CallOption) (map[AccountID]*AccountData, map[AccountID]error, error) {
+	unique := make([]AccountID, 0, len(ids))
+	seen := make(map[AccountID]bool, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+
+	idOf := func(i int) AccountID {
+		return unique[i]
+	}
+
+	results, err := runBulk(ctx, len(unique), concurrency, idOf, func(ctx context.Context, i int) BulkResult {
+		fetched, err := client.FetchResource(ctx, unique[i], opts...)
+		return BulkResult{Index: i, Data: fetched, Err: err}
+	})
+
+	var batchErr *BatchError
+	if err != nil && !errors.As(err, &batchErr) {
+		return nil, nil, err
+	}
+
+	fetched := make(map[AccountID]*AccountData, len(results))
+	failed := make(map[AccountID]error)
+	for _, result := range results {
+		if result.Err != nil {
+			failed[unique[result.Index]] = result.Err
+			continue
+		}
+		fetched[unique[result.Index]] = result.Data
+	}
+
+	return fetched, failed, nil
+}
@@ -0,0 +1,274 @@
+package accounts
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateResources(t *testing.T) {
+	failingID := uuid.New().String()
+
+	tests := []struct {
+		name           string
+		accountData    []*AccountData
+		concurrency    int
+		httpUtilsSetup func(*mockHttpUtils)
+		wantFailed     []int
+		wantErr        bool
+		wantErrMsg     string
+	}{
+		{
+			name:        "Successfully creates all the accounts",
+			accountData: []*AccountData{{ID: uuid.New().String()}, {ID: uuid.New().String()}, {ID: uuid.New().String()}},
+			concurrency: 2,
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Post", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
+					loadTestFile("./testdata/api_response.json"),
+					nil,
+				).Times(3)
+			},
+		},
+		{
+			name:        "Failed to create some of the accounts",
+			accountData: []*AccountData{{ID: uuid.New().String()}, {ID: failingID}, {ID: uuid.New().String()}},
+			concurrency: 3,
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Post", mock.Anything, mock.Anything, mock.MatchedBy(func(body []byte) bool {
+					return bytes.Contains(body, []byte(failingID))
+				}), mock.Anything).Return(nil, errors.New("the api failed the request"))
+				client.On("Post", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
+					loadTestFile("./testdata/api_response.json"),
+					nil,
+				)
+			},
+			wantFailed: []int{1},
+			wantErr:    true,
+			wantErrMsg: "1 of 3 operations failed, first error: the api failed the request; unable to create resource",
+		},
+		{
+			name:        "Failed to create the accounts with an invalid concurrency",
+			accountData: []*AccountData{{ID: uuid.New().String()}},
+			concurrency: 0,
+			wantErr:     true,
+			wantErrMsg:  "invalid concurrency 0, it must be positive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpUtilsMock := &mockHttpUtils{}
+			if tt.httpUtilsSetup != nil {
+				tt.httpUtilsSetup(httpUtilsMock)
+			}
+
+			accountsClient, err := NewClient(httpUtilsMock)
+			require.NoError(t, err)
+
+			results, err := accountsClient.CreateResources(context.Background(), tt.accountData, tt.concurrency)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.wantErrMsg)
+			} else {
+				require.NoError(t, err)
+			}
+
+			var failed []int
+			for i, result := range results {
+				assert.Equal(t, i, result.Index)
+				if result.Err != nil {
+					failed = append(failed, result.Index)
+				}
+			}
+			assert.Equal(t, tt.wantFailed, failed)
+
+			mock.AssertExpectationsForObjects(t, httpUtilsMock)
+		})
+	}
+}
+
+func TestDeleteResources(t *testing.T) {
+	failingID := NewAccountID()
+
+	tests := []struct {
+		name           string
+		refs           []AccountRef
+		httpUtilsSetup func(*mockHttpUtils)
+		wantFailed     []int
+		wantErr        bool
+		wantErrMsg     string
+	}{
+		{
+			name: "Successfully deletes all the accounts",
+			refs: []AccountRef{{ID: NewAccountID(), Version: 0}, {ID: NewAccountID(), Version: 1}},
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Delete", mock.Anything, mock.Anything, mock.Anything).Return(nil).Twice()
+			},
+		},
+		{
+			name: "Failed to delete some of the accounts",
+			refs: []AccountRef{{ID: failingID, Version: 0}, {ID: NewAccountID(), Version: 1}},
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Delete", mock.Anything, "/v1/organisation/accounts/"+failingID.String(), mock.Anything).Return(
+					errors.New("the api failed the request"),
+				)
+				client.On("Delete", mock.Anything, mock.Anything, map[string]string{"version": "1"}).Return(nil)
+			},
+			wantFailed: []int{0},
+			wantErr:    true,
+			wantErrMsg: "1 of 2 operations failed, first error: the api failed the request; unable to delete resource",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpUtilsMock := &mockHttpUtils{}
+			tt.httpUtilsSetup(httpUtilsMock)
+
+			accountsClient, err := NewClient(httpUtilsMock)
+			require.NoError(t, err)
+
+			results, err := accountsClient.DeleteResources(context.Background(), tt.refs, 2)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.wantErrMsg)
+			} else {
+				require.NoError(t, err)
+			}
+
+			var failed []int
+			for _, result := range results {
+				if result.Err != nil {
+					failed = append(failed, result.Index)
+				}
+			}
+			assert.Equal(t, tt.wantFailed, failed)
+
+			mock.AssertExpectationsForObjects(t, httpUtilsMock)
+		})
+	}
+}
+
+func TestRunBulkBoundsTheConcurrency(t *testing.T) {
+	var inFlight, maxInFlight int32
+
+	results, err := runBulk(context.Background(), 20, 2, func(int) AccountID { return AccountID{} }, func(ctx context.Context, i int) BulkResult {
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if current <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, current) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+
+		return BulkResult{Index: i}
+	})
+
+	require.NoError(t, err)
+	assert.Len(t, results, 20)
+	assert.LessOrEqual(t, atomic.LoadInt32(&maxInFlight), int32(2))
+}
+
+func TestRunBulkStopsWhenTheContextIsDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results, err := runBulk(ctx, 3, 1, func(int) AccountID { return AccountID{} }, func(ctx context.Context, i int) BulkResult {
+		return BulkResult{Index: i}
+	})
+
+	require.Error(t, err)
+	assert.Len(t, results, 3)
+	for _, result := range results {
+		if result.Err != nil {
+			assert.ErrorIs(t, result.Err, context.Canceled)
+		}
+	}
+}
+
+func TestFetchResources(t *testing.T) {
+	firstID, secondID, failingID := NewAccountID(), NewAccountID(), NewAccountID()
+
+	tests := []struct {
+		name           string
+		ids            []AccountID
+		concurrency    int
+		httpUtilsSetup func(*mockHttpUtils)
+		wantFetched    []AccountID
+		wantFailed     map[AccountID]string
+		wantErr        bool
+		wantErrMsg     string
+	}{
+		{
+			name:        "Successfully fetches all the accounts once",
+			ids:         []AccountID{firstID, secondID, firstID},
+			concurrency: 2,
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Get", mock.Anything, "/v1/organisation/accounts/"+firstID.String(), mock.Anything).Return(statusPayload(t, firstID, "confirmed"), nil).Once()
+				client.On("Get", mock.Anything, "/v1/organisation/accounts/"+secondID.String(), mock.Anything).Return(statusPayload(t, secondID, "confirmed"), nil).Once()
+			},
+			wantFetched: []AccountID{firstID, secondID},
+			wantFailed:  map[AccountID]string{},
+		},
+		{
+			name:        "Failed to fetch some of the accounts",
+			ids:         []AccountID{firstID, failingID},
+			concurrency: 2,
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Get", mock.Anything, "/v1/organisation/accounts/"+firstID.String(), mock.Anything).Return(statusPayload(t, firstID, "confirmed"), nil).Once()
+				client.On("Get", mock.Anything, "/v1/organisation/accounts/"+failingID.String(), mock.Anything).Return(nil, errors.New("the api failed the request")).Once()
+			},
+			wantFetched: []AccountID{firstID},
+			wantFailed:  map[AccountID]string{failingID: "the api failed the request; unable to fetch resource"},
+		},
+		{
+			name:           "Failed to fetch the accounts with an invalid concurrency",
+			ids:            []AccountID{firstID},
+			httpUtilsSetup: func(*mockHttpUtils) {},
+			wantErr:        true,
+			wantErrMsg:     "invalid concurrency 0, it must be positive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpUtilsMock := &mockHttpUtils{}
+			tt.httpUtilsSetup(httpUtilsMock)
+
+			accountsClient, err := NewClient(httpUtilsMock)
+			require.NoError(t, err)
+
+			fetched, failed, err := accountsClient.FetchResources(context.Background(), tt.ids, tt.concurrency)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.wantErrMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Len(t, fetched, len(tt.wantFetched))
+			for _, id := range tt.wantFetched {
+				assert.Equal(t, id.String(), fetched[id].ID)
+			}
+
+			failedMsgs := map[AccountID]string{}
+			for id, err := range failed {
+				failedMsgs[id] = err.Error()
+			}
+			assert.Equal(t, tt.wantFailed, failedMsgs)
+
+			mock.AssertExpectationsForObjects(t, httpUtilsMock)
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package accounts
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+type cacheEntry struct {
+	data      *AccountData
+	fetchedAt time.Time
+}
+
+// resourceCache keeps the last fetched copy of the account resources and the ones being refreshed in the background
+type resourceCache struct {
+	mu         sync.RWMutex
+	entries    map[AccountID]cacheEntry
+	refreshing map[AccountID]bool
+	refreshes  sync.WaitGroup
+}
+
+func newResourceCache() *resourceCache {
+	return &resourceCache{
+		entries:    make(map[AccountID]cacheEntry),
+		refreshing: make(map[AccountID]bool),
+	}
+}
+
+func (c *resourceCache) get(accountID AccountID) (cacheEntry, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	entry, ok := c.entries[accountID]
+	return entry, ok
+}
+
+func (c *resourceCache) set(accountID AccountID, data *AccountData, fetchedAt time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[accountID] = cacheEntry{data: data.Clone(), fetchedAt: fetchedAt}
+}
+
+func (c *resourceCache) delete(accountID AccountID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, accountID)
+}
+
+// startRefresh tells if the refresh of the account can start, false when it is already being refreshed
+func (c *resourceCache) startRefresh(accountID AccountID) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.refreshing[accountID] {
+		return false
+	}
+
+	c.refreshing[accountID] = true
+	c.refreshes.Add(1)
+	return true
+}
+
+func (c *resourceCache) endRefresh(accountID AccountID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.refreshing, accountID)
+	c.refreshes.Done()
+}
+
+// staleFallback fills the result with the cached copy of the account flagged as stale when the error means that
+// form3 is unreachable and the copy is within the max staleness bound
+func (client *Client) staleFallback(accountID AccountID, err error, result *Result) bool {
+	if client.cache == nil {
+		return false
+	}
+
+	if !isUnreachable(err) {
+		return false
+	}
+
+	entry, ok := client.cache.get(accountID)
+	if !ok || client.now().Sub(entry.fetchedAt) > client.maxStaleness {
+		return false
+	}
+
+	stale := entry.data.Clone()
+	stale.Stale = true
+
+	result.Data = stale
+	result.Cache = CacheProvenance{
+		Source:    SourceCache,
+		Stale:     true,
+		FetchedAt: entry.fetchedAt,
+	}
+	result.Warnings = append(result.Warnings, Warning{
+		Code:    WarningStaleData,
+		Message: fmt.Sprintf("form3 is unreachable, serving the copy fetched at %s", entry.fetchedAt.Format(time.RFC3339)),
+	})
+
+	return true
+}
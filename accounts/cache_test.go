@@ -0,0 +1,137 @@
+package accounts
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchResourceStaleFallback(t *testing.T) {
+	unreachableErr := &url.Error{Op: "Get", URL: "https://api.form3.tech", Err: errors.New("connection refused")}
+
+	tests := []struct {
+		name         string
+		fetchErr     error
+		cachedAt     time.Duration
+		withoutCache bool
+		wantStale    bool
+		wantErr      bool
+	}{
+		{
+			name:      "Successfully returns the stale data when form3 is unreachable",
+			fetchErr:  unreachableErr,
+			cachedAt:  -time.Minute,
+			wantStale: true,
+		},
+		{
+			name:     "Failed to fetch when the cached data is older than the max staleness",
+			fetchErr: unreachableErr,
+			cachedAt: -time.Hour,
+			wantErr:  true,
+		},
+		{
+			name:     "Failed to fetch when the api responds with an error",
+			fetchErr: errors.New("api failure with status code 404 and message: not found"),
+			cachedAt: -time.Minute,
+			wantErr:  true,
+		},
+		{
+			name:         "Failed to fetch when there is no cached data",
+			fetchErr:     unreachableErr,
+			withoutCache: true,
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Date(2021, 10, 15, 19, 28, 58, 0, time.UTC)
+			httpUtilsMock := &mockHttpUtils{}
+			httpUtilsMock.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(nil, tt.fetchErr)
+
+			accountsClient, err := NewClient(httpUtilsMock, WithStaleFallback(10*time.Minute))
+			require.NoError(t, err)
+			accountsClient.now = func() time.Time { return now }
+
+			accountID := NewAccountID()
+			if !tt.withoutCache {
+				accountsClient.cache.set(accountID, &AccountData{ID: accountID.String()}, now.Add(tt.cachedAt))
+			}
+
+			accountData, err := accountsClient.FetchResource(context.Background(), accountID)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Nil(t, accountData)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, accountID.String(), accountData.ID)
+				assert.Equal(t, tt.wantStale, accountData.Stale)
+			}
+
+			mock.AssertExpectationsForObjects(t, httpUtilsMock)
+		})
+	}
+}
+
+func TestStaleFallbackDoesNotShareTheCachedData(t *testing.T) {
+	unreachableErr := &url.Error{Op: "Get", URL: "https://api.form3.tech", Err: errors.New("connection refused")}
+	httpUtilsMock := &mockHttpUtils{}
+	httpUtilsMock.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(nil, unreachableErr)
+
+	accountsClient, err := NewClient(httpUtilsMock, WithStaleFallback(10*time.Minute))
+	require.NoError(t, err)
+
+	accountID := NewAccountID()
+	accountsClient.cache.set(accountID, &AccountData{
+		ID:         accountID.String(),
+		Attributes: &AccountAttributes{Bic: "NWBKGB22", Name: []string{"Jane Doe"}},
+		Extra:      map[string]json.RawMessage{"future_field": json.RawMessage(`true`)},
+	}, accountsClient.now())
+
+	degraded, err := accountsClient.FetchResource(context.Background(), accountID)
+	require.NoError(t, err)
+	require.True(t, degraded.Stale)
+
+	degraded.Attributes.Bic = "BARCGB22"
+	degraded.Attributes.Name[0] = "John Doe"
+	degraded.Extra["future_field"] = json.RawMessage(`false`)
+
+	entry, ok := accountsClient.cache.get(accountID)
+	require.True(t, ok)
+	assert.False(t, entry.data.Stale)
+	assert.Equal(t, "NWBKGB22", entry.data.Attributes.Bic)
+	assert.Equal(t, []string{"Jane Doe"}, entry.data.Attributes.Name)
+	assert.Equal(t, json.RawMessage(`true`), entry.data.Extra["future_field"])
+}
+
+func TestStaleFallbackCacheLifecycle(t *testing.T) {
+	httpUtilsMock := &mockHttpUtils{}
+	httpUtilsMock.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(loadTestFile("./testdata/api_response.json"), nil).Once()
+	httpUtilsMock.On("Delete", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+
+	accountsClient, err := NewClient(httpUtilsMock, WithStaleFallback(time.Minute))
+	require.NoError(t, err)
+	accountID := MustParseAccountID("ad27e265-9605-4b4b-a0e5-3003ea9cc4dc")
+
+	fetched, err := accountsClient.FetchResource(context.Background(), accountID)
+	require.NoError(t, err)
+
+	cached, ok := accountsClient.cache.get(accountID)
+	require.True(t, ok)
+	assert.Equal(t, fetched, cached.data)
+	assert.False(t, fetched.Stale)
+
+	require.NoError(t, accountsClient.DeleteResource(context.Background(), accountID, fetched.Version))
+
+	_, ok = accountsClient.cache.get(accountID)
+	assert.False(t, ok)
+
+	mock.AssertExpectationsForObjects(t, httpUtilsMock)
+}
@@ -0,0 +1,55 @@
+package accounts
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"renatoaraujo/form3-account-api-client/httputils"
+)
+
+// CallInfo is the telemetry of an operation, filled when the operation is given WithCallInfo
+type CallInfo struct {
+	// Attempts is how many times the operation was attempted including the retries
+	Attempts int
+	// Duration is how long the operation took including the retries
+	Duration time.Duration
+	// Requests is the latency breakdown of every request made to form3 by the operation
+	Requests []httputils.RequestTiming
+}
+
+// WithCallInfo fills the call info given with the telemetry of the operation once it returns, so SLO monitoring can
+// attribute the slowness to the network or to form3
+func WithCallInfo(info *CallInfo) CallOption {
+	return func(cfg *callConfig) {
+		cfg.callInfo = info
+	}
+}
+
+// recordCallInfo wraps the operation so its attempts and requests are recorded in the call info, the returned
+// function sets the duration, measured with the clock now, once the operation returns
+func recordCallInfo(ctx context.Context, now func() time.Time, info *CallInfo, operation func(ctx context.Context) error) (context.Context, func(ctx context.Context) error, func()) {
+	if info == nil {
+		return ctx, operation, func() {}
+	}
+
+	*info = CallInfo{}
+	started := now()
+
+	var mu sync.Mutex
+	ctx = httputils.ContextWithTimingObserver(ctx, func(timing httputils.RequestTiming) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		info.Requests = append(info.Requests, timing)
+	})
+
+	recorded := func(ctx context.Context) error {
+		info.Attempts++
+		return operation(ctx)
+	}
+
+	return ctx, recorded, func() {
+		info.Duration = now().Sub(started)
+	}
+}
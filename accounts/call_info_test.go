@@ -0,0 +1,60 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"renatoaraujo/form3-account-api-client/httputils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithCallInfo(t *testing.T) {
+	unreachableErr := &url.Error{Op: "Get", URL: "https://api.form3.tech", Err: errors.New("connection refused")}
+
+	httpUtilsMock := &mockHttpUtils{}
+	httpUtilsMock.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(nil, unreachableErr).Once()
+	httpUtilsMock.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(loadTestFile("./testdata/api_response.json"), nil).Once()
+
+	accountsClient, err := NewClient(httpUtilsMock, WithSLOPolicy(SLOPaymentCritical, SLOPolicy{MaxRetries: 1}))
+	require.NoError(t, err)
+
+	var info CallInfo
+	_, err = accountsClient.FetchResource(context.Background(), NewAccountID(), WithSLOClass(SLOPaymentCritical), WithCallInfo(&info))
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, info.Attempts)
+	assert.Greater(t, int64(info.Duration), int64(0))
+	mock.AssertExpectationsForObjects(t, httpUtilsMock)
+}
+
+func TestWithCallInfoRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(loadTestFile("./testdata/api_response.json"))
+	}))
+	defer server.Close()
+
+	httpClient, err := httputils.NewClient(server.URL, time.Second)
+	require.NoError(t, err)
+
+	accountsClient, err := NewClient(httpClient)
+	require.NoError(t, err)
+
+	accountID := NewAccountID()
+	var info CallInfo
+	_, err = accountsClient.FetchResource(context.Background(), accountID, WithCallInfo(&info))
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, info.Attempts)
+	require.Len(t, info.Requests, 1)
+	assert.Equal(t, "/v1/organisation/accounts/"+accountID.String(), info.Requests[0].Path)
+	assert.Equal(t, http.StatusOK, info.Requests[0].StatusCode)
+}
@@ -0,0 +1,69 @@
+package accounts
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CallOption configures a single operation of the account client
+type CallOption func(*callConfig)
+
+type callConfig struct {
+	sloClass   SLOClass
+	provenance *Provenance
+	callInfo   *CallInfo
+	priority   *Priority
+	requestID  string
+	// idempotencyKey permits retrying the creates, see WithIdempotencyKey
+	idempotencyKey string
+	// timeout is the deadline of the call, see WithCallTimeout
+	timeout time.Duration
+	// skipCache fetches the account from form3 even when a cached copy could be served, see WithoutCache
+	skipCache bool
+	// fields are the attributes of the sparse fieldsets, see WithFields
+	fields []string
+	// organisationID is the organisation the operation is sent on behalf of, it picks the credentials of the request
+	organisationID uuid.UUID
+}
+
+func newCallConfig(opts []CallOption) callConfig {
+	cfg := callConfig{}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return cfg
+}
+
+// header returns the request headers of the operation, the provenance metadata, the request id and the idempotency key
+func (cfg callConfig) header() http.Header {
+	header := cfg.provenance.header()
+	if cfg.requestID != "" {
+		header.Set(headerRequestID, cfg.requestID)
+	}
+	if cfg.idempotencyKey != "" {
+		header.Set(headerIdempotencyKey, cfg.idempotencyKey)
+	}
+
+	return header
+}
+
+// organisation returns the organisation of the operation, the scope of the client when the call has none
+func (cfg callConfig) organisation(scope uuid.UUID) uuid.UUID {
+	if cfg.organisationID != uuid.Nil {
+		return cfg.organisationID
+	}
+
+	return scope
+}
+
+// WithRequestOrganisation sends the operation on behalf of an organisation, the http client authenticates it with the
+// credentials registered for the organisation, see httputils.WithOrganisationCredentials, so one client can serve many
+// organisations, the organisation of the created account and then the one the client is scoped to are used otherwise
+func WithRequestOrganisation(organisationID uuid.UUID) CallOption {
+	return func(cfg *callConfig) {
+		cfg.organisationID = organisationID
+	}
+}
@@ -0,0 +1,96 @@
+package accounts
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"renatoaraujo/form3-account-api-client/httputils"
+)
+
+func TestClientSendsTheOperationOnBehalfOfTheOrganisation(t *testing.T) {
+	scope := uuid.MustParse("eb0bd6f5-c3f5-44b2-b677-acd23cdde73c")
+	tenant := uuid.MustParse("f199fe08-90b4-4756-9c1f-3a2352ea4933")
+
+	onBehalfOf := func(want uuid.UUID) interface{} {
+		return mock.MatchedBy(func(ctx context.Context) bool {
+			got, ok := httputils.OrganisationFromContext(ctx)
+			return ok && got == want
+		})
+	}
+	withoutOrganisation := mock.MatchedBy(func(ctx context.Context) bool {
+		_, ok := httputils.OrganisationFromContext(ctx)
+		return !ok
+	})
+
+	tests := []struct {
+		name           string
+		opts           []Option
+		call           func(*Client) error
+		httpUtilsSetup func(*mockHttpUtils)
+	}{
+		{
+			name: "Successfully fetches without an organisation",
+			call: func(client *Client) error {
+				_, err := client.FetchResource(context.Background(), NewAccountID())
+				return err
+			},
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Get", withoutOrganisation, mock.Anything, mock.Anything).Return(loadTestFile("./testdata/api_response.json"), nil).Once()
+			},
+		},
+		{
+			name: "Successfully fetches on behalf of the organisation the client is scoped to",
+			opts: []Option{WithOrganisationID(scope)},
+			call: func(client *Client) error {
+				_, err := client.FetchResource(context.Background(), NewAccountID())
+				return err
+			},
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Get", onBehalfOf(scope), mock.Anything, mock.Anything).Return(loadTestFile("./testdata/api_response.json"), nil).Once()
+			},
+		},
+		{
+			name: "Successfully fetches on behalf of the organisation of the call",
+			opts: []Option{WithOrganisationID(scope)},
+			call: func(client *Client) error {
+				_, err := client.FetchResource(context.Background(), NewAccountID(), WithRequestOrganisation(tenant))
+				return err
+			},
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Get", onBehalfOf(tenant), mock.Anything, mock.Anything).Return(loadTestFile("./testdata/api_response.json"), nil).Once()
+			},
+		},
+		{
+			name: "Successfully creates on behalf of the organisation of the account",
+			opts: []Option{WithOrganisationID(scope)},
+			call: func(client *Client) error {
+				_, err := client.CreateResource(context.Background(), &AccountData{
+					ID:             NewAccountID().String(),
+					OrganisationID: tenant.String(),
+				})
+				return err
+			},
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Post", onBehalfOf(tenant), mock.Anything, mock.Anything, mock.Anything).Return(loadTestFile("./testdata/api_response.json"), nil).Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpUtilsMock := &mockHttpUtils{}
+			tt.httpUtilsSetup(httpUtilsMock)
+
+			accountsClient, err := NewClient(httpUtilsMock, tt.opts...)
+			require.NoError(t, err)
+
+			assert.NoError(t, tt.call(&accountsClient))
+			mock.AssertExpectationsForObjects(t, httpUtilsMock)
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package accounts
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+)
+
+// CanonicalMarshal encodes the value as canonical json, the keys of the objects are sorted at every level, including
+// the Extra fields, without whitespace nor html escaping and with the numbers kept as they are, so equal values always
+// encode to the same bytes, such as to sign the payloads or to hash them
+func CanonicalMarshal(v interface{}) ([]byte, error) {
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, fmt.Errorf("%w; unable to decode the json", err)
+	}
+
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return nil, fmt.Errorf("%w; unable to encode the canonical json", err)
+	}
+
+	return bytes.TrimSuffix(buffer.Bytes(), []byte("\n")), nil
+}
+
+// PayloadHash returns the hex encoded sha256 of the canonical json of the value, such as the account data of a create
+// to derive its idempotency key, see WithIdempotencyKey
+func PayloadHash(v interface{}) (string, error) {
+	encoded, err := CanonicalMarshal(v)
+	if err != nil {
+		return "", fmt.Errorf("%w; unable to hash the payload", err)
+	}
+
+	sum := sha256.Sum256(encoded)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// WithCanonicalJSON encodes the request payloads as canonical json, see CanonicalMarshal, so a signature or a hash of
+// the sent bytes can be computed again from the account data
+func WithCanonicalJSON() Option {
+	return WithMarshaller(CanonicalMarshal)
+}
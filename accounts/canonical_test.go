@@ -0,0 +1,93 @@
+package accounts
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanonicalMarshal(t *testing.T) {
+	tests := []struct {
+		name       string
+		value      interface{}
+		want       string
+		wantErr    bool
+		wantErrMsg string
+	}{
+		{
+			name:  "Successfully sorts the keys at every level",
+			value: map[string]interface{}{"b": 1, "a": map[string]interface{}{"d": true, "c": nil}},
+			want:  `{"a":{"c":null,"d":true},"b":1}`,
+		},
+		{
+			name: "Successfully sorts the fields of the account data and its extra",
+			value: &AccountData{
+				ID:         "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc",
+				Type:       "accounts",
+				Attributes: &AccountAttributes{Bic: "NWBKGB22", BankID: "400300"},
+				Extra:      map[string]json.RawMessage{"a_field": json.RawMessage(`{"z": 1, "y": 2}`)},
+			},
+			want: `{"a_field":{"y":2,"z":1},"attributes":{"bank_id":"400300","bic":"NWBKGB22"},"id":"ad27e265-9605-4b4b-a0e5-3003ea9cc4dc","type":"accounts"}`,
+		},
+		{
+			name:  "Successfully keeps the numbers and the html characters as they are",
+			value: json.RawMessage(`{"amount": 10.50, "name": "<Jane & Doe>"}`),
+			want:  `{"amount":10.50,"name":"<Jane & Doe>"}`,
+		},
+		{
+			name:       "Failed to encode an unsupported value",
+			value:      make(chan int),
+			wantErr:    true,
+			wantErrMsg: "json: unsupported type: chan int",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CanonicalMarshal(tt.value)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.wantErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestPayloadHash(t *testing.T) {
+	first, err := PayloadHash(json.RawMessage(`{"b": 1, "a": 2}`))
+	require.NoError(t, err)
+
+	second, err := PayloadHash(map[string]int{"a": 2, "b": 1})
+	require.NoError(t, err)
+
+	assert.Equal(t, first, second)
+	assert.Len(t, first, 64)
+
+	_, err = PayloadHash(make(chan int))
+	assert.EqualError(t, err, "json: unsupported type: chan int; unable to hash the payload")
+}
+
+func TestWithCanonicalJSON(t *testing.T) {
+	httpUtilsMock := &mockHttpUtils{}
+	httpUtilsMock.On("Post", mock.Anything, mock.Anything, []byte(`{"data":{"id":"ad27e265-9605-4b4b-a0e5-3003ea9cc4dc","type":"accounts"}}`), mock.Anything).Return(
+		nil,
+		errors.New("the api failed the request"),
+	).Once()
+
+	accountsClient, err := NewClient(httpUtilsMock, WithCanonicalJSON())
+	require.NoError(t, err)
+
+	_, err = accountsClient.Create(context.Background(), &AccountData{ID: "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc", Type: "accounts"})
+	assert.EqualError(t, err, "the api failed the request; unable to create resource")
+
+	mock.AssertExpectationsForObjects(t, httpUtilsMock)
+}
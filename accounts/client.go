@@ -1,43 +1,132 @@
 package accounts
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
-	"strconv"
+	"net/http"
+	"time"
 
 	"github.com/google/uuid"
+
+	"renatoaraujo/form3-account-api-client/httputils"
+	"renatoaraujo/form3-account-api-client/resource"
 )
 
-const basePath = "/v1/organisation/accounts"
+// accountsPath is the path of the account resources, prefixed by the version of the api
+const accountsPath = "/organisation/accounts"
 
 type httpUtils interface {
-	Delete(resourcePath string, query map[string]string) error
-	Get(resourcePath string) ([]byte, error)
-	Post(resourcePath string, body []byte) ([]byte, error)
+	Delete(ctx context.Context, resourcePath string, query map[string]string) error
+	Get(ctx context.Context, resourcePath string, query map[string]string) ([]byte, error)
+	Patch(ctx context.Context, resourcePath string, body []byte, header http.Header) ([]byte, error)
+	Post(ctx context.Context, resourcePath string, body []byte, header http.Header) ([]byte, error)
 }
 
-type respUnmarshaller func([]byte, interface{}) error
-type bodyMarshaller func(v interface{}) ([]byte, error)
+// Marshaller encodes the payloads of the requests, json.Marshal by default, see WithMarshaller
+type Marshaller func(v interface{}) ([]byte, error)
+
+// Unmarshaller decodes the payloads of the responses, json.Unmarshal by default, see WithUnmarshaller
+type Unmarshaller func([]byte, interface{}) error
 
 // Client is the representation of the client to interact with the account section on form3 api see https://api-docs.form3.tech/api.html#organisation-accounts
 type Client struct {
 	http              httpUtils
-	respUnmarshaller  respUnmarshaller
-	payloadMarshaller bodyMarshaller
+	respUnmarshaller  Unmarshaller
+	payloadMarshaller Marshaller
+	cache             *resourceCache
+	maxStaleness      time.Duration
+	revalidation      *revalidation
+	sloPolicies       map[SLOClass]SLOPolicy
+	defaultTimeout    time.Duration
+	operationTimeouts map[Operation]time.Duration
+	maxPayloadSize    int
+	validate          bool
+	schemaValidator   SchemaValidator
+	organisationID    uuid.UUID
+	now               func() time.Time
+	sleeper           Sleeper
+	retryBudget       *retryBudget
+	auditor           Auditor
+	redactor          Redactor
+	closer            *closer
+	queue             *requestQueue
 }
 
 // NewClient creates a new account client instance with a http utils
-func NewClient(httpUtils httpUtils) Client {
-	return Client{
+func NewClient(httpUtils httpUtils, opts ...Option) (Client, error) {
+	client := Client{
 		http:              httpUtils,
 		respUnmarshaller:  json.Unmarshal,
 		payloadMarshaller: json.Marshal,
+		sloPolicies:       make(map[SLOClass]SLOPolicy),
+		now:               time.Now,
+		sleeper:           httputils.SystemClock{},
+		closer:            newCloser(),
+	}
+
+	for _, opt := range opts {
+		if err := opt(&client); err != nil {
+			return Client{}, fmt.Errorf("%w; invalid option", err)
+		}
+	}
+
+	if client.retryBudget != nil {
+		client.retryBudget.now = client.now
+	}
+
+	return client, nil
+}
+
+// versionedHTTP is implemented by the http clients configured with a version of the api, see httputils.WithAPIVersion
+type versionedHTTP interface {
+	APIVersion() httputils.APIVersion
+}
+
+// apiPath prefixes the path with the version of the api of the http client, the only place the version is set, the
+// default version when the http client has none
+func (client *Client) apiPath(path string) string {
+	var version httputils.APIVersion
+	if versioned, ok := client.http.(versionedHTTP); ok {
+		version = versioned.APIVersion()
+	}
+	if version == "" {
+		version = httputils.DefaultAPIVersion
 	}
+
+	return version.Path(path)
+}
+
+// resources returns the generic client of the account resources, the http client and the unmarshaller are the ones
+// of the account client
+func (client *Client) resources() *resource.Client[AccountData] {
+	return resource.NewClient[AccountData](client.http, client.apiPath(accountsPath), client.respUnmarshaller)
 }
 
 // CreateResource creates a new account resource see https://api-docs.form3.tech/api.html#organisation-accounts-create
-func (client *Client) CreateResource(accountData *AccountData) (*AccountData, error) {
+func (client *Client) CreateResource(ctx context.Context, accountData *AccountData, opts ...CallOption) (*AccountData, error) {
+	result, err := client.Create(ctx, accountData, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Data, nil
+}
+
+// Create creates a new account resource returning the result envelope
+func (client *Client) Create(ctx context.Context, accountData *AccountData, opts ...CallOption) (*Result, error) {
+	if accountData != nil && accountData.OrganisationID == "" && client.organisationID != uuid.Nil {
+		scoped := *accountData
+		scoped.OrganisationID = client.organisationID.String()
+		accountData = &scoped
+	}
+
+	if client.validate {
+		if err := accountData.Validate(); err != nil {
+			return nil, fmt.Errorf("%w; unable to create resource", client.redactor.Error(err, accountData))
+		}
+	}
+
 	requestPayload, err := client.payloadMarshaller(&Payload{
 		Data: accountData,
 	})
@@ -45,44 +134,260 @@ func (client *Client) CreateResource(accountData *AccountData) (*AccountData, er
 		return nil, fmt.Errorf("%w; unable to convert account data payload", err)
 	}
 
-	response, err := client.http.Post(basePath, requestPayload)
-	if err != nil {
+	if err := client.checkPayloadSize(requestPayload, accountData); err != nil {
 		return nil, fmt.Errorf("%w; unable to create resource", err)
 	}
 
-	responsePayload := &Payload{}
-	if err := client.respUnmarshaller(response, responsePayload); err != nil {
-		return nil, errors.New("failed to unmarshal response data")
+	if err := client.checkSchema(http.MethodPost, requestPayload); err != nil {
+		return nil, fmt.Errorf("%w; unable to create resource", client.redactor.Error(err, accountData))
+	}
+
+	cfg := newCallConfig(opts)
+	client.auditing(&cfg)
+	if cfg.organisationID == uuid.Nil && accountData != nil {
+		cfg.organisationID, _ = uuid.Parse(accountData.OrganisationID)
 	}
+	result := client.newResult()
 
-	return responsePayload.Data, nil
+	err = client.do(ctx, OperationCreate, cfg, func(ctx context.Context) (err error) {
+		result.Meta.Attempts++
+		payload, err := client.resources().CreatePayload(ctx, requestPayload, cfg.header())
+		if err != nil {
+			return err
+		}
+		result.Data, result.Meta.Links = payload.Data, payload.Links
+		return nil
+	})
+	result.Meta.Duration = client.now().Sub(result.Meta.StartedAt)
+	err = client.redactor.Error(err, accountData)
+	client.audit(ctx, cfg, accountData.auditRecord(AuditCreate), err)
+	if err != nil {
+		return nil, fmt.Errorf("%w; unable to create resource", err)
+	}
+
+	return result, nil
 }
 
 // FetchResource fetches an account resource by an account id see https://api-docs.form3.tech/api.html#organisation-accounts-fetch
-func (client *Client) FetchResource(accountID uuid.UUID) (*AccountData, error) {
-	resourcePath := fmt.Sprintf("%s/%s", basePath, accountID.String())
-	response, err := client.http.Get(resourcePath)
+func (client *Client) FetchResource(ctx context.Context, accountID AccountID, opts ...CallOption) (*AccountData, error) {
+	result, err := client.Fetch(ctx, accountID, opts...)
 	if err != nil {
+		return nil, err
+	}
+
+	return result.Data, nil
+}
+
+// Fetch fetches an account resource by an account id returning the result envelope, the cache provenance tells if
+// the data was served from the cache
+func (client *Client) Fetch(ctx context.Context, accountID AccountID, opts ...CallOption) (*Result, error) {
+	cfg := newCallConfig(opts)
+	if err := cfg.validateFields(); err != nil {
 		return nil, fmt.Errorf("%w; unable to fetch resource", err)
 	}
 
-	responsePayload := &Payload{}
-	if err := client.respUnmarshaller(response, responsePayload); err != nil {
-		return nil, errors.New("failed to unmarshal response data")
+	if result, ok := client.serveCached(accountID, cfg); ok {
+		return result, nil
 	}
 
-	return responsePayload.Data, nil
+	return client.fetch(ctx, accountID, cfg)
 }
 
-// DeleteResource deletes an account resource by an account id and version see https://api-docs.form3.tech/api.html#organisation-accounts-delete
-func (client *Client) DeleteResource(accountID uuid.UUID, version int) error {
-	resourcePath := fmt.Sprintf("%s/%s", basePath, accountID.String())
-	query := map[string]string{
-		"version": strconv.Itoa(version),
+// fetch fetches an account resource from form3, caching it when the cache is enabled
+func (client *Client) fetch(ctx context.Context, accountID AccountID, cfg callConfig) (*Result, error) {
+	var query *resource.Query
+	if len(cfg.fields) > 0 {
+		query = cfg.fieldsQuery(resource.NewQuery())
+	}
+	result := client.newResult()
+
+	err := client.do(ctx, OperationFetch, cfg, func(ctx context.Context) (err error) {
+		result.Meta.Attempts++
+		payload, err := client.resources().FetchPayload(ctx, accountID.UUID(), query)
+		if err != nil {
+			return err
+		}
+		result.Data, result.Meta.Links = payload.Data, payload.Links
+		return nil
+	})
+	result.Meta.Duration = client.now().Sub(result.Meta.StartedAt)
+	if err != nil {
+		if !cfg.skipCache && client.staleFallback(accountID, err, result) {
+			return result, nil
+		}
+		return nil, fmt.Errorf("%w; unable to fetch resource", err)
+	}
+
+	if client.cache != nil && len(cfg.fields) == 0 {
+		client.cache.set(accountID, result.Data, client.now())
+	}
+
+	return result, nil
+}
+
+// UpdateResource updates the attributes form3 permits to change of an account resource, the version of the account
+// data must be the current version of the resource otherwise a VersionConflictError is returned
+// see https://api-docs.form3.tech/api.html#organisation-accounts-patch
+func (client *Client) UpdateResource(ctx context.Context, accountData *AccountData, opts ...CallOption) (*AccountData, error) {
+	result, err := client.Update(ctx, accountData, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Data, nil
+}
+
+// Update updates the attributes form3 permits to change of an account resource returning the result envelope
+func (client *Client) Update(ctx context.Context, accountData *AccountData, opts ...CallOption) (*Result, error) {
+	accountID, err := accountData.AccountID()
+	if err != nil {
+		return nil, err
+	}
+
+	requestPayload, err := client.payloadMarshaller(newUpdatePayload(accountData))
+	if err != nil {
+		return nil, fmt.Errorf("%w; unable to convert account data payload", err)
+	}
+
+	if err := client.checkPayloadSize(requestPayload, accountData); err != nil {
+		return nil, fmt.Errorf("%w; unable to update resource", err)
+	}
+
+	cfg := newCallConfig(opts)
+	client.auditing(&cfg)
+	result := client.newResult()
+
+	err = client.do(ctx, OperationUpdate, cfg, func(ctx context.Context) (err error) {
+		result.Meta.Attempts++
+		payload, err := client.resources().UpdatePayload(ctx, accountID.UUID(), requestPayload, cfg.header())
+		if err != nil {
+			return err
+		}
+		result.Data, result.Meta.Links = payload.Data, payload.Links
+		return nil
+	})
+	result.Meta.Duration = client.now().Sub(result.Meta.StartedAt)
+	err = client.redactor.Error(err, accountData)
+	if isStatus(err, http.StatusConflict) {
+		err = &VersionConflictError{AccountID: accountID, Version: accountData.Version, Err: err}
+	}
+
+	record := accountData.auditRecord(AuditUpdate)
+	if organisationID := client.requestOrganisationID(cfg); organisationID != "" {
+		record.OrganisationID = organisationID
+	}
+	client.audit(ctx, cfg, record, err)
+	if err != nil {
+		return nil, fmt.Errorf("%w; unable to update resource", err)
+	}
+
+	if client.cache != nil {
+		client.cache.set(accountID, result.Data, client.now())
 	}
-	if err := client.http.Delete(resourcePath, query); err != nil {
+
+	return result, nil
+}
+
+// DeleteResource deletes an account resource by an account id and version, a stale version returns a
+// VersionConflictError see https://api-docs.form3.tech/api.html#organisation-accounts-delete
+func (client *Client) DeleteResource(ctx context.Context, accountID AccountID, version Version, opts ...CallOption) error {
+	cfg := newCallConfig(opts)
+	client.auditing(&cfg)
+
+	err := client.do(ctx, OperationDelete, cfg, func(ctx context.Context) error {
+		return client.resources().Delete(ctx, accountID.UUID(), int(version))
+	})
+	client.audit(ctx, cfg, AuditRecord{
+		Operation:      AuditDelete,
+		AccountID:      accountID,
+		OrganisationID: client.requestOrganisationID(cfg),
+	}, err)
+	if err != nil {
+		if isStatus(err, http.StatusConflict) {
+			err = &VersionConflictError{AccountID: accountID, Version: version, Err: err}
+		}
 		return fmt.Errorf("%w; unable to delete resource", err)
 	}
 
+	if client.cache != nil {
+		client.cache.delete(accountID)
+	}
+
 	return nil
 }
+
+// DeleteResourceLatest fetches the current version of an account resource and deletes it in one call, the version is
+// always fetched from form3, never from the cache
+func (client *Client) DeleteResourceLatest(ctx context.Context, accountID AccountID, opts ...CallOption) error {
+	accountData, err := client.FetchResource(ctx, accountID, uncached(opts)...)
+	if err != nil {
+		return err
+	}
+
+	return client.DeleteResource(ctx, accountID, accountData.Version, opts...)
+}
+
+// headHTTP is implemented by the http clients checking a resource without transferring it, see httputils.Client.Head
+type headHTTP interface {
+	Head(ctx context.Context, resourcePath string, query map[string]string) (http.Header, error)
+}
+
+// ExistsResource tells if an account resource exists, a not found response is not an error, the account is checked
+// with a HEAD request when the http client supports it so its body is not transferred
+func (client *Client) ExistsResource(ctx context.Context, accountID AccountID, opts ...CallOption) (bool, error) {
+	head, ok := client.http.(headHTTP)
+	if !ok {
+		return client.fetchExists(ctx, accountID, opts...)
+	}
+
+	err := client.do(ctx, OperationExists, newCallConfig(opts), func(ctx context.Context) error {
+		_, err := head.Head(ctx, client.resources().Path(accountID.UUID()), nil)
+		return err
+	})
+	if err != nil {
+		if isStatus(err, http.StatusNotFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("%w; unable to check resource", err)
+	}
+
+	return true, nil
+}
+
+// fetchExists tells if an account resource exists by fetching it
+func (client *Client) fetchExists(ctx context.Context, accountID AccountID, opts ...CallOption) (bool, error) {
+	_, err := client.FetchResource(ctx, accountID, opts...)
+	if err != nil {
+		if isStatus(err, http.StatusNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+// FetchOrCreateResource creates an account resource and, when it already exists, fetches and returns the existing
+// one, the returned flag tells if the account was created
+func (client *Client) FetchOrCreateResource(ctx context.Context, accountData *AccountData, opts ...CallOption) (*AccountData, bool, error) {
+	created, err := client.CreateResource(ctx, accountData, opts...)
+	if err == nil {
+		return created, true, nil
+	}
+
+	if !isStatus(err, http.StatusConflict) {
+		return nil, false, err
+	}
+
+	accountID, err := accountData.AccountID()
+	if err != nil {
+		return nil, false, err
+	}
+
+	existing, err := client.FetchResource(ctx, accountID, opts...)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return existing, false, nil
+}
@@ -1,12 +1,16 @@
 package accounts
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"io/ioutil"
+	"net/http"
 	"testing"
+	"time"
+
+	"renatoaraujo/form3-account-api-client/httputils"
 
-	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -24,7 +28,7 @@ func TestCreateResource(t *testing.T) {
 		{
 			name: "Failed to create an account because of an API error",
 			httpUtilsSetup: func(client *mockHttpUtils) {
-				client.On("Post", mock.Anything, mock.Anything).Return(
+				client.On("Post", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
 					nil,
 					errors.New("the api failed the request"),
 				)
@@ -34,7 +38,7 @@ func TestCreateResource(t *testing.T) {
 		{
 			name: "Failed to convert the response data after creating an account successfully",
 			httpUtilsSetup: func(client *mockHttpUtils) {
-				client.On("Post", mock.Anything, mock.Anything).Return(
+				client.On("Post", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
 					[]byte("the api did not failed but this is a wrong response data format"),
 					nil,
 				)
@@ -44,7 +48,7 @@ func TestCreateResource(t *testing.T) {
 		{
 			name: "Successfully creates an account",
 			httpUtilsSetup: func(client *mockHttpUtils) {
-				client.On("Post", mock.Anything, mock.Anything).Return(
+				client.On("Post", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
 					loadTestFile("./testdata/api_response.json"),
 					nil,
 				)
@@ -61,7 +65,7 @@ func TestCreateResource(t *testing.T) {
 		{
 			name: "Failed to unmarshal the successful response",
 			httpUtilsSetup: func(client *mockHttpUtils) {
-				client.On("Post", mock.Anything, mock.Anything).Return(
+				client.On("Post", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
 					loadTestFile("./testdata/api_response.json"),
 					nil,
 				)
@@ -93,8 +97,9 @@ func TestCreateResource(t *testing.T) {
 				http:              httpUtilsMock,
 				respUnmarshaller:  tt.respUnmarshaller,
 				payloadMarshaller: tt.payloadMarshaller,
+				now:               time.Now,
 			}
-			accountData, err := accountsClient.CreateResource(tt.accountData)
+			accountData, err := accountsClient.CreateResource(context.Background(), tt.accountData)
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -121,7 +126,7 @@ func TestFetchResource(t *testing.T) {
 		{
 			name: "Failed to fetch account data because of account id was not found",
 			httpUtilsSetup: func(client *mockHttpUtils) {
-				client.On("Get", mock.Anything).Return(
+				client.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(
 					nil,
 					errors.New("not found"),
 				)
@@ -131,7 +136,7 @@ func TestFetchResource(t *testing.T) {
 		{
 			name: "Failed to fetch because of an invalid format from the api response",
 			httpUtilsSetup: func(client *mockHttpUtils) {
-				client.On("Get", mock.Anything).Return(
+				client.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(
 					[]byte("invalid json"),
 					errors.New("unable to unmarshal invalid json"),
 				)
@@ -141,7 +146,7 @@ func TestFetchResource(t *testing.T) {
 		{
 			name: "Successfully fetches an account",
 			httpUtilsSetup: func(client *mockHttpUtils) {
-				client.On("Get", mock.Anything).Return(
+				client.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(
 					loadTestFile("./testdata/api_response.json"),
 					nil,
 				)
@@ -151,7 +156,7 @@ func TestFetchResource(t *testing.T) {
 		{
 			name: "Failed to unmarshal the successful response",
 			httpUtilsSetup: func(client *mockHttpUtils) {
-				client.On("Get", mock.Anything).Return(
+				client.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(
 					loadTestFile("./testdata/api_response.json"),
 					nil,
 				)
@@ -179,12 +184,12 @@ func TestFetchResource(t *testing.T) {
 				http:              httpUtilsMock,
 				respUnmarshaller:  tt.respUnmarshaller,
 				payloadMarshaller: json.Marshal,
+				now:               time.Now,
 			}
 
-			accountID, err := uuid.NewUUID()
-			require.NoError(t, err)
+			accountID := NewAccountID()
 
-			accountData, err := accountsClient.FetchResource(accountID)
+			accountData, err := accountsClient.FetchResource(context.Background(), accountID)
 			if tt.wantErr {
 				require.Error(t, err)
 			} else {
@@ -200,25 +205,169 @@ func TestFetchResource(t *testing.T) {
 	}
 }
 
+func TestUpdateResource(t *testing.T) {
+	accountID := MustParseAccountID("ad27e265-9605-4b4b-a0e5-3003ea9cc4dc")
+	country := CountryUnitedKingdom
+
+	tests := []struct {
+		name              string
+		accountData       *AccountData
+		httpUtilsSetup    func(*mockHttpUtils)
+		respUnmarshaller  func([]byte, interface{}) error
+		payloadMarshaller func(v interface{}) ([]byte, error)
+		wantErr           bool
+		wantConflict      bool
+	}{
+		{
+			name: "Successfully updates an account sending only the attributes permitted to change",
+			accountData: &AccountData{
+				ID:      accountID.String(),
+				Type:    "accounts",
+				Version: 0,
+				Attributes: &AccountAttributes{
+					BankID:  "400300",
+					Country: &country,
+					Name:    []string{"jane doe"},
+				},
+			},
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On(
+					"Patch",
+					mock.Anything,
+					"/v1/organisation/accounts/ad27e265-9605-4b4b-a0e5-3003ea9cc4dc",
+					[]byte(`{"data":{"attributes":{"bank_id":"400300","name":["jane doe"]},"id":"ad27e265-9605-4b4b-a0e5-3003ea9cc4dc","type":"accounts","version":0}}`),
+					http.Header{},
+				).Return(
+					loadTestFile("./testdata/api_response.json"),
+					nil,
+				)
+			},
+			wantErr: false,
+		},
+		{
+			name: "Failed to update an account with a stale version",
+			accountData: &AccountData{
+				ID:      accountID.String(),
+				Version: 3,
+			},
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Patch", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
+					nil,
+					&httputils.ResponseError{ErrorMessage: "invalid version", StatusCode: 409},
+				)
+			},
+			wantErr:      true,
+			wantConflict: true,
+		},
+		{
+			name:        "Failed to update an account because of an API error",
+			accountData: &AccountData{ID: accountID.String()},
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Patch", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
+					nil,
+					&httputils.ResponseError{ErrorMessage: "validation failure", StatusCode: 400},
+				)
+			},
+			wantErr: true,
+		},
+		{
+			name:        "Failed to update an account with an invalid account id",
+			accountData: &AccountData{ID: "invalid account id"},
+			wantErr:     true,
+		},
+		{
+			name:        "Failed to marshal the payload",
+			accountData: &AccountData{ID: accountID.String()},
+			payloadMarshaller: func(interface{}) ([]byte, error) {
+				return nil, errors.New("failed to marshal")
+			},
+			wantErr: true,
+		},
+		{
+			name:        "Failed to unmarshal the successful response",
+			accountData: &AccountData{ID: accountID.String()},
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Patch", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
+					loadTestFile("./testdata/api_response.json"),
+					nil,
+				)
+			},
+			respUnmarshaller: func([]byte, interface{}) error {
+				return errors.New("failed to unmarshal")
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			httpUtilsMock := &mockHttpUtils{}
+			if tt.httpUtilsSetup != nil {
+				tt.httpUtilsSetup(httpUtilsMock)
+			}
+
+			if tt.respUnmarshaller == nil {
+				tt.respUnmarshaller = json.Unmarshal
+			}
+
+			if tt.payloadMarshaller == nil {
+				tt.payloadMarshaller = json.Marshal
+			}
+
+			accountsClient := Client{
+				http:              httpUtilsMock,
+				respUnmarshaller:  tt.respUnmarshaller,
+				payloadMarshaller: tt.payloadMarshaller,
+				now:               time.Now,
+			}
+			accountData, err := accountsClient.UpdateResource(context.Background(), tt.accountData)
+
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.IsType(t, &AccountData{}, accountData)
+			}
+
+			var conflictErr *VersionConflictError
+			assert.Equal(t, tt.wantConflict, errors.As(err, &conflictErr))
+
+			mock.AssertExpectationsForObjects(t, httpUtilsMock)
+		})
+	}
+}
+
 func TestDeleteResource(t *testing.T) {
 	tests := []struct {
 		name           string
 		httpUtilsSetup func(*mockHttpUtils)
 		wantErr        bool
+		wantConflict   bool
 	}{
 		{
 			name: "Failed to delete an account with an error response from the api",
 			httpUtilsSetup: func(client *mockHttpUtils) {
-				client.On("Delete", mock.Anything, mock.Anything).Return(
+				client.On("Delete", mock.Anything, mock.Anything, mock.Anything).Return(
 					errors.New("failed because of a failure in the api"),
 				)
 			},
 			wantErr: true,
 		},
+		{
+			name: "Failed to delete an account with a stale version",
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Delete", mock.Anything, mock.Anything, mock.Anything).Return(
+					&httputils.ResponseError{ErrorMessage: "invalid version", StatusCode: 409},
+				)
+			},
+			wantErr:      true,
+			wantConflict: true,
+		},
 		{
 			name: "Successfully deletes an account",
 			httpUtilsSetup: func(client *mockHttpUtils) {
-				client.On("Delete", mock.Anything, mock.Anything).Return(nil)
+				client.On("Delete", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 			},
 			wantErr: false,
 		},
@@ -232,12 +381,64 @@ func TestDeleteResource(t *testing.T) {
 				tt.httpUtilsSetup(httpUtilsMock)
 			}
 
-			accountsClient := NewClient(httpUtilsMock)
+			accountsClient, err := NewClient(httpUtilsMock)
+			require.NoError(t, err)
+
+			accountID := NewAccountID()
+
+			err = accountsClient.DeleteResource(context.Background(), accountID, 123)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			var conflictErr *VersionConflictError
+			assert.Equal(t, tt.wantConflict, errors.As(err, &conflictErr))
 
-			accountID, err := uuid.NewUUID()
+			mock.AssertExpectationsForObjects(t, httpUtilsMock)
+		})
+	}
+}
+
+func TestDeleteResourceLatest(t *testing.T) {
+	tests := []struct {
+		name           string
+		httpUtilsSetup func(*mockHttpUtils)
+		wantErr        bool
+	}{
+		{
+			name: "Successfully deletes the current version of an account",
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(
+					loadTestFile("./testdata/api_response.json"),
+					nil,
+				)
+				client.On("Delete", mock.Anything, mock.Anything, map[string]string{"version": "12"}).Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "Failed to delete an account that could not be fetched",
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(
+					nil,
+					&httputils.ResponseError{ErrorMessage: "not found", StatusCode: 404},
+				)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpUtilsMock := &mockHttpUtils{}
+			tt.httpUtilsSetup(httpUtilsMock)
+
+			accountsClient, err := NewClient(httpUtilsMock)
 			require.NoError(t, err)
 
-			err = accountsClient.DeleteResource(accountID, 123)
+			err = accountsClient.DeleteResourceLatest(context.Background(), NewAccountID())
 			if tt.wantErr {
 				require.Error(t, err)
 			} else {
@@ -257,3 +458,252 @@ func loadTestFile(file string) []byte {
 
 	return raw
 }
+
+func TestExistsResource(t *testing.T) {
+	tests := []struct {
+		name           string
+		httpUtilsSetup func(*mockHttpUtils)
+		want           bool
+		wantErr        bool
+	}{
+		{
+			name: "Successfully tells that an account exists",
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(
+					loadTestFile("./testdata/api_response.json"),
+					nil,
+				)
+			},
+			want: true,
+		},
+		{
+			name: "Successfully tells that an account does not exist",
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(
+					nil,
+					&httputils.ResponseError{ErrorMessage: "record does not exist", StatusCode: 404},
+				)
+			},
+			want: false,
+		},
+		{
+			name: "Failed to tell if an account exists because of an API error",
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(
+					nil,
+					&httputils.ResponseError{ErrorMessage: "id is not a valid uuid", StatusCode: 400},
+				)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpUtilsMock := &mockHttpUtils{}
+			tt.httpUtilsSetup(httpUtilsMock)
+
+			accountsClient, err := NewClient(httpUtilsMock)
+			require.NoError(t, err)
+
+			got, err := accountsClient.ExistsResource(context.Background(), NewAccountID())
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.want, got)
+			mock.AssertExpectationsForObjects(t, httpUtilsMock)
+		})
+	}
+}
+
+// headHttpUtils is a http utils mock that can check a resource without transferring it
+type headHttpUtils struct {
+	mockHttpUtils
+}
+
+func (c *headHttpUtils) Head(ctx context.Context, resourcePath string, query map[string]string) (http.Header, error) {
+	ret := c.Called(ctx, resourcePath, query)
+
+	var header http.Header
+	if h, ok := ret.Get(0).(http.Header); ok {
+		header = h
+	}
+
+	return header, ret.Error(1)
+}
+
+func TestExistsResourceWithHead(t *testing.T) {
+	accountID := NewAccountID()
+	path := "/v1/organisation/accounts/" + accountID.String()
+
+	tests := []struct {
+		name           string
+		httpUtilsSetup func(*headHttpUtils)
+		want           bool
+		wantErr        bool
+		wantErrMsg     string
+	}{
+		{
+			name: "Successfully tells that an account exists",
+			httpUtilsSetup: func(client *headHttpUtils) {
+				client.On("Head", mock.Anything, path, map[string]string(nil)).Return(http.Header{}, nil).Once()
+			},
+			want: true,
+		},
+		{
+			name: "Successfully tells that an account does not exist",
+			httpUtilsSetup: func(client *headHttpUtils) {
+				client.On("Head", mock.Anything, path, map[string]string(nil)).Return(
+					nil,
+					&httputils.ResponseError{ErrorMessage: "not found", StatusCode: 404},
+				).Once()
+			},
+			want: false,
+		},
+		{
+			name: "Failed to tell if an account exists because of an API error",
+			httpUtilsSetup: func(client *headHttpUtils) {
+				client.On("Head", mock.Anything, path, map[string]string(nil)).Return(
+					nil,
+					&httputils.ResponseError{ErrorMessage: "bad request", StatusCode: 400},
+				).Once()
+			},
+			wantErr:    true,
+			wantErrMsg: "api failure with status code 400 and message: bad request; unable to check resource",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpUtilsMock := &headHttpUtils{}
+			tt.httpUtilsSetup(httpUtilsMock)
+
+			accountsClient, err := NewClient(httpUtilsMock)
+			require.NoError(t, err)
+
+			got, err := accountsClient.ExistsResource(context.Background(), accountID)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.wantErrMsg)
+			} else {
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.want, got)
+			mock.AssertExpectationsForObjects(t, httpUtilsMock)
+		})
+	}
+}
+
+func TestFetchOrCreateResource(t *testing.T) {
+	duplicateErr := &httputils.ResponseError{ErrorMessage: "it violates a duplicate constraint", StatusCode: 409}
+
+	tests := []struct {
+		name           string
+		accountData    *AccountData
+		httpUtilsSetup func(*mockHttpUtils)
+		wantCreated    bool
+		wantErr        bool
+	}{
+		{
+			name:        "Successfully creates an account",
+			accountData: &AccountData{ID: "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc"},
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Post", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
+					loadTestFile("./testdata/api_response.json"),
+					nil,
+				)
+			},
+			wantCreated: true,
+		},
+		{
+			name:        "Successfully fetches the existing account on a duplicate",
+			accountData: &AccountData{ID: "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc"},
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Post", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, duplicateErr)
+				client.On("Get", mock.Anything, "/v1/organisation/accounts/ad27e265-9605-4b4b-a0e5-3003ea9cc4dc", mock.Anything).Return(
+					loadTestFile("./testdata/api_response.json"),
+					nil,
+				)
+			},
+			wantCreated: false,
+		},
+		{
+			name:        "Failed to create an account because of an API error",
+			accountData: &AccountData{ID: "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc"},
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Post", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
+					nil,
+					&httputils.ResponseError{ErrorMessage: "validation failure", StatusCode: 400},
+				)
+			},
+			wantErr: true,
+		},
+		{
+			name:        "Failed to fetch the existing account with an invalid account id",
+			accountData: &AccountData{ID: "invalid account id"},
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Post", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, duplicateErr)
+			},
+			wantErr: true,
+		},
+		{
+			name:        "Failed to fetch the existing account on a duplicate",
+			accountData: &AccountData{ID: "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc"},
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Post", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, duplicateErr)
+				client.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("failed to perform request"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpUtilsMock := &mockHttpUtils{}
+			tt.httpUtilsSetup(httpUtilsMock)
+
+			accountsClient, err := NewClient(httpUtilsMock)
+			require.NoError(t, err)
+
+			accountData, created, err := accountsClient.FetchOrCreateResource(context.Background(), tt.accountData)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Nil(t, accountData)
+			} else {
+				require.NoError(t, err)
+				assert.IsType(t, &AccountData{}, accountData)
+			}
+
+			assert.Equal(t, tt.wantCreated, created)
+			mock.AssertExpectationsForObjects(t, httpUtilsMock)
+		})
+	}
+}
+
+func TestClientTagsTheOperationOfTheRequestError(t *testing.T) {
+	requestErr := &httputils.RequestError{
+		Method: http.MethodPost,
+		Path:   "/v1/organisation/accounts",
+		Err:    &httputils.ResponseError{ErrorMessage: "it violates a duplicate constraint", StatusCode: 409},
+	}
+
+	httpUtilsMock := &mockHttpUtils{}
+	httpUtilsMock.On("Post", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, requestErr).Once()
+
+	accountsClient, err := NewClient(httpUtilsMock)
+	require.NoError(t, err)
+
+	_, err = accountsClient.CreateResource(context.Background(), &AccountData{ID: NewAccountID().String()})
+	require.Error(t, err)
+	assert.EqualError(t, err, "POST /v1/organisation/accounts: api failure with status code 409 and message: it violates a duplicate constraint; unable to create resource")
+
+	var gotErr *httputils.RequestError
+	require.True(t, errors.As(err, &gotErr))
+	assert.Equal(t, "create", gotErr.Op)
+	assert.Equal(t, http.MethodPost, gotErr.Method)
+	assert.Equal(t, "/v1/organisation/accounts", gotErr.Path)
+}
@@ -0,0 +1,38 @@
+package accounts
+
+import (
+	"errors"
+
+	"renatoaraujo/form3-account-api-client/httputils"
+)
+
+// Clock tells the time to the cache, the retry budget and the audit records, see httputils.ManualClock for the tests
+type Clock = httputils.Clock
+
+// Sleeper waits for the delays of the retries and of WaitForResource
+type Sleeper = httputils.Sleeper
+
+// WithClock sets the clock of the client, the system clock is used by default
+func WithClock(clock Clock) Option {
+	return func(c *Client) error {
+		if clock == nil {
+			return errors.New("invalid clock, it must not be nil")
+		}
+
+		c.now = clock.Now
+		return nil
+	}
+}
+
+// WithSleeper sets the sleeper waiting between the retries and the polls of the client, the system clock is used by
+// default, a manual clock makes the tests fast-forward the delays instead of sleeping for real
+func WithSleeper(sleeper Sleeper) Option {
+	return func(c *Client) error {
+		if sleeper == nil {
+			return errors.New("invalid sleeper, it must not be nil")
+		}
+
+		c.sleeper = sleeper
+		return nil
+	}
+}
@@ -0,0 +1,78 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"renatoaraujo/form3-account-api-client/httputils"
+)
+
+func TestClientFastForwardsTheDelaysOnTheSleeper(t *testing.T) {
+	accountID := NewAccountID()
+	unreachableErr := &url.Error{Op: "Get", URL: "https://api.form3.tech", Err: errors.New("connection refused")}
+	clock := httputils.NewManualClock(time.Date(2021, 10, 15, 19, 28, 58, 0, time.UTC))
+
+	httpUtilsMock := &mockHttpUtils{}
+	httpUtilsMock.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(nil, unreachableErr).Twice()
+	httpUtilsMock.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(statusPayload(t, accountID, "pending"), nil).Once()
+	httpUtilsMock.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(statusPayload(t, accountID, "confirmed"), nil).Once()
+
+	accountsClient, err := NewClient(
+		httpUtilsMock,
+		WithClock(clock),
+		WithSleeper(clock),
+		WithSLOPolicy(SLOBatch, SLOPolicy{MaxRetries: 2, RetryDelay: time.Minute}),
+	)
+	require.NoError(t, err)
+
+	accountData, err := accountsClient.WaitForResource(context.Background(), accountID, func(accountData *AccountData) bool {
+		return *accountData.Attributes.Status == "confirmed"
+	}, ConstantBackoff(time.Hour), WithSLOClass(SLOBatch))
+	require.NoError(t, err)
+	assert.Equal(t, StatusConfirmed, *accountData.Attributes.Status)
+
+	assert.Equal(t, 2*time.Minute+time.Hour, clock.Slept())
+	assert.Equal(t, time.Date(2021, 10, 15, 20, 30, 58, 0, time.UTC), clock.Now())
+
+	mock.AssertExpectationsForObjects(t, httpUtilsMock)
+}
+
+func TestClientMeasuresTheResultsWithTheClock(t *testing.T) {
+	accountID := NewAccountID()
+	start := time.Date(2021, 10, 15, 19, 28, 58, 0, time.UTC)
+	clock := httputils.NewManualClock(start)
+	unreachableErr := &url.Error{Op: "Get", URL: "https://api.form3.tech", Err: errors.New("connection refused")}
+
+	httpUtilsMock := &mockHttpUtils{}
+	httpUtilsMock.On("Get", mock.Anything, "/v1/organisation/accounts/"+accountID.String(), mock.Anything).Return(nil, unreachableErr).Once()
+	httpUtilsMock.On("Get", mock.Anything, "/v1/organisation/accounts/"+accountID.String(), mock.Anything).Return(statusPayload(t, accountID, "confirmed"), nil).Once()
+	httpUtilsMock.On("Get", mock.Anything, "/v1/organisation/accounts", mock.Anything).Return(loadTestFile("./testdata/api_list_response.json"), nil).Once()
+
+	accountsClient, err := NewClient(
+		httpUtilsMock,
+		WithClock(clock),
+		WithSleeper(clock),
+		WithSLOPolicy(SLOBatch, SLOPolicy{MaxRetries: 1, RetryDelay: time.Minute}),
+	)
+	require.NoError(t, err)
+
+	var info CallInfo
+	result, err := accountsClient.Fetch(context.Background(), accountID, WithSLOClass(SLOBatch), WithCallInfo(&info))
+	require.NoError(t, err)
+	assert.Equal(t, start, result.Meta.StartedAt)
+	assert.Equal(t, time.Minute, result.Meta.Duration)
+	assert.Equal(t, time.Minute, info.Duration)
+
+	listResult, err := accountsClient.List(context.Background(), ListOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, start.Add(time.Minute), listResult.Meta.StartedAt)
+
+	mock.AssertExpectationsForObjects(t, httpUtilsMock)
+}
@@ -0,0 +1,69 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrClientClosed is returned by the operations performed once the account client is closed
+var ErrClientClosed = errors.New("account client is closed")
+
+// closer is the shutdown state shared by the copies of the account client
+type closer struct {
+	once sync.Once
+	done chan struct{}
+}
+
+func newCloser() *closer {
+	return &closer{done: make(chan struct{})}
+}
+
+// bind returns a context cancelled once the client is closed, the stop function must be called to release it
+func (c *closer) bind(ctx context.Context) (context.Context, context.CancelFunc) {
+	if c == nil {
+		return ctx, func() {}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	go func() {
+		select {
+		case <-c.done:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	return ctx, cancel
+}
+
+// isClosed tells if the client is closed, a nil closer is never closed
+func (c *closer) isClosed() bool {
+	if c == nil {
+		return false
+	}
+
+	select {
+	case <-c.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// Close shuts the account client down for a graceful shutdown, the operations waiting on a retry are cancelled and
+// the ones performed afterwards fail with ErrClientClosed, the http client is closed as well when it can be, such as
+// the httputils one which releases the throttled requests and closes the idle connections
+func (client *Client) Close() error {
+	if client.closer != nil {
+		client.closer.once.Do(func() {
+			close(client.closer.done)
+		})
+	}
+
+	if httpCloser, ok := client.http.(interface{ Close() error }); ok {
+		return httpCloser.Close()
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// closingHttpUtils is a http utils mock that can be closed
+type closingHttpUtils struct {
+	mockHttpUtils
+	closed int32
+}
+
+func (c *closingHttpUtils) Close() error {
+	atomic.AddInt32(&c.closed, 1)
+	return nil
+}
+
+func TestClientClose(t *testing.T) {
+	unreachableErr := &url.Error{Op: "Get", URL: "https://api.form3.tech", Err: errors.New("connection refused")}
+
+	t.Run("Failed to fetch waiting on a retry when the client is closed", func(t *testing.T) {
+		httpUtilsMock := &closingHttpUtils{}
+		httpUtilsMock.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(nil, unreachableErr).Once()
+
+		accountsClient, err := NewClient(httpUtilsMock, WithSLOPolicy(SLOBatch, SLOPolicy{MaxRetries: 3, RetryDelay: time.Minute}))
+		require.NoError(t, err)
+
+		time.AfterFunc(20*time.Millisecond, func() {
+			_ = accountsClient.Close()
+		})
+
+		started := time.Now()
+		_, err = accountsClient.FetchResource(context.Background(), NewAccountID(), WithSLOClass(SLOBatch))
+		require.Error(t, err)
+		assert.EqualError(t, err, "account client is closed; unable to fetch resource")
+		assert.True(t, errors.Is(err, ErrClientClosed))
+		assert.Less(t, time.Since(started), 5*time.Second)
+		assert.Eventually(t, func() bool {
+			return atomic.LoadInt32(&httpUtilsMock.closed) == 1
+		}, time.Second, time.Millisecond)
+
+		mock.AssertExpectationsForObjects(t, &httpUtilsMock.mockHttpUtils)
+	})
+
+	t.Run("Failed to delete once the client is closed", func(t *testing.T) {
+		httpUtilsMock := &closingHttpUtils{}
+
+		accountsClient, err := NewClient(httpUtilsMock)
+		require.NoError(t, err)
+
+		require.NoError(t, accountsClient.Close())
+		require.NoError(t, accountsClient.Close())
+
+		err = accountsClient.DeleteResource(context.Background(), NewAccountID(), 0)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, ErrClientClosed))
+		assert.Equal(t, int32(2), atomic.LoadInt32(&httpUtilsMock.closed))
+
+		httpUtilsMock.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
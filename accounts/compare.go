@@ -0,0 +1,167 @@
+package accounts
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Clone returns a deep copy of the account, the copy shares nothing with the account so either can be changed
+func (accountData *AccountData) Clone() *AccountData {
+	if accountData == nil {
+		return nil
+	}
+
+	clone := *accountData
+	clone.Attributes = accountData.Attributes.clone()
+	clone.CreatedOn = clonePtr(accountData.CreatedOn)
+	clone.ModifiedOn = clonePtr(accountData.ModifiedOn)
+	clone.Extra = cloneExtra(accountData.Extra)
+
+	if accountData.Relationships != nil {
+		clone.Relationships = &Relationships{
+			AccountEvents: accountData.Relationships.AccountEvents.clone(),
+			MasterAccount: accountData.Relationships.MasterAccount.clone(),
+		}
+	}
+
+	return &clone
+}
+
+// EqualOption configures how Equal compares the accounts
+type EqualOption func(*equalConfig)
+
+type equalConfig struct {
+	ignoreServerAssigned bool
+}
+
+// IgnoreServerAssigned ignores the fields form3 assigns, the version and the created_on and modified_on timestamps,
+// such as to compare the desired state of an account with the one fetched from form3
+func IgnoreServerAssigned() EqualOption {
+	return func(cfg *equalConfig) {
+		cfg.ignoreServerAssigned = true
+	}
+}
+
+// Equal tells if the accounts hold the same data, they are equal when they encode to the same json so an empty list
+// equals a missing one and the Stale flag is ignored
+func (accountData *AccountData) Equal(other *AccountData, opts ...EqualOption) bool {
+	if accountData == nil || other == nil {
+		return accountData == other
+	}
+
+	cfg := equalConfig{}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	a, b := *accountData, *other
+	if cfg.ignoreServerAssigned {
+		a.Version, b.Version = 0, 0
+		a.CreatedOn, b.CreatedOn = nil, nil
+		a.ModifiedOn, b.ModifiedOn = nil, nil
+	}
+
+	encodedA, errA := json.Marshal(a)
+	encodedB, errB := json.Marshal(b)
+	if errA != nil || errB != nil {
+		return false
+	}
+
+	return bytes.Equal(encodedA, encodedB)
+}
+
+func (attributes *AccountAttributes) clone() *AccountAttributes {
+	if attributes == nil {
+		return nil
+	}
+
+	clone := *attributes
+	clone.AccountClassification = clonePtr(attributes.AccountClassification)
+	clone.AccountMatchingOptOut = clonePtr(attributes.AccountMatchingOptOut)
+	clone.AccountQualifier = clonePtr(attributes.AccountQualifier)
+	clone.AlternativeNames = cloneSlice(attributes.AlternativeNames)
+	clone.Country = clonePtr(attributes.Country)
+	clone.CustomerID = clonePtr(attributes.CustomerID)
+	clone.JointAccount = clonePtr(attributes.JointAccount)
+	clone.Name = cloneSlice(attributes.Name)
+	clone.ProcessingService = clonePtr(attributes.ProcessingService)
+	clone.ReferenceMask = clonePtr(attributes.ReferenceMask)
+	clone.SecondaryIdentification = clonePtr(attributes.SecondaryIdentification)
+	clone.Status = clonePtr(attributes.Status)
+	clone.StatusReason = clonePtr(attributes.StatusReason)
+	clone.Switched = clonePtr(attributes.Switched)
+	clone.UserDefinedData = cloneSlice(attributes.UserDefinedData)
+	clone.UserDefinedInformation = clonePtr(attributes.UserDefinedInformation)
+	clone.ValidationType = clonePtr(attributes.ValidationType)
+	clone.Extra = cloneExtra(attributes.Extra)
+
+	if attributes.PrivateIdentification != nil {
+		identification := *attributes.PrivateIdentification
+		identification.Address = cloneSlice(identification.Address)
+		clone.PrivateIdentification = &identification
+	}
+
+	if attributes.OrganisationIdentification != nil {
+		identification := *attributes.OrganisationIdentification
+		identification.Address = cloneSlice(identification.Address)
+		identification.Name = cloneSlice(identification.Name)
+		identification.Representative = identification.Representative.clone()
+		if identification.Actors != nil {
+			identification.Actors = make([]Actor, len(attributes.OrganisationIdentification.Actors))
+			for i, actor := range attributes.OrganisationIdentification.Actors {
+				identification.Actors[i] = *actor.clone()
+			}
+		}
+		clone.OrganisationIdentification = &identification
+	}
+
+	return &clone
+}
+
+func (actor *Actor) clone() *Actor {
+	if actor == nil {
+		return nil
+	}
+
+	clone := *actor
+	clone.Name = cloneSlice(actor.Name)
+	return &clone
+}
+
+func (data *RelationshipData) clone() *RelationshipData {
+	if data == nil {
+		return nil
+	}
+
+	return &RelationshipData{Data: cloneSlice(data.Data)}
+}
+
+func clonePtr[T any](v *T) *T {
+	if v == nil {
+		return nil
+	}
+
+	clone := *v
+	return &clone
+}
+
+func cloneSlice[T any](s []T) []T {
+	if s == nil {
+		return nil
+	}
+
+	return append(make([]T, 0, len(s)), s...)
+}
+
+func cloneExtra(extra map[string]json.RawMessage) map[string]json.RawMessage {
+	if extra == nil {
+		return nil
+	}
+
+	clone := make(map[string]json.RawMessage, len(extra))
+	for key, value := range extra {
+		clone[key] = cloneSlice(value)
+	}
+
+	return clone
+}
@@ -0,0 +1,103 @@
+package accounts
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccountDataClone(t *testing.T) {
+	createdOn := time.Date(2022, 1, 1, 12, 0, 0, 0, time.UTC)
+	country := CountryCode("GB")
+	accountData := sensitiveAccount()
+	accountData.CreatedOn = &createdOn
+	accountData.Attributes.Country = &country
+	accountData.Attributes.OrganisationIdentification = &OrganisationIdentification{
+		Actors: []Actor{{Name: []string{"Jeff Page"}}},
+	}
+	accountData.Relationships = &Relationships{
+		MasterAccount: &RelationshipData{Data: []ResourceIdentifier{{ID: "a52d13a4-f435-4c00-cfad-f5e7ac5972df", Type: "accounts"}}},
+	}
+	accountData.Extra = map[string]json.RawMessage{"links": json.RawMessage(`{"self":"/v1"}`)}
+
+	clone := accountData.Clone()
+	assert.Equal(t, accountData, clone)
+
+	clone.Attributes.Name[0] = "Changed"
+	*clone.Attributes.Country = "FR"
+	*clone.CreatedOn = createdOn.Add(time.Hour)
+	clone.Attributes.OrganisationIdentification.Actors[0].Name[0] = "Changed"
+	clone.Relationships.MasterAccount.Data[0].ID = "changed"
+	clone.Extra["links"][2] = 'X'
+
+	assert.Equal(t, "Samantha Holder", accountData.Attributes.Name[0])
+	assert.Equal(t, CountryCode("GB"), *accountData.Attributes.Country)
+	assert.Equal(t, createdOn, *accountData.CreatedOn)
+	assert.Equal(t, "Jeff Page", accountData.Attributes.OrganisationIdentification.Actors[0].Name[0])
+	assert.Equal(t, "a52d13a4-f435-4c00-cfad-f5e7ac5972df", accountData.Relationships.MasterAccount.Data[0].ID)
+	assert.Equal(t, `{"self":"/v1"}`, string(accountData.Extra["links"]))
+
+	assert.Nil(t, (*AccountData)(nil).Clone())
+}
+
+func TestAccountDataEqual(t *testing.T) {
+	modifiedOn := time.Date(2022, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		change func(*AccountData)
+		opts   []EqualOption
+		want   bool
+	}{
+		{
+			name:   "Successfully compares a copy",
+			change: func(*AccountData) {},
+			want:   true,
+		},
+		{
+			name: "Successfully compares an empty list with a missing one",
+			change: func(accountData *AccountData) {
+				accountData.Attributes.AlternativeNames = []string{}
+				accountData.Stale = true
+			},
+			want: true,
+		},
+		{
+			name: "Successfully compares a changed attribute",
+			change: func(accountData *AccountData) {
+				accountData.Attributes.Bic = "NWBKGB33"
+			},
+			want: false,
+		},
+		{
+			name: "Successfully compares the server assigned fields",
+			change: func(accountData *AccountData) {
+				accountData.Version = 3
+				accountData.ModifiedOn = &modifiedOn
+			},
+			want: false,
+		},
+		{
+			name: "Successfully ignores the server assigned fields",
+			change: func(accountData *AccountData) {
+				accountData.Version = 3
+				accountData.ModifiedOn = &modifiedOn
+			},
+			opts: []EqualOption{IgnoreServerAssigned()},
+			want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			local := sensitiveAccount()
+			remote := local.Clone()
+			tt.change(remote)
+
+			assert.Equal(t, tt.want, local.Equal(remote, tt.opts...))
+			assert.Equal(t, tt.want, remote.Equal(local, tt.opts...))
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package accounts
+
+import "fmt"
+
+// NameMatchingStatus is the Confirmation of Payee (CoP) status of the name of a GB account
+type NameMatchingStatus string
+
+const (
+	// NameMatchingSupported is an account whose name can be confirmed to the payers
+	NameMatchingSupported NameMatchingStatus = "supported"
+	// NameMatchingSwitched is an account switched to another bank, the payers are told to use the new account
+	NameMatchingSwitched NameMatchingStatus = "switched"
+	// NameMatchingOptedOut is an account whose holder opted out of the name matching
+	NameMatchingOptedOut NameMatchingStatus = "opted_out"
+	// NameMatchingNotSupported is an account whose name can't be matched, such as a pooled account
+	NameMatchingNotSupported NameMatchingStatus = "not_supported"
+)
+
+// Validate checks the name matching status is a known one
+func (status NameMatchingStatus) Validate() error {
+	switch status {
+	case NameMatchingSupported, NameMatchingSwitched, NameMatchingOptedOut, NameMatchingNotSupported:
+		return nil
+	default:
+		return fmt.Errorf("invalid name matching status %q, it must be supported, switched, opted_out or not_supported", string(status))
+	}
+}
+
+// CoP is the Confirmation of Payee state of an account, see AccountData.CoP
+type CoP struct {
+	NameMatchingStatus NameMatchingStatus
+	// AccountMatchingOptOut tells if the account opted out of the account matching, which is apart from the name
+	// matching so an account can have its name matched but not its type
+	AccountMatchingOptOut bool
+	Switched              bool
+	// Names are the names the payers are matched against, the name of the account followed by its alternative names
+	Names []string
+}
+
+// CoP returns the Confirmation of Payee state of the account
+func (accountData *AccountData) CoP() CoP {
+	if accountData == nil || accountData.Attributes == nil {
+		return CoP{}
+	}
+
+	attributes := accountData.Attributes
+	cop := CoP{
+		NameMatchingStatus: attributes.NameMatchingStatus,
+		Names:              append(append([]string{}, attributes.Name...), attributes.AlternativeNames...),
+	}
+
+	if attributes.AccountMatchingOptOut != nil {
+		cop.AccountMatchingOptOut = *attributes.AccountMatchingOptOut
+	}
+
+	if attributes.Switched != nil {
+		cop.Switched = *attributes.Switched
+	}
+
+	return cop
+}
+
+// Matchable tells if the payers can have the name of the account confirmed
+func (cop CoP) Matchable() bool {
+	return cop.NameMatchingStatus == NameMatchingSupported && len(cop.Names) > 0
+}
+
+// copProblems checks the CoP attributes are only given on GB accounts and the name matching status is a known one
+func copProblems(attributes *AccountAttributes) []string {
+	if attributes.NameMatchingStatus == "" && attributes.AccountMatchingOptOut == nil {
+		return nil
+	}
+
+	var problems []string
+	if attributes.Country != nil && *attributes.Country != CountryUnitedKingdom {
+		problems = append(problems, fmt.Sprintf("%s does not support confirmation of payee", *attributes.Country))
+	}
+
+	if attributes.NameMatchingStatus != "" {
+		if err := attributes.NameMatchingStatus.Validate(); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+
+	return problems
+}
@@ -0,0 +1,76 @@
+package accounts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountDataCoP(t *testing.T) {
+	optOut, switched := true, false
+
+	tests := []struct {
+		name          string
+		accountData   *AccountData
+		wantCoP       CoP
+		wantMatchable bool
+	}{
+		{
+			name: "Successfully inspects a matchable account",
+			accountData: &AccountData{Attributes: &AccountAttributes{
+				Name:                  []string{"Samantha Holder"},
+				AlternativeNames:      []string{"Sam Holder"},
+				NameMatchingStatus:    NameMatchingSupported,
+				AccountMatchingOptOut: &optOut,
+				Switched:              &switched,
+			}},
+			wantCoP: CoP{
+				NameMatchingStatus:    NameMatchingSupported,
+				AccountMatchingOptOut: true,
+				Names:                 []string{"Samantha Holder", "Sam Holder"},
+			},
+			wantMatchable: true,
+		},
+		{
+			name: "Successfully inspects an account opted out of the name matching",
+			accountData: &AccountData{Attributes: &AccountAttributes{
+				Name:               []string{"Samantha Holder"},
+				NameMatchingStatus: NameMatchingOptedOut,
+			}},
+			wantCoP: CoP{
+				NameMatchingStatus: NameMatchingOptedOut,
+				Names:              []string{"Samantha Holder"},
+			},
+		},
+		{
+			name:        "Successfully inspects an account without attributes",
+			accountData: &AccountData{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cop := tt.accountData.CoP()
+			if tt.accountData.Attributes == nil {
+				assert.Equal(t, CoP{}, cop)
+			} else {
+				assert.Equal(t, tt.wantCoP, cop)
+			}
+			assert.Equal(t, tt.wantMatchable, cop.Matchable())
+		})
+	}
+}
+
+func TestAccountDataBuilderWithNameMatchingStatus(t *testing.T) {
+	accountData, err := NewAccountDataBuilder().
+		WithCountry(CountryUnitedKingdom).
+		WithBankID("400300", "GBDSC").
+		WithBic("NWBKGB22").
+		WithName("Samantha Holder").
+		WithNameMatchingStatus(NameMatchingSupported).
+		Build()
+	require.NoError(t, err)
+
+	assert.True(t, accountData.CoP().Matchable())
+}
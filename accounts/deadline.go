@@ -0,0 +1,103 @@
+package accounts
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Operation is the kind of an operation of the client, it picks the retry and the deadline policies of the operation,
+// see WithOperationTimeout
+type Operation string
+
+const (
+	// OperationCreate is the creation of an account
+	OperationCreate Operation = "create"
+	// OperationFetch is the fetch of an account
+	OperationFetch Operation = "fetch"
+	// OperationList is the list of the accounts, each page is an operation
+	OperationList Operation = "list"
+	// OperationExists is the check of the existence of an account
+	OperationExists Operation = "exists"
+	// OperationUpdate is the update of an account
+	OperationUpdate Operation = "update"
+	// OperationDelete is the deletion of an account
+	OperationDelete Operation = "delete"
+	// OperationEvents is the fetch of the audit history of an account
+	OperationEvents Operation = "events"
+)
+
+var operations = []Operation{
+	OperationCreate, OperationFetch, OperationList, OperationExists, OperationUpdate, OperationDelete, OperationEvents,
+}
+
+// Validate tells if the operation is one of the client
+func (operation Operation) Validate() error {
+	names := make([]string, len(operations))
+	for i, known := range operations {
+		if operation == known {
+			return nil
+		}
+		names[i] = string(known)
+	}
+
+	return fmt.Errorf("invalid operation %q, it must be one of %s", operation, strings.Join(names, ", "))
+}
+
+// WithDefaultTimeout sets the deadline of every operation including its retries, so a slow form3 call can't exceed
+// the budget of the handler invoking it, the deadline of the context of the caller still applies when it is earlier
+func WithDefaultTimeout(timeout time.Duration) Option {
+	return func(c *Client) error {
+		if timeout <= 0 {
+			return fmt.Errorf("invalid default timeout %s, it must be positive", timeout)
+		}
+
+		c.defaultTimeout = timeout
+		return nil
+	}
+}
+
+// WithOperationTimeout sets the deadline of an operation, overriding the default one, see WithDefaultTimeout
+func WithOperationTimeout(operation Operation, timeout time.Duration) Option {
+	return func(c *Client) error {
+		if err := operation.Validate(); err != nil {
+			return err
+		}
+
+		if timeout <= 0 {
+			return fmt.Errorf("invalid timeout %s of the operation %q, it must be positive", timeout, operation)
+		}
+
+		if c.operationTimeouts == nil {
+			c.operationTimeouts = make(map[Operation]time.Duration)
+		}
+		c.operationTimeouts[operation] = timeout
+		return nil
+	}
+}
+
+// WithCallTimeout sets the deadline of the call, overriding the one of its SLO class, of its operation and the default
+// one, a timeout that is not positive is ignored
+func WithCallTimeout(timeout time.Duration) CallOption {
+	return func(cfg *callConfig) {
+		cfg.timeout = timeout
+	}
+}
+
+// timeout returns the deadline of the operation, the one of the call, then the one of its SLO class, then the one of
+// the operation and then the default one, zero means no deadline
+func (client *Client) timeout(operation Operation, cfg callConfig, policy SLOPolicy) time.Duration {
+	if cfg.timeout > 0 {
+		return cfg.timeout
+	}
+
+	if policy.Timeout > 0 {
+		return policy.Timeout
+	}
+
+	if timeout, ok := client.operationTimeouts[operation]; ok {
+		return timeout
+	}
+
+	return client.defaultTimeout
+}
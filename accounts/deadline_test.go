@@ -0,0 +1,131 @@
+package accounts
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchResourceDeadline(t *testing.T) {
+	tests := []struct {
+		name         string
+		opts         []Option
+		callOpts     []CallOption
+		ctxTimeout   time.Duration
+		wantDeadline time.Duration
+	}{
+		{
+			name: "Successfully fetches without a deadline",
+		},
+		{
+			name:         "Successfully fetches with the default deadline",
+			opts:         []Option{WithDefaultTimeout(time.Minute)},
+			wantDeadline: time.Minute,
+		},
+		{
+			name:         "Successfully fetches with the deadline of the operation overriding the default one",
+			opts:         []Option{WithDefaultTimeout(time.Minute), WithOperationTimeout(OperationFetch, 2*time.Minute)},
+			wantDeadline: 2 * time.Minute,
+		},
+		{
+			name:         "Successfully fetches with the default deadline when another operation has its own",
+			opts:         []Option{WithDefaultTimeout(time.Minute), WithOperationTimeout(OperationList, 2*time.Minute)},
+			wantDeadline: time.Minute,
+		},
+		{
+			name: "Successfully fetches with the deadline of the slo class overriding the one of the operation",
+			opts: []Option{
+				WithOperationTimeout(OperationFetch, 2*time.Minute),
+				WithSLOPolicy(SLOPaymentCritical, SLOPolicy{Timeout: 3 * time.Minute}),
+			},
+			callOpts:     []CallOption{WithSLOClass(SLOPaymentCritical)},
+			wantDeadline: 3 * time.Minute,
+		},
+		{
+			name: "Successfully fetches with the deadline of the call overriding the others",
+			opts: []Option{
+				WithDefaultTimeout(time.Minute),
+				WithSLOPolicy(SLOPaymentCritical, SLOPolicy{Timeout: 3 * time.Minute}),
+			},
+			callOpts:     []CallOption{WithSLOClass(SLOPaymentCritical), WithCallTimeout(4 * time.Minute)},
+			wantDeadline: 4 * time.Minute,
+		},
+		{
+			name:         "Successfully fetches with the earlier deadline of the caller",
+			opts:         []Option{WithDefaultTimeout(time.Minute)},
+			ctxTimeout:   time.Second,
+			wantDeadline: time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var deadline time.Time
+			var hasDeadline bool
+
+			httpUtilsMock := &mockHttpUtils{}
+			httpUtilsMock.On("Get", mock.MatchedBy(func(ctx context.Context) bool {
+				deadline, hasDeadline = ctx.Deadline()
+				return true
+			}), mock.Anything, mock.Anything).Return(loadTestFile("./testdata/api_response.json"), nil).Once()
+
+			accountsClient, err := NewClient(httpUtilsMock, tt.opts...)
+			require.NoError(t, err)
+
+			ctx := context.Background()
+			if tt.ctxTimeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.ctxTimeout)
+				defer cancel()
+			}
+
+			started := time.Now()
+			_, err = accountsClient.FetchResource(ctx, NewAccountID(), tt.callOpts...)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantDeadline > 0, hasDeadline)
+			if tt.wantDeadline > 0 {
+				assert.WithinDuration(t, started.Add(tt.wantDeadline), deadline, 100*time.Millisecond)
+			}
+
+			mock.AssertExpectationsForObjects(t, httpUtilsMock)
+		})
+	}
+}
+
+func TestDeadlineInvalidOptions(t *testing.T) {
+	tests := []struct {
+		name       string
+		opt        Option
+		wantErrMsg string
+	}{
+		{
+			name:       "Failed to create the client with a non positive default timeout",
+			opt:        WithDefaultTimeout(0),
+			wantErrMsg: "invalid default timeout 0s, it must be positive; invalid option",
+		},
+		{
+			name:       "Failed to create the client with an unknown operation",
+			opt:        WithOperationTimeout("patch", time.Second),
+			wantErrMsg: `invalid operation "patch", it must be one of create, fetch, list, exists, update, delete, events; invalid option`,
+		},
+		{
+			name:       "Failed to create the client with a non positive timeout of an operation",
+			opt:        WithOperationTimeout(OperationFetch, -time.Second),
+			wantErrMsg: `invalid timeout -1s of the operation "fetch", it must be positive; invalid option`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewClient(&mockHttpUtils{}, tt.opt)
+
+			require.Error(t, err)
+			assert.EqualError(t, err, tt.wantErrMsg)
+		})
+	}
+}
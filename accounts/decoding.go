@@ -0,0 +1,65 @@
+package accounts
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// WithStrictDecoding fails the operations when form3 responds with fields the library does not model, useful in tests
+// to detect schema drift early, by default the unknown fields are kept in Extra
+func WithStrictDecoding() Option {
+	return func(c *Client) error {
+		c.respUnmarshaller = strictUnmarshal
+		return nil
+	}
+}
+
+// strictUnmarshal decodes the response disallowing the unknown fields, the account data decodes its own fields so its
+// unknown fields are checked after decoding
+func strictUnmarshal(data []byte, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		return err
+	}
+
+	var unknown []string
+	switch payload := v.(type) {
+	case *Payload:
+		unknown = payload.Data.unknownFields()
+	case *listPayload:
+		for _, accountData := range payload.Data {
+			unknown = append(unknown, accountData.unknownFields()...)
+		}
+	}
+
+	if len(unknown) > 0 {
+		return fmt.Errorf("json: unknown fields %s", strings.Join(unknown, ", "))
+	}
+
+	return nil
+}
+
+// unknownFields returns the names of the fields kept in Extra, sorted
+func (accountData *AccountData) unknownFields() []string {
+	if accountData == nil {
+		return nil
+	}
+
+	var unknown []string
+	for name := range accountData.Extra {
+		unknown = append(unknown, fmt.Sprintf("%q", name))
+	}
+
+	if accountData.Attributes != nil {
+		for name := range accountData.Attributes.Extra {
+			unknown = append(unknown, fmt.Sprintf("%q", "attributes."+name))
+		}
+	}
+
+	sort.Strings(unknown)
+	return unknown
+}
@@ -0,0 +1,76 @@
+package accounts
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStrictDecoding(t *testing.T) {
+	accountID := MustParseAccountID("ad27e265-9605-4b4b-a0e5-3003ea9cc4dc")
+
+	tests := []struct {
+		name       string
+		opts       []Option
+		response   string
+		wantErrMsg string
+	}{
+		{
+			name:     "Successfully fetches an account with the modelled fields in strict mode",
+			opts:     []Option{WithStrictDecoding()},
+			response: string(loadTestFile("./testdata/api_response.json")),
+		},
+		{
+			name:     "Successfully fetches an account with unknown fields in lenient mode",
+			response: `{"data": {"id": "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc", "future_field": 1}, "meta": {}}`,
+		},
+		{
+			name:       "Failed to fetch an account with unknown envelope fields in strict mode",
+			opts:       []Option{WithStrictDecoding()},
+			response:   `{"data": {"id": "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc"}, "meta": {}}`,
+			wantErrMsg: `json: unknown field "meta"; failed to unmarshal response data; unable to fetch resource`,
+		},
+		{
+			name:       "Failed to fetch an account with unknown fields in strict mode",
+			opts:       []Option{WithStrictDecoding()},
+			response:   `{"data": {"id": "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc", "future_field": 1, "attributes": {"future_attribute": true}}}`,
+			wantErrMsg: `json: unknown fields "attributes.future_attribute", "future_field"; failed to unmarshal response data; unable to fetch resource`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpUtilsMock := &mockHttpUtils{}
+			httpUtilsMock.On("Get", mock.Anything, mock.Anything, mock.Anything).Return([]byte(tt.response), nil)
+
+			accountsClient, err := NewClient(httpUtilsMock, tt.opts...)
+			require.NoError(t, err)
+
+			_, err = accountsClient.FetchResource(context.Background(), accountID)
+			if tt.wantErrMsg != "" {
+				assert.EqualError(t, err, tt.wantErrMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			mock.AssertExpectationsForObjects(t, httpUtilsMock)
+		})
+	}
+}
+
+func TestStrictDecodingList(t *testing.T) {
+	httpUtilsMock := &mockHttpUtils{}
+	httpUtilsMock.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(
+		[]byte(`{"data": [{"id": "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc", "future_field": 1}]}`),
+		nil,
+	)
+
+	accountsClient, err := NewClient(httpUtilsMock, WithStrictDecoding())
+	require.NoError(t, err)
+
+	_, err = accountsClient.ListResources(context.Background(), ListOptions{})
+	assert.EqualError(t, err, `json: unknown fields "future_field"; failed to unmarshal response data; unable to list resources`)
+}
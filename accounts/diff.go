@@ -0,0 +1,122 @@
+package accounts
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// ChangeKind is the kind of the change of an attribute from the remote account to the local one
+type ChangeKind string
+
+const (
+	// ChangeAdded is an attribute set on the local account only
+	ChangeAdded ChangeKind = "added"
+	// ChangeRemoved is an attribute set on the remote account only
+	ChangeRemoved ChangeKind = "removed"
+	// ChangeModified is an attribute set on both accounts to different values
+	ChangeModified ChangeKind = "modified"
+)
+
+// Change is an attribute differing between the local and the remote account, the values are the ones decoded from
+// json so a number is a float64 and a list is a []interface{}
+type Change struct {
+	// Path is the dotted json path of the attribute, such as attributes.bic, the lists are compared as a whole
+	Path   string
+	Kind   ChangeKind
+	Local  interface{}
+	Remote interface{}
+}
+
+// Diff returns the attributes differing between the local account, such as the desired state, and the remote one,
+// such as the one fetched from form3, sorted by path, IgnoreServerAssigned leaves the version and the timestamps out
+func Diff(local, remote *AccountData, opts ...EqualOption) ([]Change, error) {
+	cfg := equalConfig{}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	localFields, err := diffFields(local, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("%w; unable to diff the local account", err)
+	}
+
+	remoteFields, err := diffFields(remote, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("%w; unable to diff the remote account", err)
+	}
+
+	var changes []Change
+	diffObjects("", localFields, remoteFields, &changes)
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Path < changes[j].Path
+	})
+
+	return changes, nil
+}
+
+// diffFields decodes the json of the account into its fields
+func diffFields(accountData *AccountData, cfg equalConfig) (map[string]interface{}, error) {
+	fields := map[string]interface{}{}
+	if accountData == nil {
+		return fields, nil
+	}
+
+	data := *accountData
+	if cfg.ignoreServerAssigned {
+		data.Version = 0
+		data.CreatedOn = nil
+		data.ModifiedOn = nil
+	}
+
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(encoded, &fields); err != nil {
+		return nil, err
+	}
+
+	return fields, nil
+}
+
+// diffObjects appends the changes between the local and the remote objects, the nested objects are walked
+func diffObjects(prefix string, local, remote map[string]interface{}, changes *[]Change) {
+	for key, localValue := range local {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+
+		remoteValue, ok := remote[key]
+		if !ok {
+			*changes = append(*changes, Change{Path: path, Kind: ChangeAdded, Local: localValue})
+			continue
+		}
+
+		localObject, localIsObject := localValue.(map[string]interface{})
+		remoteObject, remoteIsObject := remoteValue.(map[string]interface{})
+		if localIsObject && remoteIsObject {
+			diffObjects(path, localObject, remoteObject, changes)
+			continue
+		}
+
+		if !reflect.DeepEqual(localValue, remoteValue) {
+			*changes = append(*changes, Change{Path: path, Kind: ChangeModified, Local: localValue, Remote: remoteValue})
+		}
+	}
+
+	for key, remoteValue := range remote {
+		if _, ok := local[key]; ok {
+			continue
+		}
+
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+		*changes = append(*changes, Change{Path: path, Kind: ChangeRemoved, Remote: remoteValue})
+	}
+}
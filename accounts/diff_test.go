@@ -0,0 +1,87 @@
+package accounts
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	modifiedOn := time.Date(2022, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		change      func(local, remote *AccountData)
+		opts        []EqualOption
+		wantChanges []Change
+	}{
+		{
+			name:   "Successfully diffs equal accounts",
+			change: func(local, remote *AccountData) {},
+		},
+		{
+			name: "Successfully diffs the added, modified and removed attributes",
+			change: func(local, remote *AccountData) {
+				local.Attributes.Bic = "NWBKGB33"
+				local.Attributes.Name = []string{"Samantha Holder", "Sam Holder"}
+				local.Attributes.CustomerID = Ptr("customer-1")
+				remote.Attributes.SecondaryIdentification = Ptr("A1B2C3D4")
+				remote.Attributes.PrivateIdentification.City = "London"
+			},
+			wantChanges: []Change{
+				{Path: "attributes.bic", Kind: ChangeModified, Local: "NWBKGB33", Remote: "NWBKGB22"},
+				{Path: "attributes.customer_id", Kind: ChangeAdded, Local: "customer-1"},
+				{
+					Path:   "attributes.name",
+					Kind:   ChangeModified,
+					Local:  []interface{}{"Samantha Holder", "Sam Holder"},
+					Remote: []interface{}{"Samantha Holder"},
+				},
+				{Path: "attributes.private_identification.city", Kind: ChangeRemoved, Remote: "London"},
+				{Path: "attributes.secondary_identification", Kind: ChangeRemoved, Remote: "A1B2C3D4"},
+			},
+		},
+		{
+			name: "Successfully diffs the server assigned fields",
+			change: func(local, remote *AccountData) {
+				remote.Version = 2
+				remote.ModifiedOn = &modifiedOn
+			},
+			wantChanges: []Change{
+				{Path: "modified_on", Kind: ChangeRemoved, Remote: "2022-01-01T12:00:00Z"},
+				{Path: "version", Kind: ChangeRemoved, Remote: float64(2)},
+			},
+		},
+		{
+			name: "Successfully ignores the server assigned fields",
+			change: func(local, remote *AccountData) {
+				remote.Version = 2
+				remote.ModifiedOn = &modifiedOn
+			},
+			opts: []EqualOption{IgnoreServerAssigned()},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			local := sensitiveAccount()
+			remote := local.Clone()
+			tt.change(local, remote)
+
+			changes, err := Diff(local, remote, tt.opts...)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantChanges, changes)
+		})
+	}
+}
+
+func TestDiffWithAMissingAccount(t *testing.T) {
+	changes, err := Diff(&AccountData{ID: "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc", Type: "accounts"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []Change{
+		{Path: "id", Kind: ChangeAdded, Local: "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc"},
+		{Path: "type", Kind: ChangeAdded, Local: "accounts"},
+	}, changes)
+}
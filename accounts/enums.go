@@ -0,0 +1,139 @@
+package accounts
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Classification is the classification of the account
+type Classification string
+
+const (
+	// ClassificationPersonal classifies the account as personal
+	ClassificationPersonal Classification = "Personal"
+	// ClassificationBusiness classifies the account as business
+	ClassificationBusiness Classification = "Business"
+)
+
+// Validate checks the classification is Personal or Business
+func (classification Classification) Validate() error {
+	if classification != ClassificationPersonal && classification != ClassificationBusiness {
+		return fmt.Errorf("invalid account classification %q, it must be Personal or Business", string(classification))
+	}
+
+	return nil
+}
+
+// MarshalJSON encodes the classification as it is, so an account fetched with a classification unknown to the client can be sent
+// back, Validate checks it before a create
+func (classification Classification) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(classification))
+}
+
+// UnmarshalJSON decodes the classification as it is, a new classification of form3 does not fail the decoding
+func (classification *Classification) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, (*string)(classification))
+}
+
+// CountryCode is an ISO 3166-1 alpha-2 country code
+type CountryCode string
+
+// The country codes of the countries form3 supports, any other ISO 3166-1 alpha-2 code is valid as well
+const (
+	CountryAustralia     CountryCode = "AU"
+	CountryBelgium       CountryCode = "BE"
+	CountryCanada        CountryCode = "CA"
+	CountryFrance        CountryCode = "FR"
+	CountryGermany       CountryCode = "DE"
+	CountryGreece        CountryCode = "GR"
+	CountryHongKong      CountryCode = "HK"
+	CountryItaly         CountryCode = "IT"
+	CountryLuxembourg    CountryCode = "LU"
+	CountryNetherlands   CountryCode = "NL"
+	CountryPoland        CountryCode = "PL"
+	CountryPortugal      CountryCode = "PT"
+	CountrySpain         CountryCode = "ES"
+	CountrySwitzerland   CountryCode = "CH"
+	CountryUnitedKingdom CountryCode = "GB"
+	CountryUnitedStates  CountryCode = "US"
+)
+
+var countryCodes = enumSet(`AD AE AF AG AI AL AM AO AQ AR AS AT AU AW AX AZ BA BB BD BE BF BG BH BI BJ BL BM BN BO BQ BR
+BS BT BV BW BY BZ CA CC CD CF CG CH CI CK CL CM CN CO CR CU CV CW CX CY CZ DE DJ DK DM DO DZ EC EE EG EH ER ES ET FI FJ
+FK FM FO FR GA GB GD GE GF GG GH GI GL GM GN GP GQ GR GS GT GU GW GY HK HM HN HR HT HU ID IE IL IM IN IO IQ IR IS IT JE
+JM JO JP KE KG KH KI KM KN KP KR KW KY KZ LA LB LC LI LK LR LS LT LU LV LY MA MC MD ME MF MG MH MK ML MM MN MO MP MQ MR
+MS MT MU MV MW MX MY MZ NA NC NE NF NG NI NL NO NP NR NU NZ OM PA PE PF PG PH PK PL PM PN PR PS PT PW PY QA RE RO RS RU
+RW SA SB SC SD SE SG SH SI SJ SK SL SM SN SO SR SS ST SV SX SY SZ TC TD TF TG TH TJ TK TL TM TN TO TR TT TV TW TZ UA UG
+UM US UY UZ VA VC VE VG VI VN VU WF WS YE YT ZA ZM ZW`)
+
+// Validate checks the country is an ISO 3166-1 alpha-2 code
+func (country CountryCode) Validate() error {
+	if !countryCodes[string(country)] {
+		return fmt.Errorf("invalid country %q, it must be an ISO 3166-1 alpha-2 code", string(country))
+	}
+
+	return nil
+}
+
+// MarshalJSON encodes the country as it is, so an account fetched with a country unknown to the client can be sent
+// back, Validate checks it before a create
+func (country CountryCode) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(country))
+}
+
+// UnmarshalJSON decodes the country as it is, a code missing from the known ones does not fail the decoding
+func (country *CountryCode) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, (*string)(country))
+}
+
+// Currency is an ISO 4217 currency code
+type Currency string
+
+// The currencies of the countries form3 supports, any other ISO 4217 code is valid as well
+const (
+	CurrencyAUD Currency = "AUD"
+	CurrencyCAD Currency = "CAD"
+	CurrencyCHF Currency = "CHF"
+	CurrencyEUR Currency = "EUR"
+	CurrencyGBP Currency = "GBP"
+	CurrencyHKD Currency = "HKD"
+	CurrencyPLN Currency = "PLN"
+	CurrencyUSD Currency = "USD"
+)
+
+var currencies = enumSet(`AED AFN ALL AMD ANG AOA ARS AUD AWG AZN BAM BBD BDT BGN BHD BIF BMD BND BOB BRL BSD BTN BWP BYN
+BZD CAD CDF CHF CLP CNY COP CRC CUP CVE CZK DJF DKK DOP DZD EGP ERN ETB EUR FJD FKP GBP GEL GHS GIP GMD GNF GTQ GYD HKD
+HNL HTG HUF IDR ILS INR IQD IRR ISK JMD JOD JPY KES KGS KHR KMF KPW KRW KWD KYD KZT LAK LBP LKR LRD LSL LYD MAD MDL MGA
+MKD MMK MNT MOP MRU MUR MVR MWK MXN MYR MZN NAD NGN NIO NOK NPR NZD OMR PAB PEN PGK PHP PKR PLN PYG QAR RON RSD RUB RWF
+SAR SBD SCR SDG SEK SGD SHP SLE SOS SRD SSP STN SVC SYP SZL THB TJS TMT TND TOP TRY TTD TWD TZS UAH UGX USD UYU UZS VES
+VND VUV WST XAF XCD XOF XPF YER ZAR ZMW ZWL`)
+
+// Validate checks the currency is an ISO 4217 code
+func (currency Currency) Validate() error {
+	if !currencies[string(currency)] {
+		return fmt.Errorf("invalid currency %q, it must be an ISO 4217 code", string(currency))
+	}
+
+	return nil
+}
+
+// MarshalJSON encodes the currency as it is, so an account fetched with a currency unknown to the client can be sent
+// back, Validate checks it before a create
+func (currency Currency) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(currency))
+}
+
+// UnmarshalJSON decodes the currency as it is, a code missing from the known ones does not fail the decoding
+func (currency *Currency) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, (*string)(currency))
+}
+
+func enumSet(codes string) map[string]bool {
+	set := map[string]bool{}
+	for _, code := range strings.Fields(codes) {
+		set[code] = true
+	}
+
+	return set
+}
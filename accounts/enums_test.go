@@ -0,0 +1,124 @@
+package accounts
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnumsJSON(t *testing.T) {
+	tests := []struct {
+		name               string
+		json               string
+		wantClassification Classification
+		wantCountry        CountryCode
+		wantCurrency       Currency
+	}{
+		{
+			name:               "Successfully decodes known values",
+			json:               `{"account_classification":"Business","base_currency":"EUR","country":"DE"}`,
+			wantClassification: ClassificationBusiness,
+			wantCountry:        CountryGermany,
+			wantCurrency:       CurrencyEUR,
+		},
+		{
+			name:               "Successfully decodes unknown values as they are",
+			json:               `{"account_classification":"Charity","base_currency":"XYZ","country":"XK"}`,
+			wantClassification: "Charity",
+			wantCountry:        "XK",
+			wantCurrency:       "XYZ",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attributes AccountAttributes
+			require.NoError(t, json.Unmarshal([]byte(tt.json), &attributes))
+
+			assert.Equal(t, tt.wantClassification, *attributes.AccountClassification)
+			assert.Equal(t, tt.wantCurrency, attributes.BaseCurrency)
+			assert.Equal(t, tt.wantCountry, *attributes.Country)
+		})
+	}
+}
+
+func TestEnumsRoundTrip(t *testing.T) {
+	original := `{"account_classification":"Business","base_currency":"EUR","country":"DE"}`
+
+	var attributes AccountAttributes
+	require.NoError(t, json.Unmarshal([]byte(original), &attributes))
+
+	encoded, err := json.Marshal(&attributes)
+	require.NoError(t, err)
+	assert.JSONEq(t, original, string(encoded))
+}
+
+func TestEnumsMarshalUnknown(t *testing.T) {
+	country := CountryCode("XK")
+	classification := Classification("Charity")
+
+	encoded, err := json.Marshal(&AccountAttributes{AccountClassification: &classification, BaseCurrency: "XYZ", Country: &country})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"account_classification":"Charity","base_currency":"XYZ","country":"XK"}`, string(encoded))
+}
+
+func TestFetchResourceWithUnlistedCodes(t *testing.T) {
+	accountID := NewAccountID()
+	httpUtilsMock := &mockHttpUtils{}
+	httpUtilsMock.On("Get", mock.Anything, "/v1/organisation/accounts/"+accountID.String(), mock.Anything).Return([]byte(`{"data": {
+		"id": "`+accountID.String()+`",
+		"type": "accounts",
+		"attributes": {"account_classification": "Charity", "base_currency": "XYZ", "country": "XK"}
+	}}`), nil).Once()
+
+	accountsClient, err := NewClient(httpUtilsMock)
+	require.NoError(t, err)
+
+	accountData, err := accountsClient.FetchResource(context.Background(), accountID)
+	require.NoError(t, err)
+	assert.Equal(t, CountryCode("XK"), *accountData.Attributes.Country)
+	assert.Equal(t, Currency("XYZ"), accountData.Attributes.BaseCurrency)
+	assert.Equal(t, Classification("Charity"), *accountData.Attributes.AccountClassification)
+
+	mock.AssertExpectationsForObjects(t, httpUtilsMock)
+}
+
+func TestUpdateResourceWithUnlistedCodes(t *testing.T) {
+	accountID := NewAccountID()
+	httpUtilsMock := &mockHttpUtils{}
+	httpUtilsMock.On("Get", mock.Anything, "/v1/organisation/accounts/"+accountID.String(), mock.Anything).Return([]byte(`{"data": {
+		"id": "`+accountID.String()+`",
+		"type": "accounts",
+		"attributes": {"account_classification": "Charity", "base_currency": "XYZ", "country": "XK", "bic": "NWBKGB22"}
+	}}`), nil).Once()
+	httpUtilsMock.On(
+		"Patch",
+		mock.Anything,
+		"/v1/organisation/accounts/"+accountID.String(),
+		[]byte(`{"data":{"attributes":{"account_classification":"Charity","bic":"NWBKGB33"},"id":"`+accountID.String()+`","type":"accounts","version":0}}`),
+		mock.Anything,
+	).Return([]byte(`{"data": {
+		"id": "`+accountID.String()+`",
+		"type": "accounts",
+		"version": 1,
+		"attributes": {"account_classification": "Charity", "base_currency": "XYZ", "country": "XK", "bic": "NWBKGB33"}
+	}}`), nil).Once()
+
+	accountsClient, err := NewClient(httpUtilsMock)
+	require.NoError(t, err)
+
+	accountData, err := accountsClient.FetchResource(context.Background(), accountID)
+	require.NoError(t, err)
+
+	accountData.Attributes.Bic = "NWBKGB33"
+	updated, err := accountsClient.UpdateResource(context.Background(), accountData)
+	require.NoError(t, err)
+	assert.Equal(t, Version(1), updated.Version)
+	assert.Equal(t, Classification("Charity"), *updated.Attributes.AccountClassification)
+
+	mock.AssertExpectationsForObjects(t, httpUtilsMock)
+}
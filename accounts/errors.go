@@ -0,0 +1,53 @@
+package accounts
+
+import (
+	"errors"
+	"fmt"
+
+	"renatoaraujo/form3-account-api-client/httputils"
+)
+
+// VersionConflictError is returned when the version given is not the current version of the account resource
+type VersionConflictError struct {
+	AccountID AccountID
+	Version   Version
+	Err       error
+}
+
+func (err *VersionConflictError) Error() string {
+	return fmt.Sprintf("version %d of account %s is stale: %s", err.Version, err.AccountID.String(), err.Err)
+}
+
+func (err *VersionConflictError) Unwrap() error {
+	return err.Err
+}
+
+// isStatus tells if the error is an api failure with the given status code
+func isStatus(err error, statusCode int) bool {
+	var respErr *httputils.ResponseError
+	return errors.As(err, &respErr) && respErr.StatusCode == statusCode
+}
+
+// statusCode returns the status code of the response form3 failed the request with, zero when it was not answered
+func statusCode(err error) int {
+	var respErr *httputils.ResponseError
+	if errors.As(err, &respErr) {
+		return respErr.StatusCode
+	}
+
+	var statusErr *httputils.StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode
+	}
+
+	return 0
+}
+
+// tagOperation sets the name of the operation on the request error of the failure, if any, so it tells which
+// operation of the client sent the failed request
+func tagOperation(err error, name string) {
+	var requestErr *httputils.RequestError
+	if errors.As(err, &requestErr) && requestErr.Op == "" {
+		requestErr.Op = name
+	}
+}
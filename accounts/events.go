@@ -0,0 +1,78 @@
+package accounts
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"renatoaraujo/form3-account-api-client/resource"
+)
+
+// auditPath is the path of the audit entries of the account resources, prefixed by the version of the api
+const auditPath = "/audit/entries/accounts"
+
+// AccountEvent is a change of the audit history of an account, who changed it, when and the account before and after
+// the change, before is nil for the creation and after is nil for the deletion
+type AccountEvent struct {
+	ID          string
+	ActionTime  time.Time
+	ActionedBy  string
+	Description string
+	Before      *AccountData
+	After       *AccountData
+}
+
+// Changes returns the attributes changed by the event, see Diff, the local values are the ones after the change and
+// the remote ones the ones before
+func (event AccountEvent) Changes() ([]Change, error) {
+	return Diff(event.After, event.Before)
+}
+
+// auditEntry is the form3 representation of an entry of the audit history
+type auditEntry struct {
+	ID         string          `json:"id"`
+	Type       string          `json:"type"`
+	Attributes auditAttributes `json:"attributes"`
+}
+
+type auditAttributes struct {
+	ActionTime  time.Time    `json:"action_time"`
+	ActionedBy  string       `json:"actioned_by"`
+	Description string       `json:"description"`
+	BeforeData  *AccountData `json:"before_data"`
+	AfterData   *AccountData `json:"after_data"`
+}
+
+// Events fetches the audit history of an account from the form3 audit entries, oldest first, the audit must be enabled
+// on the organisation
+func (client *Client) Events(ctx context.Context, accountID AccountID, opts ...CallOption) ([]AccountEvent, error) {
+	entries := resource.NewClient[auditEntry](client.http, client.apiPath(auditPath+"/"+accountID.String()), client.respUnmarshaller)
+
+	var found []*auditEntry
+	err := client.do(ctx, OperationEvents, newCallConfig(opts), func(ctx context.Context) (err error) {
+		found, err = entries.List(ctx, nil)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w; unable to fetch the events of resource", err)
+	}
+
+	events := make([]AccountEvent, 0, len(found))
+	for _, entry := range found {
+		events = append(events, AccountEvent{
+			ID:          entry.ID,
+			ActionTime:  entry.Attributes.ActionTime,
+			ActionedBy:  entry.Attributes.ActionedBy,
+			Description: entry.Attributes.Description,
+			Before:      entry.Attributes.BeforeData,
+			After:       entry.Attributes.AfterData,
+		})
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].ActionTime.Before(events[j].ActionTime)
+	})
+
+	return events, nil
+}
@@ -0,0 +1,102 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientEvents(t *testing.T) {
+	accountID, err := ParseAccountID("ad27e265-9605-4b4b-a0e5-3003ea9cc4dc")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		httpUtilsSetup func(*mockHttpUtils)
+		wantEvents     []string
+		wantErr        bool
+		wantErrMsg     string
+	}{
+		{
+			name: "Successfully fetches the events of the account oldest first",
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Get", mock.Anything, "/v1/audit/entries/accounts/"+accountID.String(), map[string]string(nil)).Return(
+					loadTestFile("./testdata/api_audit_response.json"),
+					nil,
+				)
+			},
+			wantEvents: []string{"account created", "account updated"},
+		},
+		{
+			name: "Failed to fetch the events when the api fails the request",
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("api failure"))
+			},
+			wantErr:    true,
+			wantErrMsg: "api failure; unable to fetch the events of resource",
+		},
+		{
+			name: "Failed to fetch the events with an invalid response",
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Get", mock.Anything, mock.Anything, mock.Anything).Return([]byte(`{`), nil)
+			},
+			wantErr:    true,
+			wantErrMsg: "unexpected end of JSON input; failed to unmarshal response data; unable to fetch the events of resource",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpUtilsMock := &mockHttpUtils{}
+			tt.httpUtilsSetup(httpUtilsMock)
+
+			accountsClient, err := NewClient(httpUtilsMock)
+			require.NoError(t, err)
+
+			events, err := accountsClient.Events(context.Background(), accountID)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.wantErrMsg)
+			} else {
+				require.NoError(t, err)
+
+				var descriptions []string
+				for _, event := range events {
+					descriptions = append(descriptions, event.Description)
+				}
+				assert.Equal(t, tt.wantEvents, descriptions)
+			}
+
+			mock.AssertExpectationsForObjects(t, httpUtilsMock)
+		})
+	}
+}
+
+func TestAccountEventChanges(t *testing.T) {
+	httpUtilsMock := &mockHttpUtils{}
+	httpUtilsMock.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(loadTestFile("./testdata/api_audit_response.json"), nil)
+
+	accountsClient, err := NewClient(httpUtilsMock)
+	require.NoError(t, err)
+
+	events, err := accountsClient.Events(context.Background(), NewAccountID())
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+
+	created, updated := events[0], events[1]
+	assert.Nil(t, created.Before)
+	assert.Equal(t, "f8e2a1c4-6b2d-4f7e-9a3b-5c1d2e3f4a5b", updated.ActionedBy)
+	assert.Equal(t, time.Date(2021, 10, 16, 9, 12, 3, 120000000, time.UTC), updated.ActionTime)
+
+	changes, err := updated.Changes()
+	require.NoError(t, err)
+	assert.Equal(t, []Change{
+		{Path: "attributes.bic", Kind: ChangeModified, Local: "NWBKGB42", Remote: "NWBKGB22"},
+		{Path: "version", Kind: ChangeAdded, Local: float64(1)},
+	}, changes)
+}
@@ -0,0 +1,105 @@
+package accounts
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// accountDataFields and accountAttributesFields are the json names of the modelled fields, the others are kept in Extra
+var (
+	accountDataFields       = jsonFields(reflect.TypeOf(AccountData{}))
+	accountAttributesFields = jsonFields(reflect.TypeOf(AccountAttributes{}))
+)
+
+type accountDataAlias AccountData
+
+// UnmarshalJSON decodes the account data keeping the fields not modelled yet in Extra
+func (accountData *AccountData) UnmarshalJSON(data []byte) error {
+	alias := (*accountDataAlias)(accountData)
+	if err := json.Unmarshal(data, alias); err != nil {
+		return err
+	}
+
+	extra, err := unknownFields(data, accountDataFields)
+	accountData.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes the account data including the fields in Extra, the modelled fields take precedence
+func (accountData AccountData) MarshalJSON() ([]byte, error) {
+	return marshalWithExtra(accountDataAlias(accountData), accountData.Extra)
+}
+
+type accountAttributesAlias AccountAttributes
+
+// UnmarshalJSON decodes the attributes keeping the attributes not modelled yet in Extra
+func (attributes *AccountAttributes) UnmarshalJSON(data []byte) error {
+	alias := (*accountAttributesAlias)(attributes)
+	if err := json.Unmarshal(data, alias); err != nil {
+		return err
+	}
+
+	extra, err := unknownFields(data, accountAttributesFields)
+	attributes.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes the attributes including the attributes in Extra, the modelled attributes take precedence
+func (attributes AccountAttributes) MarshalJSON() ([]byte, error) {
+	return marshalWithExtra(accountAttributesAlias(attributes), attributes.Extra)
+}
+
+// unknownFields returns the fields of the json object which are not known, nil when all of them are
+func unknownFields(data []byte, known map[string]bool) (map[string]json.RawMessage, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	var extra map[string]json.RawMessage
+	for name, value := range fields {
+		if known[name] {
+			continue
+		}
+
+		if extra == nil {
+			extra = map[string]json.RawMessage{}
+		}
+		extra[name] = value
+	}
+
+	return extra, nil
+}
+
+func marshalWithExtra(v interface{}, extra map[string]json.RawMessage) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || len(extra) == 0 {
+		return data, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	for name, value := range extra {
+		if _, ok := fields[name]; !ok {
+			fields[name] = value
+		}
+	}
+
+	return json.Marshal(fields)
+}
+
+func jsonFields(t reflect.Type) map[string]bool {
+	fields := map[string]bool{}
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if name != "" && name != "-" {
+			fields[name] = true
+		}
+	}
+
+	return fields
+}
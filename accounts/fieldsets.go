@@ -0,0 +1,33 @@
+package accounts
+
+import (
+	"fmt"
+	"strings"
+
+	"renatoaraujo/form3-account-api-client/resource"
+)
+
+// WithFields asks form3 to send back only the attributes given, such as name and iban, the json:api sparse fieldsets
+// of the fetches and the lists, the other attributes of the returned accounts are left empty and the fetched accounts
+// are not kept for the stale fallback
+func WithFields(fields ...string) CallOption {
+	return func(cfg *callConfig) {
+		cfg.fields = append(cfg.fields, fields...)
+	}
+}
+
+// validateFields checks the fields of the sparse fieldsets are not empty and have no comma
+func (cfg callConfig) validateFields() error {
+	for _, field := range cfg.fields {
+		if field == "" || strings.Contains(field, ",") {
+			return fmt.Errorf("invalid field %q, it must not be empty nor contain a comma", field)
+		}
+	}
+
+	return nil
+}
+
+// fieldsQuery adds the sparse fieldsets of the call to the query
+func (cfg callConfig) fieldsQuery(query *resource.Query) *resource.Query {
+	return query.Fields("accounts", cfg.fields...)
+}
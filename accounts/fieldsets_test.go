@@ -0,0 +1,95 @@
+package accounts
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithFields(t *testing.T) {
+	accountID := NewAccountID()
+	partial := []byte(`{"data": {"id": "` + accountID.String() + `", "type": "accounts", "attributes": {"name": ["john doe"]}}}`)
+
+	tests := []struct {
+		name           string
+		call           func(*Client) error
+		httpUtilsSetup func(*mockHttpUtils)
+		wantErr        bool
+		wantErrMsg     string
+	}{
+		{
+			name: "Successfully fetches the partial account",
+			call: func(client *Client) error {
+				accountData, err := client.FetchResource(context.Background(), accountID, WithFields("name", "iban"))
+				if err == nil {
+					assert.Equal(t, []string{"john doe"}, accountData.Attributes.Name)
+					assert.Nil(t, accountData.Attributes.Country)
+				}
+				return err
+			},
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Get", mock.Anything, "/v1/organisation/accounts/"+accountID.String(), map[string]string{
+					"fields[accounts]": "name,iban",
+				}).Return(partial, nil).Once()
+			},
+		},
+		{
+			name: "Successfully lists the partial accounts",
+			call: func(client *Client) error {
+				_, err := client.ListResources(context.Background(), ListOptions{PageSize: 10}, WithFields("name"))
+				return err
+			},
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Get", mock.Anything, "/v1/organisation/accounts", map[string]string{
+					"fields[accounts]": "name",
+					"page[size]":       "10",
+				}).Return(loadTestFile("./testdata/api_list_response.json"), nil).Once()
+			},
+		},
+		{
+			name: "Failed to fetch with an empty field",
+			call: func(client *Client) error {
+				_, err := client.FetchResource(context.Background(), accountID, WithFields(""))
+				return err
+			},
+			wantErr:    true,
+			wantErrMsg: `invalid field "", it must not be empty nor contain a comma; unable to fetch resource`,
+		},
+		{
+			name: "Failed to list with a field containing a comma",
+			call: func(client *Client) error {
+				_, err := client.ListResources(context.Background(), ListOptions{}, WithFields("name,iban"))
+				return err
+			},
+			wantErr:    true,
+			wantErrMsg: `invalid field "name,iban", it must not be empty nor contain a comma; unable to list resources`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpUtilsMock := &mockHttpUtils{}
+			if tt.httpUtilsSetup != nil {
+				tt.httpUtilsSetup(httpUtilsMock)
+			}
+
+			accountsClient, err := NewClient(httpUtilsMock, WithStaleFallback(time.Minute))
+			require.NoError(t, err)
+
+			err = tt.call(&accountsClient)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.wantErrMsg)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Empty(t, accountsClient.cache.entries, "the partial accounts must not be cached")
+
+			mock.AssertExpectationsForObjects(t, httpUtilsMock)
+		})
+	}
+}
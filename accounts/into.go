@@ -0,0 +1,29 @@
+package accounts
+
+import (
+	"context"
+	"fmt"
+)
+
+// FetchResourceInto fetches an account resource decoding its data into v, so the callers with their own account
+// model don't need to convert it from AccountData
+func (client *Client) FetchResourceInto(ctx context.Context, accountID AccountID, v interface{}, opts ...CallOption) error {
+	err := client.do(ctx, OperationFetch, newCallConfig(opts), func(ctx context.Context) error {
+		return client.resources().FetchInto(ctx, accountID.UUID(), v)
+	})
+	if err != nil {
+		return fmt.Errorf("%w; unable to fetch resource", err)
+	}
+
+	return nil
+}
+
+// FetchResourceAs fetches an account resource decoding its data into a new T, see FetchResourceInto
+func FetchResourceAs[T any](ctx context.Context, client *Client, accountID AccountID, opts ...CallOption) (*T, error) {
+	v := new(T)
+	if err := client.FetchResourceInto(ctx, accountID, v, opts...); err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
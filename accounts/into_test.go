@@ -0,0 +1,112 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type ownAccount struct {
+	ID         string `json:"id"`
+	Version    int    `json:"version"`
+	Attributes struct {
+		BankID string   `json:"bank_id"`
+		Name   []string `json:"name"`
+	} `json:"attributes"`
+}
+
+func TestFetchResourceInto(t *testing.T) {
+	accountID := MustParseAccountID("ad27e265-9605-4b4b-a0e5-3003ea9cc4dc")
+
+	tests := []struct {
+		name             string
+		httpUtilsSetup   func(*mockHttpUtils)
+		respUnmarshaller func([]byte, interface{}) error
+		wantErrMsg       string
+	}{
+		{
+			name: "Successfully fetches an account into the model of the caller",
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Get", mock.Anything, "/v1/organisation/accounts/"+accountID.String(), mock.Anything).Return(
+					loadTestFile("./testdata/api_response.json"),
+					nil,
+				)
+			},
+		},
+		{
+			name: "Failed to fetch an account into the model of the caller",
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("not found"))
+			},
+			wantErrMsg: "not found; unable to fetch resource",
+		},
+		{
+			name: "Failed to decode an account into the model of the caller",
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(
+					loadTestFile("./testdata/api_response.json"),
+					nil,
+				)
+			},
+			respUnmarshaller: func([]byte, interface{}) error {
+				return errors.New("failed to unmarshal")
+			},
+			wantErrMsg: "failed to unmarshal; failed to unmarshal response data; unable to fetch resource",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpUtilsMock := &mockHttpUtils{}
+			tt.httpUtilsSetup(httpUtilsMock)
+
+			accountsClient, err := NewClient(httpUtilsMock)
+			require.NoError(t, err)
+			if tt.respUnmarshaller != nil {
+				accountsClient.respUnmarshaller = tt.respUnmarshaller
+			}
+
+			account := &ownAccount{}
+			err = accountsClient.FetchResourceInto(context.Background(), accountID, account)
+			if tt.wantErrMsg != "" {
+				assert.EqualError(t, err, tt.wantErrMsg)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, accountID.String(), account.ID)
+				assert.Equal(t, 12, account.Version)
+				assert.Equal(t, "400300", account.Attributes.BankID)
+				assert.Equal(t, []string{"john doe"}, account.Attributes.Name)
+			}
+
+			mock.AssertExpectationsForObjects(t, httpUtilsMock)
+		})
+	}
+}
+
+func TestFetchResourceAs(t *testing.T) {
+	accountID := MustParseAccountID("ad27e265-9605-4b4b-a0e5-3003ea9cc4dc")
+
+	httpUtilsMock := &mockHttpUtils{}
+	httpUtilsMock.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(
+		loadTestFile("./testdata/api_response.json"),
+		nil,
+	).Once()
+	httpUtilsMock.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("not found")).Once()
+
+	accountsClient, err := NewClient(httpUtilsMock)
+	require.NoError(t, err)
+
+	account, err := FetchResourceAs[ownAccount](context.Background(), &accountsClient, accountID)
+	require.NoError(t, err)
+	assert.Equal(t, "400300", account.Attributes.BankID)
+
+	account, err = FetchResourceAs[ownAccount](context.Background(), &accountsClient, accountID)
+	assert.EqualError(t, err, "not found; unable to fetch resource")
+	assert.Nil(t, account)
+
+	mock.AssertExpectationsForObjects(t, httpUtilsMock)
+}
@@ -0,0 +1,130 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"renatoaraujo/form3-account-api-client/resource"
+)
+
+// maxPageSize is the largest page size accepted by form3
+const maxPageSize = 100
+
+// FilterField is the name of an attribute the account list can be filtered by
+type FilterField string
+
+const (
+	// FilterBankID filters the accounts by bank id
+	FilterBankID FilterField = "bank_id"
+	// FilterAccountNumber filters the accounts by account number
+	FilterAccountNumber FilterField = "account_number"
+	// FilterIban filters the accounts by iban
+	FilterIban FilterField = "iban"
+	// FilterCustomerID filters the accounts by customer id
+	FilterCustomerID FilterField = "customer_id"
+	// FilterCountry filters the accounts by country
+	FilterCountry FilterField = "country"
+	// FilterOrganisationID filters the accounts by organisation id, see WithOrganisationID
+	FilterOrganisationID FilterField = "organisation_id"
+)
+
+var filterFields = map[FilterField]bool{
+	FilterBankID:         true,
+	FilterAccountNumber:  true,
+	FilterIban:           true,
+	FilterCustomerID:     true,
+	FilterCountry:        true,
+	FilterOrganisationID: true,
+}
+
+// ListOptions are the filters, the page and the order of the account list, zero values are not sent
+type ListOptions struct {
+	Filters    map[FilterField]string
+	PageNumber int
+	PageSize   int
+	// Sort orders the accounts by the fields in turn, such as SortByCreatedOnDesc then SortByID, so the pages come
+	// back in a deterministic order
+	Sort []Sort
+}
+
+// Validate checks the filter names, the page and the sort of the list options
+func (opts ListOptions) Validate() error {
+	for field, value := range opts.Filters {
+		if !filterFields[field] {
+			return fmt.Errorf("invalid filter %q, it must be one of bank_id, account_number, iban, customer_id, country or organisation_id", field)
+		}
+
+		if value == "" {
+			return fmt.Errorf("invalid filter %q, the value must not be empty", field)
+		}
+	}
+
+	if opts.PageNumber < 0 {
+		return errors.New("invalid page number, it must not be negative")
+	}
+
+	if opts.PageSize < 0 || opts.PageSize > maxPageSize {
+		return fmt.Errorf("invalid page size, it must be between 0 and %d", maxPageSize)
+	}
+
+	return validateSort(opts.Sort)
+}
+
+// query returns the query of the list options
+func (opts ListOptions) query() *resource.Query {
+	query := resource.NewQuery().PageNumber(opts.PageNumber).PageSize(opts.PageSize).Sort(sortParams(opts.Sort)...)
+	for field, value := range opts.Filters {
+		query.Filter(string(field), value)
+	}
+
+	return query
+}
+
+// ListResources lists the account resources matching the filters of the list options
+// see https://api-docs.form3.tech/api.html#organisation-accounts-list
+func (client *Client) ListResources(ctx context.Context, listOpts ListOptions, opts ...CallOption) ([]*AccountData, error) {
+	result, err := client.List(ctx, listOpts, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Data, nil
+}
+
+// List lists the account resources matching the filters of the list options returning the list envelope, its links
+// point to the other pages
+func (client *Client) List(ctx context.Context, listOpts ListOptions, opts ...CallOption) (*ListResult, error) {
+	cfg := newCallConfig(opts)
+	if err := listOpts.Validate(); err != nil {
+		return nil, fmt.Errorf("%w; unable to list resources", err)
+	}
+
+	if err := cfg.validateFields(); err != nil {
+		return nil, fmt.Errorf("%w; unable to list resources", err)
+	}
+
+	query := cfg.fieldsQuery(listOpts.query())
+	if !query.HasFilter(string(FilterOrganisationID)) && client.organisationID != uuid.Nil {
+		query.Filter(string(FilterOrganisationID), client.organisationID.String())
+	}
+
+	result := &ListResult{Meta: ResponseMeta{StartedAt: client.now()}}
+	err := client.do(ctx, OperationList, cfg, func(ctx context.Context) error {
+		result.Meta.Attempts++
+		page, err := client.resources().ListPage(ctx, query)
+		if err != nil {
+			return err
+		}
+		result.Data, result.Meta.Links = page.Data, page.Links
+		return nil
+	})
+	result.Meta.Duration = client.now().Sub(result.Meta.StartedAt)
+	if err != nil {
+		return nil, fmt.Errorf("%w; unable to list resources", err)
+	}
+
+	return result, nil
+}
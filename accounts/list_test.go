@@ -0,0 +1,145 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListResources(t *testing.T) {
+	tests := []struct {
+		name             string
+		listOpts         ListOptions
+		httpUtilsSetup   func(*mockHttpUtils)
+		respUnmarshaller func([]byte, interface{}) error
+		wantLen          int
+		wantErr          bool
+		wantErrMsg       string
+	}{
+		{
+			name: "Successfully lists the accounts matching the filters",
+			listOpts: ListOptions{
+				Filters:    map[FilterField]string{FilterCountry: "GB", FilterBankID: "400300"},
+				PageNumber: 2,
+				PageSize:   50,
+			},
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Get", mock.Anything, "/v1/organisation/accounts", map[string]string{
+					"filter[country]": "GB",
+					"filter[bank_id]": "400300",
+					"page[number]":    "2",
+					"page[size]":      "50",
+				}).Return(loadTestFile("./testdata/api_list_response.json"), nil)
+			},
+			wantLen: 2,
+		},
+		{
+			name: "Successfully lists the accounts without options",
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Get", mock.Anything, "/v1/organisation/accounts", map[string]string{}).Return(
+					loadTestFile("./testdata/api_list_response.json"),
+					nil,
+				)
+			},
+			wantLen: 2,
+		},
+		{
+			name:       "Failed to list the accounts with an unknown filter",
+			listOpts:   ListOptions{Filters: map[FilterField]string{"name": "john doe"}},
+			wantErr:    true,
+			wantErrMsg: `invalid filter "name", it must be one of bank_id, account_number, iban, customer_id, country or organisation_id; unable to list resources`,
+		},
+		{
+			name:       "Failed to list the accounts with an empty filter value",
+			listOpts:   ListOptions{Filters: map[FilterField]string{FilterIban: ""}},
+			wantErr:    true,
+			wantErrMsg: `invalid filter "iban", the value must not be empty; unable to list resources`,
+		},
+		{
+			name:     "Successfully lists the accounts in order",
+			listOpts: ListOptions{Sort: []Sort{SortByCreatedOnDesc, SortByID}},
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Get", mock.Anything, "/v1/organisation/accounts", map[string]string{
+					"sort": "-created_on,id",
+				}).Return(loadTestFile("./testdata/api_list_response.json"), nil)
+			},
+			wantLen: 2,
+		},
+		{
+			name:       "Failed to list the accounts with an unknown sort",
+			listOpts:   ListOptions{Sort: []Sort{"-bank_id"}},
+			wantErr:    true,
+			wantErrMsg: `invalid sort "-bank_id", it must be one of created_on, modified_on or id, prefixed with - to sort in descending order; unable to list resources`,
+		},
+		{
+			name:       "Failed to list the accounts sorting a field twice",
+			listOpts:   ListOptions{Sort: []Sort{SortByCreatedOn, SortByCreatedOnDesc}},
+			wantErr:    true,
+			wantErrMsg: `invalid sort "-created_on", the field created_on is sorted more than once; unable to list resources`,
+		},
+		{
+			name:       "Failed to list the accounts with a negative page number",
+			listOpts:   ListOptions{PageNumber: -1},
+			wantErr:    true,
+			wantErrMsg: "invalid page number, it must not be negative; unable to list resources",
+		},
+		{
+			name:       "Failed to list the accounts with a page size too large",
+			listOpts:   ListOptions{PageSize: 1000},
+			wantErr:    true,
+			wantErrMsg: "invalid page size, it must be between 0 and 100; unable to list resources",
+		},
+		{
+			name: "Failed to list the accounts because of an API error",
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("the api failed the request"))
+			},
+			wantErr:    true,
+			wantErrMsg: "the api failed the request; unable to list resources",
+		},
+		{
+			name: "Failed to unmarshal the successful response",
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(
+					loadTestFile("./testdata/api_list_response.json"),
+					nil,
+				)
+			},
+			respUnmarshaller: func([]byte, interface{}) error {
+				return errors.New("failed to unmarshal")
+			},
+			wantErr:    true,
+			wantErrMsg: "failed to unmarshal; failed to unmarshal response data; unable to list resources",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpUtilsMock := &mockHttpUtils{}
+			if tt.httpUtilsSetup != nil {
+				tt.httpUtilsSetup(httpUtilsMock)
+			}
+
+			accountsClient, err := NewClient(httpUtilsMock)
+			require.NoError(t, err)
+			if tt.respUnmarshaller != nil {
+				accountsClient.respUnmarshaller = tt.respUnmarshaller
+			}
+
+			accountData, err := accountsClient.ListResources(context.Background(), tt.listOpts)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.wantErrMsg)
+			} else {
+				require.NoError(t, err)
+			}
+
+			assert.Len(t, accountData, tt.wantLen)
+			mock.AssertExpectationsForObjects(t, httpUtilsMock)
+		})
+	}
+}
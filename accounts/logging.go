@@ -0,0 +1,14 @@
+package accounts
+
+import "encoding/json"
+
+// String returns the json of the account with its sensitive attributes masked, so logging the account by accident
+// doesn't leak the names and the identifiers of the holder
+func (accountData AccountData) String() string {
+	redacted, err := json.Marshal(Redactor{}.Account(&accountData))
+	if err != nil {
+		return "AccountData{" + accountData.ID + "}"
+	}
+
+	return string(redacted)
+}
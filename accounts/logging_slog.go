@@ -0,0 +1,36 @@
+//go:build go1.21
+
+package accounts
+
+import (
+	"log/slog"
+	"strings"
+)
+
+// LogValue returns the account as a slog group with its sensitive attributes masked, see String
+func (accountData AccountData) LogValue() slog.Value {
+	redacted := Redactor{}.Account(&accountData)
+
+	attrs := []slog.Attr{
+		slog.String("id", redacted.ID),
+		slog.String("organisation_id", redacted.OrganisationID),
+		slog.Int("version", int(redacted.Version)),
+	}
+
+	if attributes := redacted.Attributes; attributes != nil {
+		group := []any{
+			slog.String("name", strings.Join(attributes.Name, " ")),
+			slog.String("account_number", attributes.AccountNumber),
+			slog.String("iban", attributes.Iban),
+			slog.String("bank_id", attributes.BankID),
+			slog.String("bic", attributes.Bic),
+			slog.String("base_currency", string(attributes.BaseCurrency)),
+		}
+		if attributes.Country != nil {
+			group = append(group, slog.String("country", string(*attributes.Country)))
+		}
+		attrs = append(attrs, slog.Group("attributes", group...))
+	}
+
+	return slog.GroupValue(attrs...)
+}
@@ -0,0 +1,26 @@
+//go:build go1.21
+
+package accounts
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccountDataLogValue(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	logger.Info("created", "account", sensitiveAccount())
+
+	logged := buf.String()
+	assert.Contains(t, logged, "account.id=ad27e265-9605-4b4b-a0e5-3003ea9cc4dc")
+	assert.Contains(t, logged, "account.attributes.iban=******************6819")
+	assert.Contains(t, logged, "account.attributes.name=[REDACTED]")
+	assert.Contains(t, logged, "account.attributes.bic=NWBKGB22")
+	assert.NotContains(t, logged, "Samantha Holder")
+	assert.NotContains(t, logged, "41426819")
+}
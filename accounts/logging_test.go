@@ -0,0 +1,23 @@
+package accounts
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccountDataString(t *testing.T) {
+	accountData := sensitiveAccount()
+
+	for _, printed := range []string{
+		accountData.String(),
+		fmt.Sprintf("%v", accountData),
+		fmt.Sprintf("%+v", *accountData),
+	} {
+		assert.Contains(t, printed, `"iban":"******************6819"`)
+		assert.Contains(t, printed, `"name":["[REDACTED]"]`)
+		assert.NotContains(t, printed, "Samantha Holder")
+		assert.NotContains(t, printed, "41426819")
+	}
+}
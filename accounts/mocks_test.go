@@ -2,20 +2,25 @@
 
 package accounts
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	context "context"
+	http "net/http"
+
+	mock "github.com/stretchr/testify/mock"
+)
 
 // httpUtils is an autogenerated mock type for the httpUtils type
 type mockHttpUtils struct {
 	mock.Mock
 }
 
-// Delete provides a mock function with given fields: resourcePath, query
-func (_m *mockHttpUtils) Delete(resourcePath string, query map[string]string) error {
-	ret := _m.Called(resourcePath, query)
+// Delete provides a mock function with given fields: ctx, resourcePath, query
+func (_m *mockHttpUtils) Delete(ctx context.Context, resourcePath string, query map[string]string) error {
+	ret := _m.Called(ctx, resourcePath, query)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, map[string]string) error); ok {
-		r0 = rf(resourcePath, query)
+	if rf, ok := ret.Get(0).(func(context.Context, string, map[string]string) error); ok {
+		r0 = rf(ctx, resourcePath, query)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -23,13 +28,36 @@ func (_m *mockHttpUtils) Delete(resourcePath string, query map[string]string) er
 	return r0
 }
 
-// Get provides a mock function with given fields: resourcePath
-func (_m *mockHttpUtils) Get(resourcePath string) ([]byte, error) {
-	ret := _m.Called(resourcePath)
+// Get provides a mock function with given fields: ctx, resourcePath, query
+func (_m *mockHttpUtils) Get(ctx context.Context, resourcePath string, query map[string]string) ([]byte, error) {
+	ret := _m.Called(ctx, resourcePath, query)
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func(context.Context, string, map[string]string) []byte); ok {
+		r0 = rf(ctx, resourcePath, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, map[string]string) error); ok {
+		r1 = rf(ctx, resourcePath, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Patch provides a mock function with given fields: ctx, resourcePath, body, header
+func (_m *mockHttpUtils) Patch(ctx context.Context, resourcePath string, body []byte, header http.Header) ([]byte, error) {
+	ret := _m.Called(ctx, resourcePath, body, header)
 
 	var r0 []byte
-	if rf, ok := ret.Get(0).(func(string) []byte); ok {
-		r0 = rf(resourcePath)
+	if rf, ok := ret.Get(0).(func(context.Context, string, []byte, http.Header) []byte); ok {
+		r0 = rf(ctx, resourcePath, body, header)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]byte)
@@ -37,8 +65,8 @@ func (_m *mockHttpUtils) Get(resourcePath string) ([]byte, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(resourcePath)
+	if rf, ok := ret.Get(1).(func(context.Context, string, []byte, http.Header) error); ok {
+		r1 = rf(ctx, resourcePath, body, header)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -46,13 +74,13 @@ func (_m *mockHttpUtils) Get(resourcePath string) ([]byte, error) {
 	return r0, r1
 }
 
-// Post provides a mock function with given fields: resourcePath, body
-func (_m *mockHttpUtils) Post(resourcePath string, body []byte) ([]byte, error) {
-	ret := _m.Called(resourcePath, body)
+// Post provides a mock function with given fields: ctx, resourcePath, body, header
+func (_m *mockHttpUtils) Post(ctx context.Context, resourcePath string, body []byte, header http.Header) ([]byte, error) {
+	ret := _m.Called(ctx, resourcePath, body, header)
 
 	var r0 []byte
-	if rf, ok := ret.Get(0).(func(string, []byte) []byte); ok {
-		r0 = rf(resourcePath, body)
+	if rf, ok := ret.Get(0).(func(context.Context, string, []byte, http.Header) []byte); ok {
+		r0 = rf(ctx, resourcePath, body, header)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]byte)
@@ -60,8 +88,8 @@ func (_m *mockHttpUtils) Post(resourcePath string, body []byte) ([]byte, error)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, []byte) error); ok {
-		r1 = rf(resourcePath, body)
+	if rf, ok := ret.Get(1).(func(context.Context, string, []byte, http.Header) error); ok {
+		r1 = rf(ctx, resourcePath, body, header)
 	} else {
 		r1 = ret.Error(1)
 	}
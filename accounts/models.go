@@ -1,5 +1,12 @@
 package accounts
 
+import (
+	"encoding/json"
+	"time"
+
+	"renatoaraujo/form3-account-api-client/resource"
+)
+
 // AccountData represents an account in the form3 org section.
 // See https://api-docs.form3.tech/api.html#organisation-accounts for
 // more information about fields.
@@ -8,35 +15,154 @@ type AccountData struct {
 	ID             string             `json:"id,omitempty"`
 	OrganisationID string             `json:"organisation_id,omitempty"`
 	Type           string             `json:"type,omitempty"`
-	Version        int                `json:"version,omitempty"`
+	Version        Version            `json:"version,omitempty"`
+	Relationships  *Relationships     `json:"relationships,omitempty"`
+	CreatedOn      *time.Time         `json:"created_on,omitempty"`
+	ModifiedOn     *time.Time         `json:"modified_on,omitempty"`
+
+	// Stale is set when the data was served from the cache because form3 was unreachable, see WithStaleFallback
+	Stale bool `json:"-"`
+
+	// Extra holds the fields form3 sent which are not modelled yet, they are sent back when the data is encoded
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
-// AccountAttributes represents the detail attributes of the account
+// AccountAttributes represents the detail attributes of the account, the optional booleans and free text attributes
+// are pointers so an unset attribute, omitted from the json, is told apart from a false or an empty one, see Ptr, the
+// identifiers such as the bank_id are never valid empty so they are plain strings
 type AccountAttributes struct {
-	AccountClassification   *string  `json:"account_classification,omitempty"`
-	AccountMatchingOptOut   *bool    `json:"account_matching_opt_out,omitempty"`
-	AccountNumber           string   `json:"account_number,omitempty"`
-	AccountQualifier        string   `json:"acceptance_qualifier,omitempty"`
-	AlternativeNames        []string `json:"alternative_names,omitempty"`
-	BankID                  string   `json:"bank_id,omitempty"`
-	BankIDCode              string   `json:"bank_id_code,omitempty"`
-	BaseCurrency            string   `json:"base_currency,omitempty"`
-	Bic                     string   `json:"bic,omitempty"`
-	CustomerID              string   `json:"customer_id,omitempty"`
-	Country                 *string  `json:"country,omitempty"`
-	Iban                    string   `json:"iban,omitempty"`
-	JointAccount            *bool    `json:"joint_account,omitempty"`
-	Name                    []string `json:"name,omitempty"`
-	ProcessingService       string   `json:"processing_service,omitempty"`
-	ReferenceMask           string   `json:"reference_mask,omitempty"`
-	SecondaryIdentification string   `json:"secondary_identification,omitempty"`
-	Status                  *string  `json:"status,omitempty"`
-	Switched                *bool    `json:"switched,omitempty"`
-	UserDefinedInformation  string   `json:"user_defined_information,omitempty"`
-	ValidationType          string   `json:"validation_type,omitempty"`
+	AccountClassification      *Classification             `json:"account_classification,omitempty"`
+	AccountMatchingOptOut      *bool                       `json:"account_matching_opt_out,omitempty"`
+	AccountNumber              string                      `json:"account_number,omitempty"`
+	AccountQualifier           *string                     `json:"acceptance_qualifier,omitempty"`
+	AlternativeNames           []string                    `json:"alternative_names,omitempty"`
+	BankID                     string                      `json:"bank_id,omitempty"`
+	BankIDCode                 string                      `json:"bank_id_code,omitempty"`
+	BaseCurrency               Currency                    `json:"base_currency,omitempty"`
+	Bic                        string                      `json:"bic,omitempty"`
+	CustomerID                 *string                     `json:"customer_id,omitempty"`
+	Country                    *CountryCode                `json:"country,omitempty"`
+	Iban                       string                      `json:"iban,omitempty"`
+	JointAccount               *bool                       `json:"joint_account,omitempty"`
+	Name                       []string                    `json:"name,omitempty"`
+	NameMatchingStatus         NameMatchingStatus          `json:"name_matching_status,omitempty"`
+	OrganisationIdentification *OrganisationIdentification `json:"organisation_identification,omitempty"`
+	PrivateIdentification      *PrivateIdentification      `json:"private_identification,omitempty"`
+	ProcessingService          *string                     `json:"processing_service,omitempty"`
+	ReferenceMask              *string                     `json:"reference_mask,omitempty"`
+	SecondaryIdentification    *string                     `json:"secondary_identification,omitempty"`
+	Status                     *AccountStatus              `json:"status,omitempty"`
+	StatusReason               *string                     `json:"status_reason,omitempty"`
+	Switched                   *bool                       `json:"switched,omitempty"`
+	UserDefinedData            []UserDefinedData           `json:"user_defined_data,omitempty"`
+	UserDefinedInformation     *string                     `json:"user_defined_information,omitempty"`
+	ValidationType             *string                     `json:"validation_type,omitempty"`
+
+	// Extra holds the attributes form3 sent which are not modelled yet, they are sent back when the attributes are
+	// encoded
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// PrivateIdentification identifies the person holding a personal account
+type PrivateIdentification struct {
+	Address        []string `json:"address,omitempty"`
+	BirthCountry   string   `json:"birth_country,omitempty"`
+	BirthDate      string   `json:"birth_date,omitempty"`
+	City           string   `json:"city,omitempty"`
+	Country        string   `json:"country,omitempty"`
+	Identification string   `json:"identification,omitempty"`
+}
+
+// OrganisationIdentification identifies the organisation holding a business account
+type OrganisationIdentification struct {
+	Actors             []Actor  `json:"actors,omitempty"`
+	Address            []string `json:"address,omitempty"`
+	City               string   `json:"city,omitempty"`
+	Country            string   `json:"country,omitempty"`
+	Identification     string   `json:"identification,omitempty"`
+	Name               []string `json:"name,omitempty"`
+	RegistrationNumber string   `json:"registration_number,omitempty"`
+	Representative     *Actor   `json:"representative,omitempty"`
+	TaxResidency       string   `json:"tax_residency,omitempty"`
+}
+
+// Actor is a person acting on behalf of an organisation
+type Actor struct {
+	BirthDate string   `json:"birth_date,omitempty"`
+	Name      []string `json:"name,omitempty"`
+	Residency string   `json:"residency,omitempty"`
+}
+
+// UserDefinedData is a key value pair stored with the account
+type UserDefinedData struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// Relationships are the resources related to the account, such as the master account of a virtual account
+type Relationships struct {
+	AccountEvents *RelationshipData `json:"account_events,omitempty"`
+	MasterAccount *RelationshipData `json:"master_account,omitempty"`
+}
+
+// RelationshipData lists the identifiers of the related resources
+type RelationshipData struct {
+	Data []ResourceIdentifier `json:"data"`
+}
+
+// ResourceIdentifier identifies a related resource
+type ResourceIdentifier struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
 }
 
 // Payload represents payload structure of the api request or response
-type Payload struct {
-	Data *AccountData `json:"data"`
+type Payload = resource.Payload[AccountData]
+
+// listPayload represents the payload of the list response
+type listPayload = resource.ListPayload[AccountData]
+
+// Links are the links to the resource and to the pages of a list
+type Links = resource.Links
+
+// updatePayload represents the payload of the update request, the version is always sent as it is used for the
+// optimistic locking
+type updatePayload struct {
+	Data updateData `json:"data"`
+}
+
+type updateData struct {
+	Attributes *AccountAttributes `json:"attributes,omitempty"`
+	ID         string             `json:"id"`
+	Type       string             `json:"type"`
+	Version    Version            `json:"version"`
+}
+
+// newUpdatePayload creates the update payload keeping only the attributes form3 permits to change
+func newUpdatePayload(accountData *AccountData) *updatePayload {
+	payload := &updatePayload{
+		Data: updateData{
+			ID:      accountData.ID,
+			Type:    accountData.Type,
+			Version: accountData.Version,
+		},
+	}
+
+	if attributes := accountData.Attributes; attributes != nil {
+		payload.Data.Attributes = &AccountAttributes{
+			AccountClassification:   attributes.AccountClassification,
+			AccountMatchingOptOut:   attributes.AccountMatchingOptOut,
+			AlternativeNames:        attributes.AlternativeNames,
+			BankID:                  attributes.BankID,
+			BankIDCode:              attributes.BankIDCode,
+			Bic:                     attributes.Bic,
+			Name:                    attributes.Name,
+			SecondaryIdentification: attributes.SecondaryIdentification,
+			Status:                  attributes.Status,
+			Switched:                attributes.Switched,
+			UserDefinedInformation:  attributes.UserDefinedInformation,
+		}
+	}
+
+	return payload
 }
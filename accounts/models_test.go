@@ -0,0 +1,106 @@
+package accounts
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPayloadRoundTrip(t *testing.T) {
+	var original struct {
+		Data json.RawMessage `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(loadTestFile("./testdata/api_full_response.json"), &original))
+
+	accountData := &AccountData{}
+	require.NoError(t, json.Unmarshal(original.Data, accountData))
+
+	encoded, err := json.Marshal(accountData)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(original.Data), string(encoded))
+}
+
+func TestFullResponseAttributes(t *testing.T) {
+	payload := &Payload{}
+	require.NoError(t, json.Unmarshal(loadTestFile("./testdata/api_full_response.json"), payload))
+
+	attributes := payload.Data.Attributes
+	assert.Equal(t, NameMatchingSupported, attributes.NameMatchingStatus)
+	assert.Equal(t, "unspecified", Value(attributes.StatusReason))
+	assert.Equal(t, []UserDefinedData{{Key: "team", Value: "payments"}}, attributes.UserDefinedData)
+	assert.Equal(t, "10000000", attributes.OrganisationIdentification.RegistrationNumber)
+	assert.Equal(t, []string{"jane doe"}, attributes.OrganisationIdentification.Representative.Name)
+	assert.Equal(t, "a52d13a4-f435-4c00-cfad-f5e7ac5972df", payload.Data.Relationships.MasterAccount.Data[0].ID)
+	assert.Equal(t, "account_events", payload.Data.Relationships.AccountEvents.Data[0].Type)
+}
+
+func TestUnknownFieldsAreKept(t *testing.T) {
+	original := `{
+		"attributes": {"bank_id": "400300", "future_attribute": {"enabled": true}},
+		"future_field": "2021-10-15T19:28:58.772Z",
+		"id": "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc",
+		"type": "accounts"
+	}`
+
+	accountData := &AccountData{}
+	require.NoError(t, json.Unmarshal([]byte(original), accountData))
+
+	assert.Equal(t, json.RawMessage(`"2021-10-15T19:28:58.772Z"`), accountData.Extra["future_field"])
+	assert.Equal(t, json.RawMessage(`{"enabled": true}`), accountData.Attributes.Extra["future_attribute"])
+	assert.NotContains(t, accountData.Extra, "id")
+	assert.NotContains(t, accountData.Attributes.Extra, "bank_id")
+
+	encoded, err := json.Marshal(accountData)
+	require.NoError(t, err)
+	assert.JSONEq(t, original, string(encoded))
+}
+
+func TestModelledFieldsTakePrecedenceOverExtra(t *testing.T) {
+	encoded, err := json.Marshal(&AccountAttributes{
+		BankID: "400300",
+		Extra:  map[string]json.RawMessage{"bank_id": json.RawMessage(`"000000"`)},
+	})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"bank_id": "400300"}`, string(encoded))
+}
+
+func TestOptionalAttributesRoundTrip(t *testing.T) {
+	tests := []struct {
+		name       string
+		attributes string
+	}{
+		{name: "Unset attributes stay omitted", attributes: `{}`},
+		{name: "False booleans are kept", attributes: `{"joint_account": false, "switched": false, "account_matching_opt_out": false}`},
+		{name: "Empty strings are kept", attributes: `{"secondary_identification": "", "user_defined_information": "", "customer_id": ""}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attributes := &AccountAttributes{}
+			require.NoError(t, json.Unmarshal([]byte(tt.attributes), attributes))
+
+			encoded, err := json.Marshal(attributes)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.attributes, string(encoded))
+		})
+	}
+}
+
+func TestUpdatePayloadClearsAnAttribute(t *testing.T) {
+	payload := newUpdatePayload(&AccountData{
+		ID:         "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc",
+		Attributes: &AccountAttributes{UserDefinedInformation: Ptr(""), Switched: Ptr(false)},
+	})
+
+	encoded, err := json.Marshal(payload.Data.Attributes)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"user_defined_information": "", "switched": false}`, string(encoded))
+}
+
+func TestValue(t *testing.T) {
+	assert.Equal(t, "", Value[string](nil))
+	assert.Equal(t, "A1B2C3D4", Value(Ptr("A1B2C3D4")))
+	assert.False(t, Value[bool](nil))
+}
@@ -0,0 +1,17 @@
+package accounts
+
+// Ptr returns a pointer to the value, to set the optional attributes such as Ptr(false) for the joint_account or
+// Ptr("") to clear the user_defined_information
+func Ptr[T any](value T) *T {
+	return &value
+}
+
+// Value returns the value of an optional attribute, the zero value when it is not set
+func Value[T any](value *T) T {
+	if value == nil {
+		var zero T
+		return zero
+	}
+
+	return *value
+}
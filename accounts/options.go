@@ -0,0 +1,68 @@
+package accounts
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Option configures the account client created by NewClient, the options validate their input and the invalid ones
+// make NewClient fail
+type Option func(*Client) error
+
+// WithStaleFallback enables the graceful degradation mode, when form3 is unreachable FetchResource returns the last
+// fetched copy of the account flagged as stale, as long as it is not older than the given max staleness
+func WithStaleFallback(maxStaleness time.Duration) Option {
+	return func(c *Client) error {
+		if maxStaleness <= 0 {
+			return fmt.Errorf("invalid max staleness %s, it must be positive", maxStaleness)
+		}
+
+		c.cache = newResourceCache()
+		c.maxStaleness = maxStaleness
+		return nil
+	}
+}
+
+// WithMarshaller sets the encoding of the request payloads, such as a canonical json for signing them, the payloads
+// given to it are the ones json.Marshal encodes by default, a panic of it fails the operation with a
+// httputils.PanicError
+func WithMarshaller(marshaller Marshaller) Option {
+	return func(c *Client) error {
+		if marshaller == nil {
+			return errors.New("invalid marshaller, it must not be nil")
+		}
+
+		c.payloadMarshaller = safeMarshaller(marshaller)
+		return nil
+	}
+}
+
+// WithUnmarshaller sets the decoding of the response payloads, json.Unmarshal is used by default, it replaces the
+// strict decoding of WithStrictDecoding and the other way around, the last option given wins, a panic of it fails the
+// operation with a httputils.PanicError
+func WithUnmarshaller(unmarshaller Unmarshaller) Option {
+	return func(c *Client) error {
+		if unmarshaller == nil {
+			return errors.New("invalid unmarshaller, it must not be nil")
+		}
+
+		c.respUnmarshaller = safeUnmarshaller(unmarshaller)
+		return nil
+	}
+}
+
+// WithOrganisationID scopes the client to an organisation, the id is set on the created accounts without one and the
+// lists are filtered by it unless the filter is given
+func WithOrganisationID(organisationID uuid.UUID) Option {
+	return func(c *Client) error {
+		if organisationID == uuid.Nil {
+			return errors.New("invalid organisation id, it must not be nil")
+		}
+
+		c.organisationID = organisationID
+		return nil
+	}
+}
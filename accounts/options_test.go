@@ -0,0 +1,210 @@
+package accounts
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"renatoaraujo/form3-account-api-client/httputils"
+)
+
+func TestClientInvalidOptions(t *testing.T) {
+	tests := []struct {
+		name       string
+		opt        Option
+		wantErrMsg string
+	}{
+		{
+			name:       "Failed to create the client with a non positive max staleness",
+			opt:        WithStaleFallback(0),
+			wantErrMsg: "invalid max staleness 0s, it must be positive; invalid option",
+		},
+		{
+			name:       "Failed to create the client with an empty slo class",
+			opt:        WithSLOPolicy("", SLOPolicy{Timeout: time.Second}),
+			wantErrMsg: "invalid slo class, it must not be empty; invalid option",
+		},
+		{
+			name:       "Failed to create the client with a negative slo policy",
+			opt:        WithSLOPolicy(SLOBatch, SLOPolicy{MaxRetries: -1}),
+			wantErrMsg: `invalid policy {Timeout:0s MaxRetries:-1 RetryDelay:0s Backoff:<nil> Priority:normal} for slo class "batch", it must not be negative; invalid option`,
+		},
+		{
+			name:       "Failed to create the client with a non positive max in flight",
+			opt:        WithRequestQueue(0),
+			wantErrMsg: "invalid max in flight 0, it must be positive; invalid option",
+		},
+		{
+			name:       "Failed to create the client with a nil auditor",
+			opt:        WithAuditor(nil),
+			wantErrMsg: "invalid auditor, it must not be nil; invalid option",
+		},
+		{
+			name:       "Failed to create the client with an unknown redaction allowlist attribute",
+			opt:        WithRedactionAllowlist("country"),
+			wantErrMsg: `invalid redaction allowlist attribute "country", it must be one of name, alternative_names, account_number, iban, bank_id, customer_id, secondary_identification, private_identification, organisation_identification; invalid option`,
+		},
+		{
+			name:       "Failed to create the client with a non positive max payload size",
+			opt:        WithMaxPayloadSize(0),
+			wantErrMsg: "invalid max payload size 0, it must be positive; invalid option",
+		},
+		{
+			name:       "Failed to create the client with a nil organisation id",
+			opt:        WithOrganisationID(uuid.Nil),
+			wantErrMsg: "invalid organisation id, it must not be nil; invalid option",
+		},
+		{
+			name:       "Failed to create the client with a nil clock",
+			opt:        WithClock(nil),
+			wantErrMsg: "invalid clock, it must not be nil; invalid option",
+		},
+		{
+			name:       "Failed to create the client with a nil sleeper",
+			opt:        WithSleeper(nil),
+			wantErrMsg: "invalid sleeper, it must not be nil; invalid option",
+		},
+		{
+			name:       "Failed to create the client with a nil marshaller",
+			opt:        WithMarshaller(nil),
+			wantErrMsg: "invalid marshaller, it must not be nil; invalid option",
+		},
+		{
+			name:       "Failed to create the client with a nil unmarshaller",
+			opt:        WithUnmarshaller(nil),
+			wantErrMsg: "invalid unmarshaller, it must not be nil; invalid option",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewClient(&mockHttpUtils{}, tt.opt)
+
+			require.Error(t, err)
+			assert.EqualError(t, err, tt.wantErrMsg)
+		})
+	}
+}
+
+func TestWithOrganisationID(t *testing.T) {
+	organisationID := uuid.New()
+	otherOrganisationID := NewAccountID()
+
+	httpUtilsMock := &mockHttpUtils{}
+	httpUtilsMock.On("Post", mock.Anything, mock.Anything, mock.MatchedBy(func(body []byte) bool {
+		payload := &Payload{}
+		return json.Unmarshal(body, payload) == nil && payload.Data.OrganisationID == organisationID.String()
+	}), mock.Anything).Return(loadTestFile("./testdata/api_response.json"), nil).Once()
+	httpUtilsMock.On("Post", mock.Anything, mock.Anything, mock.MatchedBy(func(body []byte) bool {
+		payload := &Payload{}
+		return json.Unmarshal(body, payload) == nil && payload.Data.OrganisationID == otherOrganisationID.String()
+	}), mock.Anything).Return(loadTestFile("./testdata/api_response.json"), nil).Once()
+	httpUtilsMock.On("Get", mock.Anything, "/v1/organisation/accounts", map[string]string{
+		"filter[organisation_id]": organisationID.String(),
+	}).Return(loadTestFile("./testdata/api_list_response.json"), nil).Once()
+	httpUtilsMock.On("Get", mock.Anything, "/v1/organisation/accounts", map[string]string{
+		"filter[organisation_id]": otherOrganisationID.String(),
+	}).Return(loadTestFile("./testdata/api_list_response.json"), nil).Once()
+
+	accountsClient, err := NewClient(httpUtilsMock, WithOrganisationID(organisationID))
+	require.NoError(t, err)
+
+	accountData := &AccountData{}
+	_, err = accountsClient.CreateResource(context.Background(), accountData)
+	require.NoError(t, err)
+	assert.Empty(t, accountData.OrganisationID, "the account data of the caller must not be changed")
+
+	_, err = accountsClient.CreateResource(context.Background(), &AccountData{OrganisationID: otherOrganisationID.String()})
+	require.NoError(t, err)
+
+	_, err = accountsClient.ListResources(context.Background(), ListOptions{})
+	require.NoError(t, err)
+
+	_, err = accountsClient.ListResources(context.Background(), ListOptions{
+		Filters: map[FilterField]string{FilterOrganisationID: otherOrganisationID.String()},
+	})
+	require.NoError(t, err)
+
+	mock.AssertExpectationsForObjects(t, httpUtilsMock)
+}
+
+// versionedHttpUtils is a http utils mock configured with a version of the api
+type versionedHttpUtils struct {
+	mockHttpUtils
+	version httputils.APIVersion
+}
+
+func (c *versionedHttpUtils) APIVersion() httputils.APIVersion {
+	return c.version
+}
+
+func TestAPIVersionOfTheHTTPClient(t *testing.T) {
+	accountID := NewAccountID()
+
+	tests := []struct {
+		name     string
+		version  httputils.APIVersion
+		wantPath string
+	}{
+		{
+			name:     "Successfully fetches with the default version when the http client has none",
+			wantPath: "/v1/organisation/accounts/" + accountID.String(),
+		},
+		{
+			name:     "Successfully fetches with the version of the http client",
+			version:  "v2",
+			wantPath: "/v2/organisation/accounts/" + accountID.String(),
+		},
+		{
+			name:     "Successfully fetches with the gateway version of the http client",
+			version:  "gateway/v1",
+			wantPath: "/gateway/v1/organisation/accounts/" + accountID.String(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpUtilsMock := &versionedHttpUtils{version: tt.version}
+			httpUtilsMock.On("Get", mock.Anything, tt.wantPath, mock.Anything).Return(loadTestFile("./testdata/api_response.json"), nil).Once()
+
+			accountsClient, err := NewClient(httpUtilsMock)
+			require.NoError(t, err)
+
+			_, err = accountsClient.FetchResource(context.Background(), accountID)
+			require.NoError(t, err)
+
+			mock.AssertExpectationsForObjects(t, httpUtilsMock)
+		})
+	}
+}
+
+func TestWithMarshallers(t *testing.T) {
+	var marshalled, unmarshalled int
+	marshaller := func(v interface{}) ([]byte, error) {
+		marshalled++
+		return json.Marshal(v)
+	}
+	unmarshaller := func(data []byte, v interface{}) error {
+		unmarshalled++
+		return json.Unmarshal(data, v)
+	}
+
+	httpUtilsMock := &mockHttpUtils{}
+	httpUtilsMock.On("Post", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(loadTestFile("./testdata/api_response.json"), nil).Once()
+
+	accountsClient, err := NewClient(httpUtilsMock, WithMarshaller(marshaller), WithUnmarshaller(unmarshaller))
+	require.NoError(t, err)
+
+	_, err = accountsClient.CreateResource(context.Background(), &AccountData{ID: uuid.New().String()})
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, marshalled)
+	assert.Equal(t, 1, unmarshalled)
+	mock.AssertExpectationsForObjects(t, httpUtilsMock)
+}
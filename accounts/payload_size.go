@@ -0,0 +1,99 @@
+package accounts
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// reportedFields is how many of the largest fields are named in the error message
+const reportedFields = 3
+
+// FieldSize is the size in bytes of an attribute in the marshalled payload
+type FieldSize struct {
+	Field string
+	Size  int
+}
+
+// PayloadTooLargeError is returned when the request payload exceeds the max payload size, the fields are sorted from
+// the largest to the smallest so the offending ones come first
+type PayloadTooLargeError struct {
+	Size   int
+	Limit  int
+	Fields []FieldSize
+}
+
+func (err *PayloadTooLargeError) Error() string {
+	largest := make([]string, 0, reportedFields)
+	for i, field := range err.Fields {
+		if i == reportedFields {
+			break
+		}
+		largest = append(largest, fmt.Sprintf("%s (%d bytes)", field.Field, field.Size))
+	}
+
+	return fmt.Sprintf(
+		"payload of %d bytes exceeds the limit of %d bytes, largest fields: %s",
+		err.Size,
+		err.Limit,
+		strings.Join(largest, ", "),
+	)
+}
+
+// WithMaxPayloadSize rejects the create and update requests whose payload exceeds the given size in bytes before
+// sending them
+func WithMaxPayloadSize(maxPayloadSize int) Option {
+	return func(c *Client) error {
+		if maxPayloadSize <= 0 {
+			return fmt.Errorf("invalid max payload size %d, it must be positive", maxPayloadSize)
+		}
+
+		c.maxPayloadSize = maxPayloadSize
+		return nil
+	}
+}
+
+// checkPayloadSize returns a PayloadTooLargeError when the payload exceeds the max payload size of the client
+func (client *Client) checkPayloadSize(payload []byte, accountData *AccountData) error {
+	if client.maxPayloadSize == 0 || len(payload) <= client.maxPayloadSize {
+		return nil
+	}
+
+	return &PayloadTooLargeError{
+		Size:   len(payload),
+		Limit:  client.maxPayloadSize,
+		Fields: attributeSizes(accountData),
+	}
+}
+
+// attributeSizes returns the size of every attribute of the account data sorted from the largest
+func attributeSizes(accountData *AccountData) []FieldSize {
+	if accountData == nil || accountData.Attributes == nil {
+		return nil
+	}
+
+	raw, err := json.Marshal(accountData.Attributes)
+	if err != nil {
+		return nil
+	}
+
+	var attributes map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &attributes); err != nil {
+		return nil
+	}
+
+	sizes := make([]FieldSize, 0, len(attributes))
+	for field, value := range attributes {
+		sizes = append(sizes, FieldSize{Field: field, Size: len(value)})
+	}
+
+	sort.Slice(sizes, func(i, j int) bool {
+		if sizes[i].Size == sizes[j].Size {
+			return sizes[i].Field < sizes[j].Field
+		}
+		return sizes[i].Size > sizes[j].Size
+	})
+
+	return sizes
+}
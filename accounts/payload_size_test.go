@@ -0,0 +1,87 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaxPayloadSize(t *testing.T) {
+	oversized := &AccountData{
+		ID: uuid.New().String(),
+		Attributes: &AccountAttributes{
+			BankID:                 "400300",
+			Name:                   []string{"john doe"},
+			UserDefinedInformation: Ptr(strings.Repeat("x", 500)),
+		},
+	}
+
+	tests := []struct {
+		name           string
+		httpUtilsSetup func(*mockHttpUtils)
+		call           func(*Client) error
+		wantErrMsg     string
+	}{
+		{
+			name: "Failed to create an account with an oversized payload",
+			call: func(c *Client) error {
+				_, err := c.CreateResource(context.Background(), oversized)
+				return err
+			},
+			wantErrMsg: "payload of 638 bytes exceeds the limit of 256 bytes, largest fields: user_defined_information (502 bytes), name (12 bytes), bank_id (8 bytes); unable to create resource",
+		},
+		{
+			name: "Failed to update an account with an oversized payload",
+			call: func(c *Client) error {
+				_, err := c.UpdateResource(context.Background(), oversized)
+				return err
+			},
+			wantErrMsg: "payload of 660 bytes exceeds the limit of 256 bytes, largest fields: user_defined_information (502 bytes), name (12 bytes), bank_id (8 bytes); unable to update resource",
+		},
+		{
+			name: "Successfully creates an account within the max payload size",
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Post", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
+					loadTestFile("./testdata/api_response.json"),
+					nil,
+				)
+			},
+			call: func(c *Client) error {
+				_, err := c.CreateResource(context.Background(), &AccountData{Attributes: &AccountAttributes{BankID: "400300"}})
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpUtilsMock := &mockHttpUtils{}
+			if tt.httpUtilsSetup != nil {
+				tt.httpUtilsSetup(httpUtilsMock)
+			}
+
+			accountsClient, err := NewClient(httpUtilsMock, WithMaxPayloadSize(256))
+			require.NoError(t, err)
+
+			err = tt.call(&accountsClient)
+			if tt.wantErrMsg != "" {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.wantErrMsg)
+
+				var tooLargeErr *PayloadTooLargeError
+				require.True(t, errors.As(err, &tooLargeErr))
+				assert.Equal(t, "user_defined_information", tooLargeErr.Fields[0].Field)
+			} else {
+				require.NoError(t, err)
+			}
+
+			mock.AssertExpectationsForObjects(t, httpUtilsMock)
+		})
+	}
+}
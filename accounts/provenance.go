@@ -0,0 +1,40 @@
+package accounts
+
+import "net/http"
+
+const (
+	headerSourceSystem = "X-Source-System"
+	headerBatchID      = "X-Batch-Id"
+)
+
+// Provenance is the caller supplied metadata tracing an account back to the originating system and batch
+type Provenance struct {
+	SourceSystem string
+	BatchID      string
+}
+
+// WithProvenance attaches the provenance metadata to a create or update operation, it is sent as request headers and
+// recorded in the audit record of the operation
+func WithProvenance(provenance Provenance) CallOption {
+	return func(cfg *callConfig) {
+		cfg.provenance = &provenance
+	}
+}
+
+// header returns the request headers carrying the provenance metadata
+func (provenance *Provenance) header() http.Header {
+	header := http.Header{}
+	if provenance == nil {
+		return header
+	}
+
+	if provenance.SourceSystem != "" {
+		header.Set(headerSourceSystem, provenance.SourceSystem)
+	}
+
+	if provenance.BatchID != "" {
+		header.Set(headerBatchID, provenance.BatchID)
+	}
+
+	return header
+}
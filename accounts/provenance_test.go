@@ -0,0 +1,54 @@
+package accounts
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProvenance(t *testing.T) {
+	tests := []struct {
+		name       string
+		opts       []CallOption
+		wantHeader http.Header
+	}{
+		{
+			name: "Successfully sends the provenance metadata as headers",
+			opts: []CallOption{WithProvenance(Provenance{SourceSystem: "core-banking", BatchID: "batch-42"})},
+			wantHeader: http.Header{
+				"X-Source-System": []string{"core-banking"},
+				"X-Batch-Id":      []string{"batch-42"},
+			},
+		},
+		{
+			name:       "Successfully skips the empty provenance fields",
+			opts:       []CallOption{WithProvenance(Provenance{BatchID: "batch-42"})},
+			wantHeader: http.Header{"X-Batch-Id": []string{"batch-42"}},
+		},
+		{
+			name:       "Successfully sends no header without provenance",
+			wantHeader: http.Header{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpUtilsMock := &mockHttpUtils{}
+			httpUtilsMock.On("Post", mock.Anything, mock.Anything, mock.Anything, tt.wantHeader).Return(
+				loadTestFile("./testdata/api_response.json"),
+				nil,
+			)
+
+			accountsClient, err := NewClient(httpUtilsMock)
+			require.NoError(t, err)
+
+			_, err = accountsClient.CreateResource(context.Background(), &AccountData{}, tt.opts...)
+			require.NoError(t, err)
+
+			mock.AssertExpectationsForObjects(t, httpUtilsMock)
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package accounts
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+)
+
+// Priority orders the operations waiting in the request queue, the higher ones are sent first
+type Priority int
+
+const (
+	// PriorityLow is meant for the background and bulk operations
+	PriorityLow Priority = -1
+	// PriorityNormal is the priority of the operations not given one
+	PriorityNormal Priority = 0
+	// PriorityHigh is meant for the interactive operations a user is waiting on
+	PriorityHigh Priority = 1
+)
+
+func (p Priority) String() string {
+	switch p {
+	case PriorityLow:
+		return "low"
+	case PriorityNormal:
+		return "normal"
+	case PriorityHigh:
+		return "high"
+	default:
+		return strconv.Itoa(int(p))
+	}
+}
+
+// WithRequestQueue bounds the requests in flight across the operations of the client, the ones over the bound wait
+// in a queue ordered by priority so the batch jobs sharing the client don't starve the interactive operations
+func WithRequestQueue(maxInFlight int) Option {
+	return func(c *Client) error {
+		if maxInFlight <= 0 {
+			return fmt.Errorf("invalid max in flight %d, it must be positive", maxInFlight)
+		}
+
+		c.queue = newRequestQueue(maxInFlight)
+		return nil
+	}
+}
+
+// WithPriority sets the priority of the operation in the request queue, it overrides the one of the SLO class
+func WithPriority(priority Priority) CallOption {
+	return func(cfg *callConfig) {
+		cfg.priority = &priority
+	}
+}
+
+// requestQueue is the concurrency limiter of the client, the waiting requests are granted a slot by priority and
+// then in arrival order
+type requestQueue struct {
+	mu          sync.Mutex
+	maxInFlight int
+	inFlight    int
+	waiting     waiters
+	seq         uint64
+}
+
+func newRequestQueue(maxInFlight int) *requestQueue {
+	return &requestQueue{maxInFlight: maxInFlight}
+}
+
+// waiter is a request waiting for a slot, its ready channel is closed once the slot is granted
+type waiter struct {
+	priority Priority
+	seq      uint64
+	ready    chan struct{}
+	index    int
+}
+
+// acquire waits for a slot of the queue, the release function must be called once the request is done
+func (q *requestQueue) acquire(ctx context.Context, priority Priority) (func(), error) {
+	if q == nil {
+		return func() {}, nil
+	}
+
+	q.mu.Lock()
+	if q.inFlight < q.maxInFlight && len(q.waiting) == 0 {
+		q.inFlight++
+		q.mu.Unlock()
+		return q.release, nil
+	}
+
+	q.seq++
+	w := &waiter{priority: priority, seq: q.seq, ready: make(chan struct{})}
+	heap.Push(&q.waiting, w)
+	q.mu.Unlock()
+
+	select {
+	case <-w.ready:
+		return q.release, nil
+	case <-ctx.Done():
+	}
+
+	q.mu.Lock()
+	if w.index >= 0 {
+		heap.Remove(&q.waiting, w.index)
+		q.mu.Unlock()
+		return nil, fmt.Errorf("%w; unable to acquire a slot of the request queue", ctx.Err())
+	}
+	q.mu.Unlock()
+
+	// the slot was granted while the context was done so it is handed over to the next request
+	q.release()
+	return nil, fmt.Errorf("%w; unable to acquire a slot of the request queue", ctx.Err())
+}
+
+// release hands the slot over to the next waiting request or frees it
+func (q *requestQueue) release() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if len(q.waiting) == 0 {
+		q.inFlight--
+		return
+	}
+
+	w := heap.Pop(&q.waiting).(*waiter)
+	close(w.ready)
+}
+
+// queued wraps the operation so every attempt holds a slot of the queue while it is sent
+func (q *requestQueue) queued(priority Priority, operation func(ctx context.Context) error) func(ctx context.Context) error {
+	if q == nil {
+		return operation
+	}
+
+	return func(ctx context.Context) error {
+		release, err := q.acquire(ctx, priority)
+		if err != nil {
+			return err
+		}
+		defer release()
+
+		return operation(ctx)
+	}
+}
+
+// waiters is the heap of the waiting requests, see container/heap
+type waiters []*waiter
+
+func (w waiters) Len() int { return len(w) }
+
+func (w waiters) Less(i, j int) bool {
+	if w[i].priority != w[j].priority {
+		return w[i].priority > w[j].priority
+	}
+
+	return w[i].seq < w[j].seq
+}
+
+func (w waiters) Swap(i, j int) {
+	w[i], w[j] = w[j], w[i]
+	w[i].index = i
+	w[j].index = j
+}
+
+func (w *waiters) Push(x interface{}) {
+	item := x.(*waiter)
+	item.index = len(*w)
+	*w = append(*w, item)
+}
+
+func (w *waiters) Pop() interface{} {
+	old := *w
+	item := old[len(old)-1]
+	old[len(old)-1] = nil
+	item.index = -1
+	*w = old[:len(old)-1]
+	return item
+}
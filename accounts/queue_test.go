@@ -0,0 +1,106 @@
+package accounts
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestQueueOrdersByPriority(t *testing.T) {
+	queue := newRequestQueue(1)
+
+	release, err := queue.acquire(context.Background(), PriorityNormal)
+	require.NoError(t, err)
+
+	var (
+		mu    sync.Mutex
+		order []Priority
+		wg    sync.WaitGroup
+	)
+	for i, priority := range []Priority{PriorityLow, PriorityNormal, PriorityHigh, PriorityLow} {
+		wg.Add(1)
+		go func(priority Priority) {
+			defer wg.Done()
+			release, err := queue.acquire(context.Background(), priority)
+			require.NoError(t, err)
+
+			mu.Lock()
+			order = append(order, priority)
+			mu.Unlock()
+			release()
+		}(priority)
+
+		waitForWaiting(t, queue, i+1)
+	}
+
+	release()
+	wg.Wait()
+
+	assert.Equal(t, []Priority{PriorityHigh, PriorityNormal, PriorityLow, PriorityLow}, order)
+	assert.Equal(t, 0, queue.inFlight)
+}
+
+func TestRequestQueueStopsWaitingWhenTheContextIsDone(t *testing.T) {
+	queue := newRequestQueue(1)
+
+	release, err := queue.acquire(context.Background(), PriorityNormal)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = queue.acquire(ctx, PriorityHigh)
+	require.Error(t, err)
+	assert.EqualError(t, err, "context deadline exceeded; unable to acquire a slot of the request queue")
+	assert.Empty(t, queue.waiting)
+
+	release()
+	assert.Equal(t, 0, queue.inFlight)
+}
+
+func TestRequestQueueBoundsTheRequestsInFlight(t *testing.T) {
+	var inFlight, maxInFlight int32
+
+	httpUtilsMock := &mockHttpUtils{}
+	httpUtilsMock.On("Post", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Run(func(mock.Arguments) {
+		current := atomic.AddInt32(&inFlight, 1)
+		for {
+			seen := atomic.LoadInt32(&maxInFlight)
+			if current <= seen || atomic.CompareAndSwapInt32(&maxInFlight, seen, current) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+	}).Return(loadTestFile("./testdata/api_response.json"), nil)
+
+	accountsClient, err := NewClient(httpUtilsMock, WithRequestQueue(2))
+	require.NoError(t, err)
+
+	accountData := make([]*AccountData, 10)
+	for i := range accountData {
+		accountData[i] = &AccountData{ID: uuid.New().String()}
+	}
+
+	_, err = accountsClient.CreateResources(context.Background(), accountData, 5, WithPriority(PriorityLow))
+	require.NoError(t, err)
+	assert.LessOrEqual(t, atomic.LoadInt32(&maxInFlight), int32(2))
+}
+
+// waitForWaiting waits until the given number of requests are waiting in the queue
+func waitForWaiting(t *testing.T, queue *requestQueue, waiting int) {
+	t.Helper()
+
+	require.Eventually(t, func() bool {
+		queue.mu.Lock()
+		defer queue.mu.Unlock()
+		return len(queue.waiting) == waiting
+	}, time.Second, time.Millisecond)
+}
@@ -0,0 +1,38 @@
+package accounts
+
+import (
+	"runtime/debug"
+
+	"renatoaraujo/form3-account-api-client/httputils"
+)
+
+// recoverPanic sets the panic, if any, as a httputils.PanicError on the error given, it must be deferred
+func recoverPanic(err *error) {
+	if value := recover(); value != nil {
+		*err = &httputils.PanicError{Value: value, Stack: debug.Stack()}
+	}
+}
+
+// ignorePanic recovers the panic of a hook observing an operation, such as the auditor, so it neither fails nor
+// crashes the operation it observes, it must be deferred
+func ignorePanic() {
+	_ = recover()
+}
+
+// safeMarshaller returns the marshaller given with its panics returned as a httputils.PanicError
+func safeMarshaller(marshaller Marshaller) Marshaller {
+	return func(v interface{}) (_ []byte, err error) {
+		defer recoverPanic(&err)
+
+		return marshaller(v)
+	}
+}
+
+// safeUnmarshaller returns the unmarshaller given with its panics returned as a httputils.PanicError
+func safeUnmarshaller(unmarshaller Unmarshaller) Unmarshaller {
+	return func(data []byte, v interface{}) (err error) {
+		defer recoverPanic(&err)
+
+		return unmarshaller(data, v)
+	}
+}
@@ -0,0 +1,78 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"renatoaraujo/form3-account-api-client/httputils"
+)
+
+func TestClientRecoversThePanicsOfTheHooks(t *testing.T) {
+	tests := []struct {
+		name           string
+		opts           []Option
+		httpUtilsSetup func(*mockHttpUtils)
+		wantErr        bool
+		wantErrMsg     string
+	}{
+		{
+			name: "Failed to create when the marshaller panics",
+			opts: []Option{WithMarshaller(func(interface{}) ([]byte, error) {
+				panic("bad marshaller")
+			})},
+			wantErr:    true,
+			wantErrMsg: "recovered from a panic: bad marshaller; unable to convert account data payload",
+		},
+		{
+			name: "Failed to create when the unmarshaller panics",
+			opts: []Option{WithUnmarshaller(func([]byte, interface{}) error {
+				panic("bad unmarshaller")
+			})},
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Post", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(loadTestFile("./testdata/api_response.json"), nil).Once()
+			},
+			wantErr:    true,
+			wantErrMsg: "recovered from a panic: bad unmarshaller; failed to unmarshal response data; unable to create resource",
+		},
+		{
+			name: "Successfully creates when the auditor panics",
+			opts: []Option{WithAuditor(AuditorFunc(func(context.Context, AuditRecord) {
+				panic("bad auditor")
+			}))},
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Post", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(loadTestFile("./testdata/api_response.json"), nil).Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpUtilsMock := &mockHttpUtils{}
+			if tt.httpUtilsSetup != nil {
+				tt.httpUtilsSetup(httpUtilsMock)
+			}
+
+			accountsClient, err := NewClient(httpUtilsMock, tt.opts...)
+			require.NoError(t, err)
+
+			_, err = accountsClient.CreateResource(context.Background(), &AccountData{ID: uuid.New().String()})
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.wantErrMsg)
+
+				var panicErr *httputils.PanicError
+				assert.True(t, errors.As(err, &panicErr))
+			} else {
+				require.NoError(t, err)
+			}
+
+			mock.AssertExpectationsForObjects(t, httpUtilsMock)
+		})
+	}
+}
@@ -0,0 +1,248 @@
+package accounts
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const redactedName = "[REDACTED]"
+
+// SensitiveAttributes are the account attributes redacted by default from the error messages, the debug dumps and
+// the audit records, by their json name
+var SensitiveAttributes = []string{
+	"name",
+	"alternative_names",
+	"account_number",
+	"iban",
+	"bank_id",
+	"customer_id",
+	"secondary_identification",
+	"private_identification",
+	"organisation_identification",
+}
+
+// Redactor masks the sensitive attributes of the accounts, the names are replaced and only the last four characters
+// of the identifiers are kept, the zero value redacts all the sensitive attributes
+type Redactor struct {
+	allow map[string]bool
+}
+
+// NewRedactor creates a redactor keeping the sensitive attributes of the allowlist in the clear
+func NewRedactor(allow ...string) (Redactor, error) {
+	redactor := Redactor{allow: make(map[string]bool, len(allow))}
+	for _, attribute := range allow {
+		if !isSensitiveAttribute(attribute) {
+			return Redactor{}, fmt.Errorf("invalid redaction allowlist attribute %q, it must be one of %s", attribute, strings.Join(SensitiveAttributes, ", "))
+		}
+		redactor.allow[attribute] = true
+	}
+
+	return redactor, nil
+}
+
+// WithRedactionAllowlist keeps the sensitive attributes given in the clear in the error messages and the audit
+// records, by default all of them are redacted
+func WithRedactionAllowlist(allow ...string) Option {
+	return func(c *Client) error {
+		redactor, err := NewRedactor(allow...)
+		if err != nil {
+			return err
+		}
+
+		c.redactor = redactor
+		return nil
+	}
+}
+
+// Account returns a copy of the account with the sensitive attributes masked
+func (r Redactor) Account(accountData *AccountData) *AccountData {
+	if accountData == nil || accountData.Attributes == nil {
+		return accountData
+	}
+
+	redacted := *accountData
+	attributes := *accountData.Attributes
+	redacted.Attributes = &attributes
+
+	if r.redacts("name") {
+		attributes.Name = redactNames(attributes.Name)
+	}
+	if r.redacts("alternative_names") {
+		attributes.AlternativeNames = redactNames(attributes.AlternativeNames)
+	}
+	if r.redacts("account_number") {
+		attributes.AccountNumber = maskIdentifier(attributes.AccountNumber)
+	}
+	if r.redacts("iban") {
+		attributes.Iban = maskIdentifier(attributes.Iban)
+	}
+	if r.redacts("bank_id") {
+		attributes.BankID = maskIdentifier(attributes.BankID)
+	}
+	if r.redacts("customer_id") {
+		attributes.CustomerID = maskOptional(attributes.CustomerID)
+	}
+	if r.redacts("secondary_identification") {
+		attributes.SecondaryIdentification = maskOptional(attributes.SecondaryIdentification)
+	}
+	if r.redacts("private_identification") && attributes.PrivateIdentification != nil {
+		attributes.PrivateIdentification = &PrivateIdentification{
+			BirthCountry:   attributes.PrivateIdentification.BirthCountry,
+			Country:        attributes.PrivateIdentification.Country,
+			Identification: maskIdentifier(attributes.PrivateIdentification.Identification),
+		}
+	}
+	if r.redacts("organisation_identification") && attributes.OrganisationIdentification != nil {
+		identification := *attributes.OrganisationIdentification
+		identification.Actors = nil
+		identification.Representative = nil
+		identification.Name = redactNames(identification.Name)
+		identification.Identification = maskIdentifier(identification.Identification)
+		attributes.OrganisationIdentification = &identification
+	}
+
+	return &redacted
+}
+
+// String masks the values of the sensitive attributes of the account found in the string, such as in the message of
+// an error echoing the payload
+func (r Redactor) String(s string, accountData *AccountData) string {
+	replacements := r.replacements(accountData)
+	if len(replacements) == 0 {
+		return s
+	}
+
+	return strings.NewReplacer(replacements...).Replace(s)
+}
+
+// Payload masks the sensitive attributes of the account of a request or response payload, such as a body recorded
+// with the vcr package, the bodies which are not an account payload are returned as they are
+func (r Redactor) Payload(body []byte) []byte {
+	var payload Payload
+	if err := json.Unmarshal(body, &payload); err != nil || payload.Data == nil || payload.Data.Attributes == nil {
+		return body
+	}
+
+	redacted, err := json.Marshal(&Payload{Data: r.Account(payload.Data), Links: payload.Links})
+	if err != nil {
+		return body
+	}
+
+	return redacted
+}
+
+// Error returns the error with the sensitive values of the account masked from its message, it still unwraps to the
+// original error
+func (r Redactor) Error(err error, accountData *AccountData) error {
+	if err == nil {
+		return nil
+	}
+
+	message := err.Error()
+	redacted := r.String(message, accountData)
+	if redacted == message {
+		return err
+	}
+
+	return &redactedError{err: err, message: redacted}
+}
+
+// replacements lists the values of the sensitive attributes of the account followed by their masks
+func (r Redactor) replacements(accountData *AccountData) []string {
+	if accountData == nil || accountData.Attributes == nil {
+		return nil
+	}
+
+	var replacements []string
+	add := func(attribute, value, mask string) {
+		if value != "" && r.redacts(attribute) {
+			replacements = append(replacements, value, mask)
+		}
+	}
+	addNames := func(attribute string, names []string) {
+		for _, name := range names {
+			add(attribute, name, redactedName)
+		}
+	}
+
+	attributes := accountData.Attributes
+	addNames("name", attributes.Name)
+	addNames("alternative_names", attributes.AlternativeNames)
+	add("account_number", attributes.AccountNumber, maskIdentifier(attributes.AccountNumber))
+	add("iban", attributes.Iban, maskIdentifier(attributes.Iban))
+	add("bank_id", attributes.BankID, maskIdentifier(attributes.BankID))
+	add("customer_id", Value(attributes.CustomerID), maskIdentifier(Value(attributes.CustomerID)))
+	add("secondary_identification", Value(attributes.SecondaryIdentification), maskIdentifier(Value(attributes.SecondaryIdentification)))
+
+	if identification := attributes.PrivateIdentification; identification != nil {
+		add("private_identification", identification.Identification, maskIdentifier(identification.Identification))
+	}
+
+	if identification := attributes.OrganisationIdentification; identification != nil {
+		add("organisation_identification", identification.Identification, maskIdentifier(identification.Identification))
+		addNames("organisation_identification", identification.Name)
+	}
+
+	return replacements
+}
+
+func (r Redactor) redacts(attribute string) bool {
+	return !r.allow[attribute]
+}
+
+// redactedError is an error whose message had its sensitive values masked
+type redactedError struct {
+	err     error
+	message string
+}
+
+func (err *redactedError) Error() string {
+	return err.message
+}
+
+func (err *redactedError) Unwrap() error {
+	return err.err
+}
+
+// redactNames replaces every name
+func redactNames(names []string) []string {
+	if names == nil {
+		return nil
+	}
+
+	redacted := make([]string, len(names))
+	for i := range names {
+		redacted[i] = redactedName
+	}
+
+	return redacted
+}
+
+// maskIdentifier masks all but the last four characters of the identifier
+func maskIdentifier(identifier string) string {
+	if len(identifier) <= 4 {
+		return strings.Repeat("*", len(identifier))
+	}
+
+	return strings.Repeat("*", len(identifier)-4) + identifier[len(identifier)-4:]
+}
+
+// maskOptional masks an optional identifier, an unset one stays unset
+func maskOptional(identifier *string) *string {
+	if identifier == nil {
+		return nil
+	}
+
+	return Ptr(maskIdentifier(*identifier))
+}
+
+func isSensitiveAttribute(attribute string) bool {
+	for _, sensitive := range SensitiveAttributes {
+		if attribute == sensitive {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,138 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"renatoaraujo/form3-account-api-client/httputils"
+)
+
+func sensitiveAccount() *AccountData {
+	return &AccountData{
+		ID: "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc",
+		Attributes: &AccountAttributes{
+			Name:          []string{"Samantha Holder"},
+			AccountNumber: "41426819",
+			Iban:          "GB11NWBK40030041426819",
+			BankID:        "400300",
+			Bic:           "NWBKGB22",
+			PrivateIdentification: &PrivateIdentification{
+				Address:        []string{"10 Avenue des Champs"},
+				BirthDate:      "2017-07-23",
+				Country:        "GB",
+				Identification: "13YH458762",
+			},
+		},
+	}
+}
+
+func TestRedactorAccount(t *testing.T) {
+	tests := []struct {
+		name           string
+		allow          []string
+		wantAttributes *AccountAttributes
+	}{
+		{
+			name: "Successfully redacts all the sensitive attributes",
+			wantAttributes: &AccountAttributes{
+				Name:          []string{"[REDACTED]"},
+				AccountNumber: "****6819",
+				Iban:          "******************6819",
+				BankID:        "**0300",
+				Bic:           "NWBKGB22",
+				PrivateIdentification: &PrivateIdentification{
+					Country:        "GB",
+					Identification: "******8762",
+				},
+			},
+		},
+		{
+			name:  "Successfully redacts the sensitive attributes not in the allowlist",
+			allow: []string{"name", "bank_id", "private_identification"},
+			wantAttributes: &AccountAttributes{
+				Name:                  []string{"Samantha Holder"},
+				AccountNumber:         "****6819",
+				Iban:                  "******************6819",
+				BankID:                "400300",
+				Bic:                   "NWBKGB22",
+				PrivateIdentification: sensitiveAccount().Attributes.PrivateIdentification,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			redactor, err := NewRedactor(tt.allow...)
+			require.NoError(t, err)
+
+			accountData := sensitiveAccount()
+			redacted := redactor.Account(accountData)
+
+			assert.Equal(t, tt.wantAttributes, redacted.Attributes)
+			assert.Equal(t, sensitiveAccount(), accountData)
+		})
+	}
+}
+
+func TestNewRedactorWithAnUnknownAttribute(t *testing.T) {
+	_, err := NewRedactor("bic")
+	require.Error(t, err)
+	assert.EqualError(t, err, `invalid redaction allowlist attribute "bic", it must be one of name, alternative_names, account_number, iban, bank_id, customer_id, secondary_identification, private_identification, organisation_identification`)
+}
+
+func TestRedactorPayload(t *testing.T) {
+	body := []byte(`{"data":{"attributes":{"iban":"GB11NWBK40030041426819","name":["Samantha Holder"]},"id":"ad27e265-9605-4b4b-a0e5-3003ea9cc4dc"}}`)
+
+	redacted := Redactor{}.Payload(body)
+	assert.JSONEq(t, `{"data":{"attributes":{"iban":"******************6819","name":["[REDACTED]"]},"id":"ad27e265-9605-4b4b-a0e5-3003ea9cc4dc"}}`, string(redacted))
+
+	assert.Equal(t, []byte(`not json`), Redactor{}.Payload([]byte(`not json`)))
+}
+
+func TestClientRedactsTheErrors(t *testing.T) {
+	apiErr := &httputils.ResponseError{
+		StatusCode:   400,
+		ErrorMessage: "account Samantha Holder with iban GB11NWBK40030041426819 already exists",
+	}
+
+	httpUtilsMock := &mockHttpUtils{}
+	httpUtilsMock.On("Post", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, apiErr).Once()
+
+	var record AuditRecord
+	accountsClient, err := NewClient(httpUtilsMock, WithAuditor(AuditorFunc(func(_ context.Context, r AuditRecord) {
+		record = r
+	})))
+	require.NoError(t, err)
+
+	_, err = accountsClient.CreateResource(context.Background(), sensitiveAccount())
+	require.Error(t, err)
+	assert.EqualError(t, err, "api failure with status code 400 and message: account [REDACTED] with iban ******************6819 already exists; unable to create resource")
+	assert.EqualError(t, record.Err, "api failure with status code 400 and message: account [REDACTED] with iban ******************6819 already exists")
+
+	var respErr *httputils.ResponseError
+	require.True(t, errors.As(err, &respErr))
+	assert.Equal(t, 400, respErr.StatusCode)
+}
+
+func TestClientRedactsTheValidationErrors(t *testing.T) {
+	accountsClient, err := NewClient(&mockHttpUtils{}, WithValidation())
+	require.NoError(t, err)
+
+	country := CountryUnitedKingdom
+	accountData := sensitiveAccount()
+	accountData.Attributes.Country = &country
+	accountData.Attributes.BankIDCode = "GBDSC"
+	accountData.Attributes.Iban = "GB34BUKB20201555555555"
+
+	_, err = accountsClient.CreateResource(context.Background(), accountData)
+	require.Error(t, err)
+	assert.EqualError(t, err, `invalid account data: invalid iban "******************5555", the check digits do not match; unable to create resource`)
+
+	var validationErr *ValidationError
+	assert.True(t, errors.As(err, &validationErr))
+}
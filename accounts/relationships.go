@@ -0,0 +1,71 @@
+package accounts
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// MasterAccountID returns the id of the master account of a virtual account, the flag is false when the account is
+// not linked to a master account
+func (accountData *AccountData) MasterAccountID() (AccountID, bool) {
+	if accountData.Relationships == nil || accountData.Relationships.MasterAccount == nil {
+		return AccountID{}, false
+	}
+
+	for _, identifier := range accountData.Relationships.MasterAccount.Data {
+		if accountID, err := ParseAccountID(identifier.ID); err == nil {
+			return accountID, true
+		}
+	}
+
+	return AccountID{}, false
+}
+
+// IsVirtual tells if the account is a virtual account linked to a master account
+func (accountData *AccountData) IsVirtual() bool {
+	_, ok := accountData.MasterAccountID()
+	return ok
+}
+
+// WithMasterAccount links the account to its master account, making it a virtual account
+func (builder *AccountDataBuilder) WithMasterAccount(masterAccountID AccountID) *AccountDataBuilder {
+	if builder.data.Relationships == nil {
+		builder.data.Relationships = &Relationships{}
+	}
+
+	builder.data.Relationships.MasterAccount = &RelationshipData{
+		Data: []ResourceIdentifier{{ID: masterAccountID.String(), Type: "accounts"}},
+	}
+	return builder
+}
+
+// NewVirtualAccountBuilder creates a builder of a virtual account linked to the master account, the organisation, the
+// country, the base currency, the bank id and the bic are the ones of the master account unless they are set
+func NewVirtualAccountBuilder(master *AccountData) (*AccountDataBuilder, error) {
+	if master == nil {
+		return nil, errors.New("invalid master account, it must not be nil")
+	}
+
+	masterAccountID, err := master.AccountID()
+	if err != nil {
+		return nil, fmt.Errorf("%w; invalid master account", err)
+	}
+
+	builder := NewAccountDataBuilder().WithMasterAccount(masterAccountID)
+	if organisationID, err := uuid.Parse(master.OrganisationID); err == nil {
+		builder.WithOrganisationID(organisationID)
+	}
+
+	if attributes := master.Attributes; attributes != nil {
+		if attributes.Country != nil {
+			builder.WithCountry(*attributes.Country)
+		}
+		builder.WithBaseCurrency(attributes.BaseCurrency).
+			WithBankID(attributes.BankID, attributes.BankIDCode).
+			WithBic(attributes.Bic)
+	}
+
+	return builder, nil
+}
@@ -0,0 +1,62 @@
+package accounts
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewVirtualAccountBuilder(t *testing.T) {
+	master := &Payload{}
+	require.NoError(t, json.Unmarshal(loadTestFile("./testdata/api_response.json"), master))
+
+	builder, err := NewVirtualAccountBuilder(master.Data)
+	require.NoError(t, err)
+
+	virtual, err := builder.WithName("jane doe").Build()
+	require.NoError(t, err)
+
+	masterAccountID, ok := virtual.MasterAccountID()
+	require.True(t, ok)
+	assert.Equal(t, master.Data.ID, masterAccountID.String())
+	assert.True(t, virtual.IsVirtual())
+	assert.False(t, master.Data.IsVirtual())
+	assert.NotEqual(t, master.Data.ID, virtual.ID)
+	assert.Equal(t, master.Data.OrganisationID, virtual.OrganisationID)
+	assert.Equal(t, "400300", virtual.Attributes.BankID)
+	assert.Equal(t, "NWBKGB22", virtual.Attributes.Bic)
+	assert.Equal(t, CurrencyGBP, virtual.Attributes.BaseCurrency)
+
+	raw, err := json.Marshal(&Payload{Data: virtual})
+	require.NoError(t, err)
+	assert.Contains(t, string(raw), `"relationships":{"master_account":{"data":[{"id":"`+master.Data.ID+`","type":"accounts"}]}}`)
+}
+
+func TestNewVirtualAccountBuilderFails(t *testing.T) {
+	tests := []struct {
+		name       string
+		master     *AccountData
+		wantErrMsg string
+	}{
+		{
+			name:       "Failed to create the builder without a master account",
+			wantErrMsg: "invalid master account, it must not be nil",
+		},
+		{
+			name:       "Failed to create the builder with a master account without a valid id",
+			master:     &AccountData{ID: "not-an-id", OrganisationID: uuid.NewString()},
+			wantErrMsg: "invalid UUID length: 9; invalid account id; invalid master account",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewVirtualAccountBuilder(tt.master)
+			require.Error(t, err)
+			assert.EqualError(t, err, tt.wantErrMsg)
+		})
+	}
+}
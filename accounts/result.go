@@ -0,0 +1,69 @@
+package accounts
+
+import "time"
+
+// Result is the envelope returned by the Create, Fetch and Update methods, it carries the decoded resource along with
+// the non-fatal information gathered while performing the operation
+type Result struct {
+	Data     *AccountData
+	Meta     ResponseMeta
+	Warnings []Warning
+	Cache    CacheProvenance
+}
+
+// ResponseMeta is the metadata of the operation
+type ResponseMeta struct {
+	// StartedAt is when the operation started
+	StartedAt time.Time
+	// Duration is how long the operation took including the retries
+	Duration time.Duration
+	// Attempts is how many requests were made to form3
+	Attempts int
+	// Links are the links of the response, the self link of the resource or the first, last, next and prev pages of a
+	// list, nil when form3 sent none
+	Links *Links
+}
+
+// ListResult is the envelope returned by List, the accounts of the page and the metadata with the links to the other
+// pages
+type ListResult struct {
+	Data []*AccountData
+	Meta ResponseMeta
+}
+
+// WarningCode identifies the kind of warning
+type WarningCode string
+
+// WarningStaleData is raised when the data was served from the cache because form3 was unreachable
+const WarningStaleData WarningCode = "stale_data"
+
+// Warning is a non-fatal issue found while performing the operation
+type Warning struct {
+	Code    WarningCode
+	Message string
+}
+
+// DataSource tells where the data of the result comes from
+type DataSource string
+
+const (
+	// SourceNetwork is set when the data was received from form3
+	SourceNetwork DataSource = "network"
+	// SourceCache is set when the data was served from the cache
+	SourceCache DataSource = "cache"
+)
+
+// CacheProvenance tells if the data of the result was served from the cache and how old it is
+type CacheProvenance struct {
+	Source    DataSource
+	Stale     bool
+	FetchedAt time.Time
+}
+
+// newResult returns the result of an operation starting now, according to the clock of the client
+func (client *Client) newResult() *Result {
+	return &Result{
+		Meta:  ResponseMeta{StartedAt: client.now()},
+		Cache: CacheProvenance{Source: SourceNetwork},
+	}
+}
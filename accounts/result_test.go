@@ -0,0 +1,140 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResultEnvelope(t *testing.T) {
+	unreachableErr := &url.Error{Op: "Get", URL: "https://api.form3.tech", Err: errors.New("connection refused")}
+	accountID := MustParseAccountID("ad27e265-9605-4b4b-a0e5-3003ea9cc4dc")
+
+	tests := []struct {
+		name           string
+		httpUtilsSetup func(*mockHttpUtils)
+		call           func(*Client) (*Result, error)
+		wantAttempts   int
+		wantSource     DataSource
+		wantWarnings   []WarningCode
+		wantLinks      *Links
+	}{
+		{
+			name: "Successfully creates an account with the result envelope",
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Post", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
+					loadTestFile("./testdata/api_response.json"),
+					nil,
+				)
+			},
+			call: func(c *Client) (*Result, error) {
+				return c.Create(context.Background(), &AccountData{})
+			},
+			wantAttempts: 1,
+			wantSource:   SourceNetwork,
+		},
+		{
+			name: "Successfully updates an account with the result envelope",
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Patch", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
+					loadTestFile("./testdata/api_response.json"),
+					nil,
+				)
+			},
+			call: func(c *Client) (*Result, error) {
+				return c.Update(context.Background(), &AccountData{ID: accountID.String()})
+			},
+			wantAttempts: 1,
+			wantSource:   SourceNetwork,
+		},
+		{
+			name: "Successfully fetches an account after retrying with the result envelope",
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(nil, unreachableErr).Once()
+				client.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(
+					loadTestFile("./testdata/api_response.json"),
+					nil,
+				).Once()
+			},
+			call: func(c *Client) (*Result, error) {
+				return c.Fetch(context.Background(), accountID, WithSLOClass(SLOBatch))
+			},
+			wantAttempts: 2,
+			wantSource:   SourceNetwork,
+			wantLinks:    &Links{Self: "/v1/organisation/accounts/ad27e265-9605-4b4b-a0e5-3003ea9cc4dc"},
+		},
+		{
+			name: "Successfully fetches a stale account from the cache with a warning",
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(nil, unreachableErr)
+			},
+			call: func(c *Client) (*Result, error) {
+				c.cache.set(accountID, &AccountData{ID: accountID.String()}, time.Now())
+				return c.Fetch(context.Background(), accountID)
+			},
+			wantAttempts: 1,
+			wantSource:   SourceCache,
+			wantWarnings: []WarningCode{WarningStaleData},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpUtilsMock := &mockHttpUtils{}
+			tt.httpUtilsSetup(httpUtilsMock)
+
+			accountsClient, err := NewClient(
+				httpUtilsMock,
+				WithStaleFallback(time.Minute),
+				WithSLOPolicy(SLOBatch, SLOPolicy{MaxRetries: 1}),
+			)
+			require.NoError(t, err)
+
+			result, err := tt.call(&accountsClient)
+			require.NoError(t, err)
+
+			assert.IsType(t, &AccountData{}, result.Data)
+			assert.Equal(t, tt.wantAttempts, result.Meta.Attempts)
+			assert.Equal(t, tt.wantSource, result.Cache.Source)
+			assert.Equal(t, tt.wantSource == SourceCache, result.Cache.Stale)
+
+			var warnings []WarningCode
+			for _, warning := range result.Warnings {
+				warnings = append(warnings, warning.Code)
+			}
+			assert.Equal(t, tt.wantWarnings, warnings)
+			if tt.wantLinks != nil {
+				assert.Equal(t, tt.wantLinks, result.Meta.Links)
+			}
+
+			mock.AssertExpectationsForObjects(t, httpUtilsMock)
+		})
+	}
+}
+
+func TestListResultEnvelope(t *testing.T) {
+	httpUtilsMock := &mockHttpUtils{}
+	httpUtilsMock.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(loadTestFile("./testdata/api_list_response.json"), nil)
+
+	accountsClient, err := NewClient(httpUtilsMock)
+	require.NoError(t, err)
+
+	result, err := accountsClient.List(context.Background(), ListOptions{})
+	require.NoError(t, err)
+
+	assert.Len(t, result.Data, 2)
+	assert.Equal(t, 1, result.Meta.Attempts)
+	assert.Equal(t, &Links{
+		First: "/v1/organisation/accounts?page%5Bnumber%5D=first",
+		Last:  "/v1/organisation/accounts?page%5Bnumber%5D=last",
+		Self:  "/v1/organisation/accounts",
+	}, result.Meta.Links)
+
+	mock.AssertExpectationsForObjects(t, httpUtilsMock)
+}
@@ -0,0 +1,73 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+)
+
+const headerIdempotencyKey = "Idempotency-Key"
+
+// idempotentOperations are the operations retried by default, a retried create could create the account twice and a
+// retried update could be applied twice or fail with a conflict so they are only retried when the call has an
+// idempotency key
+var idempotentOperations = map[Operation]bool{
+	OperationFetch:  true,
+	OperationList:   true,
+	OperationExists: true,
+	OperationDelete: true,
+	OperationEvents: true,
+}
+
+// WithIdempotencyKey sends the idempotency key of the operation as the Idempotency-Key header, a create or an update
+// with an idempotency key is retried according to its SLO policy like the idempotent operations
+func WithIdempotencyKey(key string) CallOption {
+	return func(cfg *callConfig) {
+		cfg.idempotencyKey = key
+	}
+}
+
+// retries returns how many times the operation can be retried, none for a create or an update without an idempotency
+// key
+func (cfg callConfig) retries(operation Operation, maxRetries int) int {
+	if !idempotentOperations[operation] && cfg.idempotencyKey == "" {
+		return 0
+	}
+
+	return maxRetries
+}
+
+// retry performs the operation until it succeeds, fails with an error that is not worth retrying, the max retries
+// or the retry budget are exhausted or the context is done, the sleeper waits the delay of the backoff before each retry
+func retry(ctx context.Context, maxRetries int, backoff Backoff, budget *retryBudget, sleeper Sleeper, operation func(ctx context.Context) error) error {
+	budget.request()
+
+	for attempt := 0; ; attempt++ {
+		err := operation(ctx)
+		if err == nil || attempt >= maxRetries || !isRetryable(err) || ctx.Err() != nil {
+			return err
+		}
+
+		if !budget.withdraw() {
+			return err
+		}
+
+		if sleeper.Sleep(ctx, backoff.Delay(attempt+1)) != nil {
+			return err
+		}
+	}
+}
+
+// isRetryable tells if the error is worth retrying, form3 could not be reached, it failed with a server error or it
+// throttled the request
+func isRetryable(err error) bool {
+	code := statusCode(err)
+	return isUnreachable(err) || code >= http.StatusInternalServerError || code == http.StatusTooManyRequests
+}
+
+// isUnreachable tells if the error means that form3 could not be reached, rather than form3 failing the request
+func isUnreachable(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
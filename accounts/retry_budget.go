@@ -0,0 +1,116 @@
+package accounts
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// defaultRetryBudgetWindow is the window of the retry budget when it is not set
+const defaultRetryBudgetWindow = 10 * time.Second
+
+// RetryBudget bounds the retries across all the operations of the client, so a form3 incident doesn't turn the
+// retries of every operation into a retry storm
+type RetryBudget struct {
+	// Ratio is the share of the requests of the window that may be retried, such as 0.1 for 10%
+	Ratio float64
+	// MinRetries is the retries allowed in every window whatever the number of requests, so a client with little
+	// traffic can still retry
+	MinRetries int
+	// Window is the period the requests and the retries are counted over, zero means 10 seconds
+	Window time.Duration
+	// OnExhausted is called, when set, with the stats of the window every time a retry is refused
+	OnExhausted func(RetryBudgetStats)
+}
+
+// RetryBudgetStats are the requests and the retries counted in the current window of a retry budget
+type RetryBudgetStats struct {
+	Requests int
+	Retries  int
+	Allowed  int
+}
+
+// WithRetryBudget shares a retry budget between all the operations of the client, the retries of the SLO policies
+// are only performed while the budget allows them
+func WithRetryBudget(budget RetryBudget) Option {
+	return func(c *Client) error {
+		if budget.Ratio < 0 || budget.Ratio > 1 {
+			return fmt.Errorf("invalid retry budget ratio %v, it must be between 0 and 1", budget.Ratio)
+		}
+
+		if budget.MinRetries < 0 || budget.Window < 0 {
+			return fmt.Errorf("invalid retry budget min retries %d and window %s, they must not be negative", budget.MinRetries, budget.Window)
+		}
+
+		if budget.Window == 0 {
+			budget.Window = defaultRetryBudgetWindow
+		}
+
+		c.retryBudget = &retryBudget{policy: budget}
+		return nil
+	}
+}
+
+// retryBudget counts the requests and the retries of the current window, a nil budget allows every retry
+type retryBudget struct {
+	mu          sync.Mutex
+	policy      RetryBudget
+	now         func() time.Time
+	windowStart time.Time
+	requests    int
+	retries     int
+}
+
+// rotate starts a new window when the current one is over
+func (b *retryBudget) rotate() {
+	now := b.now()
+	if now.Sub(b.windowStart) >= b.policy.Window {
+		b.windowStart = now
+		b.requests = 0
+		b.retries = 0
+	}
+}
+
+// request counts the first attempt of an operation
+func (b *retryBudget) request() {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.rotate()
+	b.requests++
+}
+
+// withdraw tells if a retry is allowed counting it when it is, the exhaustion hook is called when it is not
+func (b *retryBudget) withdraw() bool {
+	if b == nil {
+		return true
+	}
+
+	b.mu.Lock()
+	b.rotate()
+	allowed := b.policy.MinRetries + int(b.policy.Ratio*float64(b.requests))
+	if b.retries < allowed {
+		b.retries++
+		b.mu.Unlock()
+		return true
+	}
+	stats := RetryBudgetStats{Requests: b.requests, Retries: b.retries, Allowed: allowed}
+	b.mu.Unlock()
+
+	if b.policy.OnExhausted != nil {
+		b.exhausted(stats)
+	}
+
+	return false
+}
+
+// exhausted calls the hook of the exhausted budget, a panic of the hook is recovered so the retry is only refused
+func (b *retryBudget) exhausted(stats RetryBudgetStats) {
+	defer ignorePanic()
+
+	b.policy.OnExhausted(stats)
+}
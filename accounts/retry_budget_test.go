@@ -0,0 +1,92 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryBudget(t *testing.T) {
+	now := time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC)
+	var exhausted []RetryBudgetStats
+	budget := &retryBudget{
+		policy: RetryBudget{Ratio: 0.1, MinRetries: 1, Window: 10 * time.Second, OnExhausted: func(stats RetryBudgetStats) {
+			exhausted = append(exhausted, stats)
+		}},
+		now: func() time.Time { return now },
+	}
+
+	for i := 0; i < 10; i++ {
+		budget.request()
+	}
+
+	assert.True(t, budget.withdraw())
+	assert.True(t, budget.withdraw())
+	assert.False(t, budget.withdraw(), "only the min retries and 10% of the requests may be retried")
+	assert.Equal(t, []RetryBudgetStats{{Requests: 10, Retries: 2, Allowed: 2}}, exhausted)
+
+	now = now.Add(10 * time.Second)
+	assert.True(t, budget.withdraw(), "a new window must allow the min retries again")
+	assert.False(t, budget.withdraw())
+
+	var unlimited *retryBudget
+	unlimited.request()
+	assert.True(t, unlimited.withdraw())
+}
+
+func TestClientWithRetryBudget(t *testing.T) {
+	unreachableErr := &url.Error{Op: "Get", URL: "https://api.form3.tech", Err: errors.New("connection refused")}
+
+	httpUtilsMock := &mockHttpUtils{}
+	httpUtilsMock.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(nil, unreachableErr).Times(3)
+
+	var exhausted int
+	accountsClient, err := NewClient(
+		httpUtilsMock,
+		WithSLOPolicy(SLOPaymentCritical, SLOPolicy{MaxRetries: 5}),
+		WithRetryBudget(RetryBudget{MinRetries: 1, OnExhausted: func(RetryBudgetStats) { exhausted++ }}),
+	)
+	require.NoError(t, err)
+
+	_, err = accountsClient.FetchResource(context.Background(), NewAccountID(), WithSLOClass(SLOPaymentCritical))
+	require.Error(t, err)
+
+	_, err = accountsClient.FetchResource(context.Background(), NewAccountID(), WithSLOClass(SLOPaymentCritical))
+	require.Error(t, err)
+
+	assert.Equal(t, 2, exhausted)
+	mock.AssertExpectationsForObjects(t, httpUtilsMock)
+}
+
+func TestClientWithInvalidRetryBudget(t *testing.T) {
+	tests := []struct {
+		name       string
+		budget     RetryBudget
+		wantErrMsg string
+	}{
+		{
+			name:       "Failed to create the client with a ratio above 1",
+			budget:     RetryBudget{Ratio: 1.5},
+			wantErrMsg: "invalid retry budget ratio 1.5, it must be between 0 and 1; invalid option",
+		},
+		{
+			name:       "Failed to create the client with negative min retries",
+			budget:     RetryBudget{MinRetries: -1},
+			wantErrMsg: "invalid retry budget min retries -1 and window 0s, they must not be negative; invalid option",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewClient(&mockHttpUtils{}, WithRetryBudget(tt.budget))
+			require.Error(t, err)
+			assert.EqualError(t, err, tt.wantErrMsg)
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package accounts
+
+import (
+	"context"
+	"time"
+)
+
+// Attempt is one of the requests made by a retried operation, the status code is zero when form3 was not reached
+type Attempt struct {
+	Number     int
+	StartedAt  time.Time
+	Duration   time.Duration
+	StatusCode int
+	Err        error
+}
+
+// RetryError is returned when an operation failed once retried, it reports every attempt made on the wire for the
+// incident tooling, its message is the one of the last error so it reads as the error it wraps
+type RetryError struct {
+	Attempts []Attempt
+	Err      error
+}
+
+func (err *RetryError) Error() string {
+	return err.Err.Error()
+}
+
+func (err *RetryError) Unwrap() error {
+	return err.Err
+}
+
+// attemptsRecorder records the attempts of an operation
+type attemptsRecorder struct {
+	now      func() time.Time
+	attempts []Attempt
+}
+
+// newAttemptsRecorder creates the recorder of the attempts timed by the clock given, the system one when it is nil
+func newAttemptsRecorder(now func() time.Time) *attemptsRecorder {
+	if now == nil {
+		now = time.Now
+	}
+
+	return &attemptsRecorder{now: now}
+}
+
+// record wraps the operation so every call of it is recorded as an attempt
+func (recorder *attemptsRecorder) record(operation func(ctx context.Context) error) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		startedAt := recorder.now()
+		err := operation(ctx)
+
+		attempt := Attempt{
+			Number:     len(recorder.attempts) + 1,
+			StartedAt:  startedAt,
+			Duration:   recorder.now().Sub(startedAt),
+			Err:        err,
+			StatusCode: statusCode(err),
+		}
+		recorder.attempts = append(recorder.attempts, attempt)
+
+		return err
+	}
+}
+
+// report wraps the error with the attempts when the operation was retried, the errors of the operations attempted
+// once are returned as they are
+func (recorder *attemptsRecorder) report(err error) error {
+	if err == nil || len(recorder.attempts) < 2 {
+		return err
+	}
+
+	return &RetryError{Attempts: recorder.attempts, Err: err}
+}
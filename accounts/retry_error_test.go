@@ -0,0 +1,103 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"renatoaraujo/form3-account-api-client/httputils"
+)
+
+func TestFetchResourceRetryError(t *testing.T) {
+	unreachableErr := &url.Error{Op: "Get", URL: "https://api.form3.tech", Err: errors.New("connection refused")}
+	notFoundErr := &httputils.ResponseError{ErrorMessage: "not found", StatusCode: 404}
+	unavailableErr := &httputils.StatusError{StatusCode: 503}
+
+	tests := []struct {
+		name           string
+		httpUtilsSetup func(*mockHttpUtils)
+		wantAttempts   []Attempt
+		wantErrMsg     string
+	}{
+		{
+			name: "Failed to fetch once the retries are exhausted",
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(nil, unreachableErr).Times(3)
+			},
+			wantAttempts: []Attempt{
+				{Number: 1, StartedAt: time.Date(2021, 10, 15, 19, 28, 58, 0, time.UTC), Err: unreachableErr},
+				{Number: 2, StartedAt: time.Date(2021, 10, 15, 19, 29, 58, 0, time.UTC), Err: unreachableErr},
+				{Number: 3, StartedAt: time.Date(2021, 10, 15, 19, 30, 58, 0, time.UTC), Err: unreachableErr},
+			},
+			wantErrMsg: `Get "https://api.form3.tech": connection refused; unable to fetch resource`,
+		},
+		{
+			name: "Failed to fetch with an error response once retried",
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(nil, unreachableErr).Once()
+				client.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(nil, notFoundErr).Once()
+			},
+			wantAttempts: []Attempt{
+				{Number: 1, StartedAt: time.Date(2021, 10, 15, 19, 28, 58, 0, time.UTC), Err: unreachableErr},
+				{Number: 2, StartedAt: time.Date(2021, 10, 15, 19, 29, 58, 0, time.UTC), StatusCode: 404, Err: notFoundErr},
+			},
+			wantErrMsg: "api failure with status code 404 and message: not found; unable to fetch resource",
+		},
+		{
+			name: "Failed to fetch with the status codes of the server errors once retried",
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(nil, unavailableErr).Twice()
+				client.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(nil, notFoundErr).Once()
+			},
+			wantAttempts: []Attempt{
+				{Number: 1, StartedAt: time.Date(2021, 10, 15, 19, 28, 58, 0, time.UTC), StatusCode: 503, Err: unavailableErr},
+				{Number: 2, StartedAt: time.Date(2021, 10, 15, 19, 29, 58, 0, time.UTC), StatusCode: 503, Err: unavailableErr},
+				{Number: 3, StartedAt: time.Date(2021, 10, 15, 19, 30, 58, 0, time.UTC), StatusCode: 404, Err: notFoundErr},
+			},
+			wantErrMsg: "api failure with status code 404 and message: not found; unable to fetch resource",
+		},
+		{
+			name: "Failed to fetch without a retry",
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(nil, notFoundErr).Once()
+			},
+			wantErrMsg: "api failure with status code 404 and message: not found; unable to fetch resource",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := httputils.NewManualClock(time.Date(2021, 10, 15, 19, 28, 58, 0, time.UTC))
+			httpUtilsMock := &mockHttpUtils{}
+			tt.httpUtilsSetup(httpUtilsMock)
+
+			accountsClient, err := NewClient(
+				httpUtilsMock,
+				WithClock(clock),
+				WithSleeper(clock),
+				WithSLOPolicy(SLOBatch, SLOPolicy{MaxRetries: 2, RetryDelay: time.Minute}),
+			)
+			require.NoError(t, err)
+
+			_, err = accountsClient.FetchResource(context.Background(), NewAccountID(), WithSLOClass(SLOBatch))
+			require.Error(t, err)
+			assert.EqualError(t, err, tt.wantErrMsg)
+
+			var retryErr *RetryError
+			if tt.wantAttempts == nil {
+				assert.False(t, errors.As(err, &retryErr))
+			} else {
+				require.True(t, errors.As(err, &retryErr))
+				assert.Equal(t, tt.wantAttempts, retryErr.Attempts)
+			}
+
+			mock.AssertExpectationsForObjects(t, httpUtilsMock)
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package accounts
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// revalidation is the stale-while-revalidate config, see WithStaleWhileRevalidate
+type revalidation struct {
+	fresh        time.Duration
+	maxStaleness time.Duration
+}
+
+// WithStaleWhileRevalidate serves the fetches from the cache for the latency critical read paths, a copy younger than
+// fresh is served as it is, a copy younger than the max staleness is served right away and refreshed in the background,
+// an older one is fetched from form3, the fetches with sparse fieldsets always go to form3
+func WithStaleWhileRevalidate(fresh, maxStaleness time.Duration) Option {
+	return func(c *Client) error {
+		if fresh < 0 {
+			return fmt.Errorf("invalid fresh %s, it must not be negative", fresh)
+		}
+
+		if maxStaleness <= fresh {
+			return fmt.Errorf("invalid max staleness %s, it must be greater than the fresh %s", maxStaleness, fresh)
+		}
+
+		if c.cache == nil {
+			c.cache = newResourceCache()
+		}
+		c.revalidation = &revalidation{fresh: fresh, maxStaleness: maxStaleness}
+		return nil
+	}
+}
+
+// serveCached fills the result with the cached copy of the account when it is within the max staleness of the
+// stale-while-revalidate mode, refreshing it in the background once it is no longer fresh
+func (client *Client) serveCached(accountID AccountID, cfg callConfig) (*Result, bool) {
+	if client.revalidation == nil || cfg.skipCache || len(cfg.fields) > 0 {
+		return nil, false
+	}
+
+	entry, ok := client.cache.get(accountID)
+	if !ok {
+		return nil, false
+	}
+
+	age := client.now().Sub(entry.fetchedAt)
+	if age > client.revalidation.maxStaleness {
+		return nil, false
+	}
+
+	stale := age > client.revalidation.fresh
+	if stale {
+		client.revalidate(accountID, cfg)
+	}
+
+	result := client.newResult()
+	result.Data = entry.data.Clone()
+	result.Cache = CacheProvenance{Source: SourceCache, Stale: stale, FetchedAt: entry.fetchedAt}
+	return result, true
+}
+
+// WithoutCache fetches the account from form3 even when the stale-while-revalidate or the stale fallback modes could
+// serve a cached copy, for the operations which must act on the current state of the account, the fetched account is
+// still cached
+func WithoutCache() CallOption {
+	return func(cfg *callConfig) {
+		cfg.skipCache = true
+	}
+}
+
+// uncached returns the call options followed by WithoutCache, without changing the ones of the caller
+func uncached(opts []CallOption) []CallOption {
+	return append(append(make([]CallOption, 0, len(opts)+1), opts...), WithoutCache())
+}
+
+// revalidate refreshes the cached copy of the account in the background, once at a time per account, the refresh is
+// cancelled when the client is closed
+func (client *Client) revalidate(accountID AccountID, cfg callConfig) {
+	if !client.cache.startRefresh(accountID) {
+		return
+	}
+
+	refreshCfg := callConfig{sloClass: cfg.sloClass, organisationID: cfg.organisationID}
+	go func() {
+		defer client.cache.endRefresh(accountID)
+
+		ctx, cancel := client.closer.bind(context.Background())
+		defer cancel()
+
+		_, _ = client.fetch(ctx, accountID, refreshCfg)
+	}()
+}
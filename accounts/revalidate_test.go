@@ -0,0 +1,224 @@
+package accounts
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchStaleWhileRevalidate(t *testing.T) {
+	accountID := NewAccountID()
+
+	tests := []struct {
+		name           string
+		cachedAt       time.Duration
+		withoutCache   bool
+		opts           []CallOption
+		wantSource     DataSource
+		wantStale      bool
+		wantStatus     AccountStatus
+		wantFetches    int
+		wantRevalidate bool
+	}{
+		{
+			name:       "Successfully serves a fresh copy from the cache",
+			cachedAt:   -time.Second,
+			wantSource: SourceCache,
+			wantStatus: "pending",
+		},
+		{
+			name:           "Successfully serves a stale copy from the cache and refreshes it in the background",
+			cachedAt:       -10 * time.Second,
+			wantSource:     SourceCache,
+			wantStale:      true,
+			wantStatus:     "pending",
+			wantFetches:    1,
+			wantRevalidate: true,
+		},
+		{
+			name:        "Successfully fetches from form3 when the copy is older than the max staleness",
+			cachedAt:    -time.Hour,
+			wantSource:  SourceNetwork,
+			wantStatus:  "confirmed",
+			wantFetches: 1,
+		},
+		{
+			name:         "Successfully fetches from form3 when there is no cached copy",
+			withoutCache: true,
+			wantSource:   SourceNetwork,
+			wantStatus:   "confirmed",
+			wantFetches:  1,
+		},
+		{
+			name:        "Successfully fetches from form3 with the sparse fieldsets",
+			cachedAt:    -time.Second,
+			opts:        []CallOption{WithFields("status")},
+			wantSource:  SourceNetwork,
+			wantStatus:  "confirmed",
+			wantFetches: 1,
+		},
+		{
+			name:        "Successfully fetches from form3 without the cache",
+			cachedAt:    -time.Second,
+			opts:        []CallOption{WithoutCache()},
+			wantSource:  SourceNetwork,
+			wantStatus:  "confirmed",
+			wantFetches: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Date(2021, 10, 15, 19, 28, 58, 0, time.UTC)
+			httpUtilsMock := &mockHttpUtils{}
+			if tt.wantFetches > 0 {
+				httpUtilsMock.On("Get", mock.Anything, "/v1/organisation/accounts/"+accountID.String(), mock.Anything).Return(
+					statusPayload(t, accountID, "confirmed"), nil,
+				).Times(tt.wantFetches)
+			}
+
+			accountsClient, err := NewClient(httpUtilsMock, WithStaleWhileRevalidate(5*time.Second, time.Minute))
+			require.NoError(t, err)
+			accountsClient.now = func() time.Time { return now }
+
+			if !tt.withoutCache {
+				status := StatusPending
+				accountsClient.cache.set(accountID, &AccountData{
+					ID:         accountID.String(),
+					Attributes: &AccountAttributes{Status: &status},
+				}, now.Add(tt.cachedAt))
+			}
+
+			result, err := accountsClient.Fetch(context.Background(), accountID, tt.opts...)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantSource, result.Cache.Source)
+			assert.Equal(t, tt.wantStale, result.Cache.Stale)
+			assert.Equal(t, tt.wantStatus, *result.Data.Attributes.Status)
+
+			accountsClient.cache.refreshes.Wait()
+			if tt.wantRevalidate {
+				entry, ok := accountsClient.cache.get(accountID)
+				require.True(t, ok)
+				assert.Equal(t, StatusConfirmed, *entry.data.Attributes.Status)
+			}
+
+			mock.AssertExpectationsForObjects(t, httpUtilsMock)
+		})
+	}
+}
+
+func TestWithStaleWhileRevalidateInvalid(t *testing.T) {
+	tests := []struct {
+		name         string
+		fresh        time.Duration
+		maxStaleness time.Duration
+		wantErrMsg   string
+	}{
+		{
+			name:         "Failed to create the client with a negative fresh",
+			fresh:        -time.Second,
+			maxStaleness: time.Minute,
+			wantErrMsg:   "invalid fresh -1s, it must not be negative; invalid option",
+		},
+		{
+			name:         "Failed to create the client with a max staleness not greater than the fresh",
+			fresh:        time.Minute,
+			maxStaleness: time.Minute,
+			wantErrMsg:   "invalid max staleness 1m0s, it must be greater than the fresh 1m0s; invalid option",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewClient(&mockHttpUtils{}, WithStaleWhileRevalidate(tt.fresh, tt.maxStaleness))
+
+			require.Error(t, err)
+			assert.EqualError(t, err, tt.wantErrMsg)
+		})
+	}
+}
+
+func TestOperationsOnTheCurrentStateSkipTheCache(t *testing.T) {
+	accountID := NewAccountID()
+	country := CountryCode("GB")
+	desired := &AccountData{
+		ID:         accountID.String(),
+		Type:       "accounts",
+		Attributes: &AccountAttributes{Country: &country, BankID: "400300", Bic: "NWBKGB22"},
+	}
+
+	current := desired.Clone()
+	current.Version = 3
+	status := StatusConfirmed
+	current.Attributes.Status = &status
+	currentPayload, err := json.Marshal(&Payload{Data: current})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		call           func(*Client) error
+		httpUtilsSetup func(*mockHttpUtils)
+	}{
+		{
+			name: "Successfully deletes the current version",
+			call: func(c *Client) error {
+				return c.DeleteResourceLatest(context.Background(), accountID)
+			},
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Delete", mock.Anything, mock.Anything, map[string]string{"version": "3"}).Return(nil).Once()
+			},
+		},
+		{
+			name: "Successfully applies against the current state",
+			call: func(c *Client) error {
+				result, err := c.Apply(context.Background(), desired)
+				if err == nil {
+					assert.Equal(t, ApplyUnchanged, result.Action)
+				}
+				return err
+			},
+		},
+		{
+			name: "Successfully waits for the current status",
+			call: func(c *Client) error {
+				ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+				defer cancel()
+
+				accountData, err := c.WaitForTerminalStatus(ctx, accountID, ConstantBackoff(time.Millisecond))
+				if err == nil {
+					assert.Equal(t, StatusConfirmed, accountData.Status())
+				}
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpUtilsMock := &mockHttpUtils{}
+			httpUtilsMock.On("Get", mock.Anything, "/v1/organisation/accounts/"+accountID.String(), mock.Anything).Return(currentPayload, nil).Once()
+			if tt.httpUtilsSetup != nil {
+				tt.httpUtilsSetup(httpUtilsMock)
+			}
+
+			accountsClient, err := NewClient(httpUtilsMock, WithStaleWhileRevalidate(time.Hour, 2*time.Hour))
+			require.NoError(t, err)
+
+			cached := desired.Clone()
+			cached.Version = 1
+			cached.Attributes.Bic = "BARCGB22"
+			pending := StatusPending
+			cached.Attributes.Status = &pending
+			accountsClient.cache.set(accountID, cached, accountsClient.now())
+
+			require.NoError(t, tt.call(&accountsClient))
+
+			mock.AssertExpectationsForObjects(t, httpUtilsMock)
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package accounts
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// SchemaValidator validates the marshalled payload of a request against a schema, the contract.Spec of the embedded
+// accounts specification implements it
+type SchemaValidator interface {
+	ValidateRequest(method, requestPath string, body []byte) ([]string, error)
+}
+
+// SchemaError lists the paths of the payload violating the schema, such as data.attributes.country is required
+type SchemaError struct {
+	Problems []string
+}
+
+func (err *SchemaError) Error() string {
+	return fmt.Sprintf("invalid request payload: %s", strings.Join(err.Problems, "; "))
+}
+
+// WithSchemaValidation validates the marshalled create payload against the schema before sending it, so the mistakes
+// fail locally with the paths of the violations instead of a generic bad request from form3
+func WithSchemaValidation(validator SchemaValidator) Option {
+	return func(c *Client) error {
+		if validator == nil {
+			return errors.New("invalid schema validator, it must not be nil")
+		}
+
+		c.schemaValidator = validator
+		return nil
+	}
+}
+
+// checkSchema validates the payload of the method against the schema, when the client validates them
+func (client *Client) checkSchema(method string, payload []byte) error {
+	if client.schemaValidator == nil {
+		return nil
+	}
+
+	problems, err := client.schemaValidator.ValidateRequest(method, client.apiPath(accountsPath), payload)
+	if err != nil {
+		return fmt.Errorf("%w; unable to validate the payload schema", err)
+	}
+	if len(problems) > 0 {
+		return &SchemaError{Problems: problems}
+	}
+
+	return nil
+}
@@ -0,0 +1,121 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"renatoaraujo/form3-account-api-client/contract"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type schemaValidatorFunc func(method, requestPath string, body []byte) ([]string, error)
+
+func (f schemaValidatorFunc) ValidateRequest(method, requestPath string, body []byte) ([]string, error) {
+	return f(method, requestPath, body)
+}
+
+func TestCreateWithSchemaValidation(t *testing.T) {
+	country := CountryCode("GB")
+
+	tests := []struct {
+		name           string
+		validator      SchemaValidator
+		accountData    *AccountData
+		httpUtilsSetup func(*mockHttpUtils)
+		wantErr        bool
+		wantErrMsg     string
+		wantProblems   []string
+	}{
+		{
+			name:      "Successfully creates the account matching the schema",
+			validator: contract.AccountsSpec(),
+			accountData: &AccountData{
+				ID:             uuid.New().String(),
+				OrganisationID: uuid.New().String(),
+				Type:           "accounts",
+				Attributes:     &AccountAttributes{Country: &country, Name: []string{"Jane Doe"}},
+			},
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Post", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
+					loadTestFile("./testdata/api_response.json"),
+					nil,
+				).Once()
+			},
+		},
+		{
+			name:      "Failed to create the account violating the schema",
+			validator: contract.AccountsSpec(),
+			accountData: &AccountData{
+				ID:             uuid.New().String(),
+				OrganisationID: uuid.New().String(),
+				Type:           "accounts",
+				Attributes:     &AccountAttributes{Name: []string{"Jane Doe"}},
+			},
+			wantErr:      true,
+			wantErrMsg:   "invalid request payload: data.attributes.country is required; unable to create resource",
+			wantProblems: []string{"data.attributes.country is required"},
+		},
+		{
+			name: "Failed to create the account when the schema validation fails",
+			validator: schemaValidatorFunc(func(string, string, []byte) ([]string, error) {
+				return nil, errors.New("broken schema")
+			}),
+			accountData: &AccountData{ID: uuid.New().String()},
+			wantErr:     true,
+			wantErrMsg:  "broken schema; unable to validate the payload schema; unable to create resource",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpUtilsMock := &mockHttpUtils{}
+			if tt.httpUtilsSetup != nil {
+				tt.httpUtilsSetup(httpUtilsMock)
+			}
+
+			accountsClient, err := NewClient(httpUtilsMock, WithSchemaValidation(tt.validator))
+			require.NoError(t, err)
+
+			_, err = accountsClient.Create(context.Background(), tt.accountData)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.wantErrMsg)
+			} else {
+				require.NoError(t, err)
+			}
+
+			if tt.wantProblems != nil {
+				var schemaErr *SchemaError
+				require.ErrorAs(t, err, &schemaErr)
+				assert.Equal(t, tt.wantProblems, schemaErr.Problems)
+			}
+
+			mock.AssertExpectationsForObjects(t, httpUtilsMock)
+		})
+	}
+}
+
+func TestCheckSchemaValidatesThePathOfTheClient(t *testing.T) {
+	var gotMethod, gotPath string
+	accountsClient, err := NewClient(&mockHttpUtils{}, WithSchemaValidation(schemaValidatorFunc(func(method, requestPath string, _ []byte) ([]string, error) {
+		gotMethod, gotPath = method, requestPath
+		return nil, nil
+	})))
+	require.NoError(t, err)
+
+	require.NoError(t, accountsClient.checkSchema("POST", []byte(`{}`)))
+	assert.Equal(t, "POST", gotMethod)
+	assert.Equal(t, "/v1/organisation/accounts", gotPath)
+}
+
+func TestWithSchemaValidationWithoutAValidator(t *testing.T) {
+	_, err := NewClient(&mockHttpUtils{}, WithSchemaValidation(nil))
+
+	require.Error(t, err)
+	assert.EqualError(t, err, "invalid schema validator, it must not be nil; invalid option")
+}
@@ -0,0 +1,113 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"renatoaraujo/form3-account-api-client/httputils"
+)
+
+// SLOClass names a bundle of call policies configured once per client, so the operations can be tagged with the
+// class instead of repeating timeouts and retries at every call site
+type SLOClass string
+
+const (
+	// SLOPaymentCritical is the class meant for the operations on the payment path
+	SLOPaymentCritical SLOClass = "payment-critical"
+	// SLOBatch is the class meant for background and bulk operations
+	SLOBatch SLOClass = "batch"
+)
+
+// SLOPolicy is the preset bundle applied to the operations tagged with a SLO class
+type SLOPolicy struct {
+	// Timeout is the deadline of the whole operation including the retries, zero means no deadline
+	Timeout time.Duration
+	// MaxRetries is how many times the operation is retried when form3 is unreachable, fails with a server error or
+	// throttles the request, the creates are only retried when they have an idempotency key, see WithIdempotencyKey
+	MaxRetries int
+	// RetryDelay is the wait between the retries
+	RetryDelay time.Duration
+	// Backoff is the wait before each retry, such as ExponentialBackoff or DecorrelatedJitterBackoff, it takes
+	// precedence over the retry delay
+	Backoff Backoff
+	// Priority is the priority of the operations in the request queue, see WithRequestQueue
+	Priority Priority
+}
+
+// WithSLOPolicy registers the policy bundle for a SLO class on the client
+func WithSLOPolicy(class SLOClass, policy SLOPolicy) Option {
+	return func(c *Client) error {
+		if class == "" {
+			return errors.New("invalid slo class, it must not be empty")
+		}
+
+		if policy.Timeout < 0 || policy.MaxRetries < 0 || policy.RetryDelay < 0 {
+			return fmt.Errorf("invalid policy %+v for slo class %q, it must not be negative", policy, class)
+		}
+
+		c.sloPolicies[class] = policy
+		return nil
+	}
+}
+
+// WithSLOClass tags the operation with a SLO class registered on the client with WithSLOPolicy
+func WithSLOClass(class SLOClass) CallOption {
+	return func(cfg *callConfig) {
+		cfg.sloClass = class
+	}
+}
+
+// do performs the operation applying the policy of the SLO class the call was tagged with, the kind of the operation
+// picks its retry and deadline policies and it is set on the request error of a failure
+func (client *Client) do(ctx context.Context, kind Operation, cfg callConfig, operation func(ctx context.Context) error) error {
+	if client.closer.isClosed() {
+		return ErrClientClosed
+	}
+
+	ctx, stop := client.closer.bind(ctx)
+	defer stop()
+
+	if organisationID := cfg.organisation(client.organisationID); organisationID != uuid.Nil {
+		ctx = httputils.ContextWithOrganisation(ctx, organisationID)
+	}
+
+	ctx, operation, finish := recordCallInfo(ctx, client.now, cfg.callInfo, operation)
+	defer finish()
+
+	policy := SLOPolicy{}
+	if cfg.sloClass != "" {
+		var ok bool
+		if policy, ok = client.sloPolicies[cfg.sloClass]; !ok {
+			return fmt.Errorf("unknown slo class %q", cfg.sloClass)
+		}
+	}
+
+	if timeout := client.timeout(kind, cfg, policy); timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	priority := policy.Priority
+	if cfg.priority != nil {
+		priority = *cfg.priority
+	}
+
+	recorder := newAttemptsRecorder(client.now)
+	operation = client.queue.queued(priority, recorder.record(operation))
+	backoff := policy.Backoff
+	if backoff == nil {
+		backoff = ConstantBackoff(policy.RetryDelay)
+	}
+	err := retry(ctx, cfg.retries(kind, policy.MaxRetries), backoff, client.retryBudget, client.sleeper, operation)
+	if err != nil && client.closer.isClosed() {
+		return ErrClientClosed
+	}
+
+	tagOperation(err, string(kind))
+	return recorder.report(err)
+}
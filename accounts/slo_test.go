@@ -0,0 +1,270 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"renatoaraujo/form3-account-api-client/httputils"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSLOClass(t *testing.T) {
+	unreachableErr := &url.Error{Op: "Get", URL: "https://api.form3.tech", Err: errors.New("connection refused")}
+
+	tests := []struct {
+		name           string
+		class          SLOClass
+		httpUtilsSetup func(*mockHttpUtils)
+		wantErr        bool
+		wantErrMsg     string
+	}{
+		{
+			name:  "Successfully fetches with the deadline of the slo class",
+			class: SLOPaymentCritical,
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Get", mock.MatchedBy(func(ctx context.Context) bool {
+					_, ok := ctx.Deadline()
+					return ok
+				}), mock.Anything, mock.Anything).Return(loadTestFile("./testdata/api_response.json"), nil)
+			},
+			wantErr: false,
+		},
+		{
+			name:  "Successfully fetches after retrying while form3 is unreachable",
+			class: SLOPaymentCritical,
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(nil, unreachableErr).Twice()
+				client.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(loadTestFile("./testdata/api_response.json"), nil).Once()
+			},
+			wantErr: false,
+		},
+		{
+			name:  "Failed to fetch when form3 is still unreachable after all the retries",
+			class: SLOPaymentCritical,
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(nil, unreachableErr).Times(3)
+			},
+			wantErr:    true,
+			wantErrMsg: `Get "https://api.form3.tech": connection refused; unable to fetch resource`,
+		},
+		{
+			name:  "Successfully fetches after retrying a server error and a throttled request",
+			class: SLOPaymentCritical,
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(nil, &httputils.StatusError{StatusCode: http.StatusServiceUnavailable}).Once()
+				client.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(nil, &httputils.StatusError{StatusCode: http.StatusTooManyRequests}).Once()
+				client.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(loadTestFile("./testdata/api_response.json"), nil).Once()
+			},
+			wantErr: false,
+		},
+		{
+			name:  "Failed to fetch without retrying an api error",
+			class: SLOPaymentCritical,
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("api failure")).Once()
+			},
+			wantErr:    true,
+			wantErrMsg: "api failure; unable to fetch resource",
+		},
+		{
+			name:  "Failed to fetch without retrying when the slo class has no retries",
+			class: SLOBatch,
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(nil, unreachableErr).Once()
+			},
+			wantErr:    true,
+			wantErrMsg: `Get "https://api.form3.tech": connection refused; unable to fetch resource`,
+		},
+		{
+			name:       "Failed to fetch with an unknown slo class",
+			class:      "unknown",
+			wantErr:    true,
+			wantErrMsg: `unknown slo class "unknown"; unable to fetch resource`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpUtilsMock := &mockHttpUtils{}
+			if tt.httpUtilsSetup != nil {
+				tt.httpUtilsSetup(httpUtilsMock)
+			}
+
+			accountsClient, err := NewClient(
+				httpUtilsMock,
+				WithSLOPolicy(SLOPaymentCritical, SLOPolicy{Timeout: time.Second, MaxRetries: 2}),
+				WithSLOPolicy(SLOBatch, SLOPolicy{Timeout: time.Minute}),
+			)
+			require.NoError(t, err)
+
+			accountData, err := accountsClient.FetchResource(context.Background(), NewAccountID(), WithSLOClass(tt.class))
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.wantErrMsg)
+			} else {
+				require.NoError(t, err)
+				assert.IsType(t, &AccountData{}, accountData)
+			}
+
+			mock.AssertExpectationsForObjects(t, httpUtilsMock)
+		})
+	}
+}
+
+func TestRetryStopsWhenTheContextIsDone(t *testing.T) {
+	unreachableErr := &url.Error{Op: "Get", URL: "https://api.form3.tech", Err: errors.New("connection refused")}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	attempts := 0
+	err := retry(ctx, 5, ConstantBackoff(time.Minute), nil, httputils.SystemClock{}, func(context.Context) error {
+		attempts++
+		return unreachableErr
+	})
+
+	require.Error(t, err)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestCreateIsRetriedOnlyWithAnIdempotencyKey(t *testing.T) {
+	unreachableErr := &url.Error{Op: "Post", URL: "https://api.form3.tech", Err: errors.New("connection refused")}
+
+	tests := []struct {
+		name           string
+		opts           []CallOption
+		httpUtilsSetup func(*mockHttpUtils)
+		wantErr        bool
+		wantErrMsg     string
+	}{
+		{
+			name: "Failed to create without retrying when the call has no idempotency key",
+			opts: []CallOption{WithSLOClass(SLOPaymentCritical)},
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Post", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, unreachableErr).Once()
+			},
+			wantErr:    true,
+			wantErrMsg: `Post "https://api.form3.tech": connection refused; unable to create resource`,
+		},
+		{
+			name: "Successfully creates after retrying with the idempotency key",
+			opts: []CallOption{WithSLOClass(SLOPaymentCritical), WithIdempotencyKey("a-key")},
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				withKey := mock.MatchedBy(func(header http.Header) bool {
+					return header.Get("Idempotency-Key") == "a-key"
+				})
+				client.On("Post", mock.Anything, mock.Anything, mock.Anything, withKey).Return(nil, unreachableErr).Twice()
+				client.On("Post", mock.Anything, mock.Anything, mock.Anything, withKey).Return(loadTestFile("./testdata/api_response.json"), nil).Once()
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpUtilsMock := &mockHttpUtils{}
+			tt.httpUtilsSetup(httpUtilsMock)
+
+			accountsClient, err := NewClient(
+				httpUtilsMock,
+				WithSLOPolicy(SLOPaymentCritical, SLOPolicy{Timeout: time.Second, MaxRetries: 2}),
+			)
+			require.NoError(t, err)
+
+			accountData, err := accountsClient.CreateResource(context.Background(), &AccountData{ID: uuid.New().String()}, tt.opts...)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.wantErrMsg)
+			} else {
+				require.NoError(t, err)
+				assert.IsType(t, &AccountData{}, accountData)
+			}
+
+			mock.AssertExpectationsForObjects(t, httpUtilsMock)
+		})
+	}
+}
+
+func TestOperationsRetries(t *testing.T) {
+	for operation := range idempotentOperations {
+		assert.NoError(t, operation.Validate())
+	}
+
+	for _, operation := range operations {
+		t.Run(string(operation), func(t *testing.T) {
+			want := 2
+			if operation == OperationCreate || operation == OperationUpdate {
+				want = 0
+			}
+
+			assert.Equal(t, want, callConfig{}.retries(operation, 2))
+			assert.Equal(t, 2, callConfig{idempotencyKey: "key"}.retries(operation, 2))
+		})
+	}
+}
+
+func TestUpdateIsRetriedOnlyWithAnIdempotencyKey(t *testing.T) {
+	unreachableErr := &url.Error{Op: "Patch", URL: "https://api.form3.tech", Err: errors.New("connection refused")}
+
+	tests := []struct {
+		name           string
+		opts           []CallOption
+		httpUtilsSetup func(*mockHttpUtils)
+		wantErr        bool
+		wantErrMsg     string
+	}{
+		{
+			name: "Failed to update without retrying when the call has no idempotency key",
+			opts: []CallOption{WithSLOClass(SLOPaymentCritical)},
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Patch", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, unreachableErr).Once()
+			},
+			wantErr:    true,
+			wantErrMsg: `Patch "https://api.form3.tech": connection refused; unable to update resource`,
+		},
+		{
+			name: "Successfully updates after retrying with the idempotency key",
+			opts: []CallOption{WithSLOClass(SLOPaymentCritical), WithIdempotencyKey("a-key")},
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				withKey := mock.MatchedBy(func(header http.Header) bool {
+					return header.Get("Idempotency-Key") == "a-key"
+				})
+				client.On("Patch", mock.Anything, mock.Anything, mock.Anything, withKey).Return(nil, unreachableErr).Twice()
+				client.On("Patch", mock.Anything, mock.Anything, mock.Anything, withKey).Return(loadTestFile("./testdata/api_response.json"), nil).Once()
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpUtilsMock := &mockHttpUtils{}
+			tt.httpUtilsSetup(httpUtilsMock)
+
+			accountsClient, err := NewClient(
+				httpUtilsMock,
+				WithSLOPolicy(SLOPaymentCritical, SLOPolicy{Timeout: time.Second, MaxRetries: 2}),
+			)
+			require.NoError(t, err)
+
+			accountData, err := accountsClient.UpdateResource(context.Background(), &AccountData{ID: uuid.New().String()}, tt.opts...)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.wantErrMsg)
+			} else {
+				require.NoError(t, err)
+				assert.IsType(t, &AccountData{}, accountData)
+			}
+
+			mock.AssertExpectationsForObjects(t, httpUtilsMock)
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package accounts
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Sort is an order of the account list, a field sorted ascending or descending
+type Sort string
+
+const (
+	// SortByCreatedOn sorts the accounts from the oldest to the newest
+	SortByCreatedOn Sort = "created_on"
+	// SortByCreatedOnDesc sorts the accounts from the newest to the oldest
+	SortByCreatedOnDesc Sort = "-created_on"
+	// SortByModifiedOn sorts the accounts from the least to the most recently modified
+	SortByModifiedOn Sort = "modified_on"
+	// SortByModifiedOnDesc sorts the accounts from the most to the least recently modified
+	SortByModifiedOnDesc Sort = "-modified_on"
+	// SortByID sorts the accounts by id, the tie breaker of a deterministic order
+	SortByID Sort = "id"
+	// SortByIDDesc sorts the accounts by id in descending order
+	SortByIDDesc Sort = "-id"
+)
+
+var sortFields = map[string]bool{
+	"created_on":  true,
+	"modified_on": true,
+	"id":          true,
+}
+
+// field returns the field sorted by, without the direction
+func (sort Sort) field() string {
+	return strings.TrimPrefix(string(sort), "-")
+}
+
+// validateSort checks the sort fields are known and sorted once
+func validateSort(sorts []Sort) error {
+	seen := map[string]bool{}
+	for _, sort := range sorts {
+		field := sort.field()
+		if !sortFields[field] {
+			return fmt.Errorf("invalid sort %q, it must be one of created_on, modified_on or id, prefixed with - to sort in descending order", sort)
+		}
+
+		if seen[field] {
+			return fmt.Errorf("invalid sort %q, the field %s is sorted more than once", sort, field)
+		}
+		seen[field] = true
+	}
+
+	return nil
+}
+
+// sortParams returns the fields of the sort query parameter
+func sortParams(sorts []Sort) []string {
+	params := make([]string, len(sorts))
+	for i, sort := range sorts {
+		params[i] = string(sort)
+	}
+
+	return params
+}
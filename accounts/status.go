@@ -0,0 +1,52 @@
+package accounts
+
+import (
+	"context"
+	"fmt"
+)
+
+// AccountStatus is the status of the account, decoded from the status attribute
+type AccountStatus string
+
+const (
+	// StatusPending is an account form3 is still processing
+	StatusPending AccountStatus = "pending"
+	// StatusConfirmed is an account form3 confirmed, it can be used
+	StatusConfirmed AccountStatus = "confirmed"
+	// StatusFailed is an account form3 failed to process
+	StatusFailed AccountStatus = "failed"
+	// StatusClosed is an account closed by its holder
+	StatusClosed AccountStatus = "closed"
+)
+
+// Validate checks the status is a known one
+func (status AccountStatus) Validate() error {
+	switch status {
+	case StatusPending, StatusConfirmed, StatusFailed, StatusClosed:
+		return nil
+	default:
+		return fmt.Errorf("invalid account status %q, it must be pending, confirmed, failed or closed", string(status))
+	}
+}
+
+// IsTerminal tells if the status is final, form3 does not change a confirmed, failed or closed account on its own
+func (status AccountStatus) IsTerminal() bool {
+	return status == StatusConfirmed || status == StatusFailed || status == StatusClosed
+}
+
+// Status returns the status of the account, empty when form3 sent none
+func (accountData *AccountData) Status() AccountStatus {
+	if accountData == nil || accountData.Attributes == nil || accountData.Attributes.Status == nil {
+		return ""
+	}
+
+	return *accountData.Attributes.Status
+}
+
+// WaitForTerminalStatus polls an account resource until its status is terminal, such as a pending account becoming
+// confirmed or failed, see WaitForResource for the backoff and the failures
+func (client *Client) WaitForTerminalStatus(ctx context.Context, accountID AccountID, backoff Backoff, opts ...CallOption) (*AccountData, error) {
+	return client.WaitForResource(ctx, accountID, func(accountData *AccountData) bool {
+		return accountData.Status().IsTerminal()
+	}, backoff, opts...)
+}
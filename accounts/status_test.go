@@ -0,0 +1,113 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountStatus(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       AccountStatus
+		wantTerminal bool
+		wantErr      bool
+		wantErrMsg   string
+	}{
+		{name: "Pending is not terminal", status: StatusPending},
+		{name: "Confirmed is terminal", status: StatusConfirmed, wantTerminal: true},
+		{name: "Failed is terminal", status: StatusFailed, wantTerminal: true},
+		{name: "Closed is terminal", status: StatusClosed, wantTerminal: true},
+		{
+			name:       "Unknown status is not terminal",
+			status:     "archived",
+			wantErr:    true,
+			wantErrMsg: `invalid account status "archived", it must be pending, confirmed, failed or closed`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantTerminal, tt.status.IsTerminal())
+
+			err := tt.status.Validate()
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.wantErrMsg)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAccountDataStatus(t *testing.T) {
+	status := StatusFailed
+
+	assert.Equal(t, StatusFailed, (&AccountData{Attributes: &AccountAttributes{Status: &status}}).Status())
+	assert.Equal(t, AccountStatus(""), (&AccountData{}).Status())
+	assert.Equal(t, AccountStatus(""), (*AccountData)(nil).Status())
+}
+
+func TestClientWaitForTerminalStatus(t *testing.T) {
+	accountID := NewAccountID()
+
+	tests := []struct {
+		name           string
+		httpUtilsSetup func(*mockHttpUtils)
+		wantStatus     AccountStatus
+		wantErr        bool
+		wantErrMsg     string
+	}{
+		{
+			name: "Successfully waits for the account to be confirmed",
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(statusPayload(t, accountID, StatusPending), nil).Twice()
+				client.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(statusPayload(t, accountID, StatusConfirmed), nil).Once()
+			},
+			wantStatus: StatusConfirmed,
+		},
+		{
+			name: "Successfully waits for the account to fail",
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(statusPayload(t, accountID, StatusPending), nil).Once()
+				client.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(statusPayload(t, accountID, StatusFailed), nil).Once()
+			},
+			wantStatus: StatusFailed,
+		},
+		{
+			name: "Failed to wait for the account when the fetch fails",
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("api failure")).Once()
+			},
+			wantErr:    true,
+			wantErrMsg: "api failure; unable to fetch resource; unable to wait for resource",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpUtilsMock := &mockHttpUtils{}
+			tt.httpUtilsSetup(httpUtilsMock)
+
+			accountsClient, err := NewClient(httpUtilsMock)
+			require.NoError(t, err)
+
+			accountData, err := accountsClient.WaitForTerminalStatus(context.Background(), accountID, ConstantBackoff(time.Millisecond))
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.wantErrMsg)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantStatus, accountData.Status())
+			}
+
+			mock.AssertExpectationsForObjects(t, httpUtilsMock)
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package accounts
+
+import (
+	"context"
+	"fmt"
+)
+
+// AccountCursor pulls the accounts of a list one at a time, fetching the pages lazily so only one page is held in
+// memory, it is not safe for concurrent use
+type AccountCursor struct {
+	ctx      context.Context
+	client   *Client
+	listOpts ListOptions
+	opts     []CallOption
+
+	page    []*AccountData
+	index   int
+	current *AccountData
+	last    bool
+	err     error
+}
+
+// ListStream returns a cursor over every account matching the filters of the list options, starting from their page
+// number, the pages are fetched on demand with the page size of the list options, the largest one when it is zero
+//
+//	cursor := client.ListStream(ctx, accounts.ListOptions{})
+//	for cursor.Next() {
+//		accountData := cursor.Value()
+//	}
+//	if err := cursor.Err(); err != nil {
+//		return err
+//	}
+func (client *Client) ListStream(ctx context.Context, listOpts ListOptions, opts ...CallOption) *AccountCursor {
+	if listOpts.PageSize == 0 {
+		listOpts.PageSize = maxPageSize
+	}
+
+	return &AccountCursor{
+		ctx:      ctx,
+		client:   client,
+		listOpts: listOpts,
+		opts:     opts,
+	}
+}
+
+// Next advances the cursor to the next account, fetching the next page when the current one is consumed, it returns
+// false at the end of the list, when the context is done or when a page fails, see Err
+func (cursor *AccountCursor) Next() bool {
+	cursor.current = nil
+	if cursor.err != nil {
+		return false
+	}
+
+	if err := cursor.ctx.Err(); err != nil {
+		cursor.err = err
+		return false
+	}
+
+	if cursor.index == len(cursor.page) {
+		if cursor.last || !cursor.fetch() {
+			return false
+		}
+	}
+
+	cursor.current = cursor.page[cursor.index]
+	cursor.index++
+	return true
+}
+
+// fetch fetches the next page, it tells if the page has any account
+func (cursor *AccountCursor) fetch() bool {
+	result, err := cursor.client.List(cursor.ctx, cursor.listOpts, cursor.opts...)
+	if err != nil {
+		cursor.err = fmt.Errorf("%w; unable to stream the page %d", err, cursor.listOpts.PageNumber)
+		return false
+	}
+
+	cursor.page, cursor.index = result.Data, 0
+	cursor.last = len(result.Data) < cursor.listOpts.PageSize
+	if links := result.Meta.Links; links != nil && links.Next == "" {
+		cursor.last = true
+	}
+	cursor.listOpts.PageNumber++
+
+	return len(result.Data) > 0
+}
+
+// Value returns the account the cursor is at, nil before the first call to Next and once it returns false
+func (cursor *AccountCursor) Value() *AccountData {
+	return cursor.current
+}
+
+// Err returns the failure which stopped the cursor, nil when it reached the end of the list
+func (cursor *AccountCursor) Err() error {
+	return cursor.err
+}
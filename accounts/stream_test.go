@@ -0,0 +1,136 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientListStream(t *testing.T) {
+	page := func(number string) interface{} {
+		return mock.MatchedBy(func(query map[string]string) bool {
+			return query["page[number]"] == number && query["page[size]"] == "2"
+		})
+	}
+	firstPage := mock.MatchedBy(func(query map[string]string) bool {
+		_, ok := query["page[number]"]
+		return !ok && query["page[size]"] == "2"
+	})
+	// nextPage is a full page linking to the next one
+	nextPage := []byte(`{"data": [{"id": "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc"}, {"id": "ad27e265-9605-4b4b-a0e5-3003ea9cc4dd"}], "links": {"next": "/v1/organisation/accounts?page%5Bnumber%5D=next"}}`)
+
+	tests := []struct {
+		name           string
+		listOpts       ListOptions
+		httpUtilsSetup func(*mockHttpUtils)
+		wantCount      int
+		wantErr        bool
+		wantErrMsg     string
+	}{
+		{
+			name:     "Successfully streams every page until an empty one",
+			listOpts: ListOptions{PageSize: 2},
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Get", mock.Anything, mock.Anything, firstPage).Return(nextPage, nil).Once()
+				client.On("Get", mock.Anything, mock.Anything, page("1")).Return(nextPage, nil).Once()
+				client.On("Get", mock.Anything, mock.Anything, page("2")).Return([]byte(`{"data": []}`), nil).Once()
+			},
+			wantCount: 4,
+		},
+		{
+			name:     "Successfully streams until a page without a next link without fetching the next one",
+			listOpts: ListOptions{PageSize: 2},
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Get", mock.Anything, mock.Anything, firstPage).Return(loadTestFile("./testdata/api_list_response.json"), nil).Once()
+			},
+			wantCount: 2,
+		},
+		{
+			name:     "Successfully streams until a short page without fetching the next one",
+			listOpts: ListOptions{PageSize: 3},
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(loadTestFile("./testdata/api_list_response.json"), nil).Once()
+			},
+			wantCount: 2,
+		},
+		{
+			name:     "Successfully streams from the page of the list options with the largest page size",
+			listOpts: ListOptions{PageNumber: 4},
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Get", mock.Anything, mock.Anything, map[string]string{"page[number]": "4", "page[size]": "100"}).Return(loadTestFile("./testdata/api_list_response.json"), nil).Once()
+			},
+			wantCount: 2,
+		},
+		{
+			name:     "Failed to stream when a page fails",
+			listOpts: ListOptions{PageSize: 2},
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Get", mock.Anything, mock.Anything, firstPage).Return(nextPage, nil).Once()
+				client.On("Get", mock.Anything, mock.Anything, page("1")).Return(nil, errors.New("api failure")).Once()
+			},
+			wantCount:  2,
+			wantErr:    true,
+			wantErrMsg: "api failure; unable to list resources; unable to stream the page 1",
+		},
+		{
+			name:       "Failed to stream with invalid list options",
+			listOpts:   ListOptions{PageNumber: -1},
+			wantErr:    true,
+			wantErrMsg: "invalid page number, it must not be negative; unable to list resources; unable to stream the page -1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpUtilsMock := &mockHttpUtils{}
+			if tt.httpUtilsSetup != nil {
+				tt.httpUtilsSetup(httpUtilsMock)
+			}
+
+			accountsClient, err := NewClient(httpUtilsMock)
+			require.NoError(t, err)
+
+			cursor := accountsClient.ListStream(context.Background(), tt.listOpts)
+			assert.Nil(t, cursor.Value())
+
+			count := 0
+			for cursor.Next() {
+				require.NotNil(t, cursor.Value())
+				count++
+			}
+
+			assert.Equal(t, tt.wantCount, count)
+			assert.Nil(t, cursor.Value())
+			assert.False(t, cursor.Next(), "the cursor stays stopped")
+			if tt.wantErr {
+				require.Error(t, cursor.Err())
+				assert.EqualError(t, cursor.Err(), tt.wantErrMsg)
+			} else {
+				require.NoError(t, cursor.Err())
+			}
+
+			mock.AssertExpectationsForObjects(t, httpUtilsMock)
+		})
+	}
+}
+
+func TestClientListStreamStopsWhenTheContextIsDone(t *testing.T) {
+	httpUtilsMock := &mockHttpUtils{}
+	httpUtilsMock.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(loadTestFile("./testdata/api_list_response.json"), nil).Once()
+
+	accountsClient, err := NewClient(httpUtilsMock)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cursor := accountsClient.ListStream(ctx, ListOptions{PageSize: 2})
+	require.True(t, cursor.Next())
+
+	cancel()
+	assert.False(t, cursor.Next())
+	assert.ErrorIs(t, cursor.Err(), context.Canceled)
+	mock.AssertExpectationsForObjects(t, httpUtilsMock)
+}
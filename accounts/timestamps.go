@@ -0,0 +1,24 @@
+package accounts
+
+import "time"
+
+// CreatedBefore tells if the account was created before the cutoff, such as for a retention job, an account without
+// a creation timestamp, such as one not created yet, is not
+func (accountData *AccountData) CreatedBefore(cutoff time.Time) bool {
+	return accountData.CreatedOn != nil && accountData.CreatedOn.Before(cutoff)
+}
+
+// ModifiedBefore tells if the account was last modified before the cutoff, an account without a modification
+// timestamp is not
+func (accountData *AccountData) ModifiedBefore(cutoff time.Time) bool {
+	return accountData.ModifiedOn != nil && accountData.ModifiedOn.Before(cutoff)
+}
+
+// Age returns how long ago the account was created, zero when it has no creation timestamp
+func (accountData *AccountData) Age(now time.Time) time.Duration {
+	if accountData.CreatedOn == nil {
+		return 0
+	}
+
+	return now.Sub(*accountData.CreatedOn)
+}
@@ -0,0 +1,91 @@
+package accounts
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountDataTimestamps(t *testing.T) {
+	tests := []struct {
+		name           string
+		payload        string
+		wantCreatedOn  *time.Time
+		wantModifiedOn *time.Time
+		wantErr        bool
+		wantErrMsg     string
+	}{
+		{
+			name:           "Successfully decodes the timestamps with milliseconds in utc",
+			payload:        `{"created_on": "2021-10-15T19:28:58.772Z", "modified_on": "2021-10-16T09:12:03Z"}`,
+			wantCreatedOn:  timePtr(time.Date(2021, 10, 15, 19, 28, 58, 772000000, time.UTC)),
+			wantModifiedOn: timePtr(time.Date(2021, 10, 16, 9, 12, 3, 0, time.UTC)),
+		},
+		{
+			name:          "Successfully decodes a timestamp with an offset",
+			payload:       `{"created_on": "2021-10-15T20:28:58.772+01:00"}`,
+			wantCreatedOn: timePtr(time.Date(2021, 10, 15, 19, 28, 58, 772000000, time.UTC)),
+		},
+		{
+			name:    "Successfully decodes an account without timestamps",
+			payload: `{}`,
+		},
+		{
+			name:       "Failed to decode a timestamp which is not rfc3339",
+			payload:    `{"created_on": "2021-10-15 19:28:58"}`,
+			wantErr:    true,
+			wantErrMsg: `parsing time "2021-10-15 19:28:58" as "2006-01-02T15:04:05Z07:00": cannot parse " 19:28:58" as "T"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accountData := &AccountData{}
+			err := json.Unmarshal([]byte(tt.payload), accountData)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.wantErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			assertSameTime(t, tt.wantCreatedOn, accountData.CreatedOn)
+			assertSameTime(t, tt.wantModifiedOn, accountData.ModifiedOn)
+		})
+	}
+}
+
+func TestAccountDataAge(t *testing.T) {
+	now := time.Date(2022, 10, 15, 0, 0, 0, 0, time.UTC)
+	createdOn := now.Add(-400 * 24 * time.Hour)
+	modifiedOn := now.Add(-time.Hour)
+
+	accountData := &AccountData{CreatedOn: &createdOn, ModifiedOn: &modifiedOn}
+	assert.True(t, accountData.CreatedBefore(now.AddDate(-1, 0, 0)))
+	assert.False(t, accountData.ModifiedBefore(now.AddDate(-1, 0, 0)))
+	assert.Equal(t, 400*24*time.Hour, accountData.Age(now))
+
+	unsaved := &AccountData{}
+	assert.False(t, unsaved.CreatedBefore(now))
+	assert.False(t, unsaved.ModifiedBefore(now))
+	assert.Equal(t, time.Duration(0), unsaved.Age(now))
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
+
+func assertSameTime(t *testing.T, want, got *time.Time) {
+	t.Helper()
+
+	if want == nil {
+		assert.Nil(t, got)
+		return
+	}
+
+	require.NotNil(t, got)
+	assert.True(t, want.Equal(*got), "want %s, got %s", want, got)
+}
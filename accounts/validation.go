@@ -0,0 +1,232 @@
+package accounts
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"renatoaraujo/form3-account-api-client/validation"
+)
+
+// CountryRule is the set of rules form3 applies to the attributes of the accounts of a country
+// see https://api-docs.form3.tech/api.html#organisation-accounts-resource
+type CountryRule struct {
+	// BankIDRequired tells if the bank id must be given
+	BankIDRequired bool
+	// BankIDSupported tells if the bank id can be given at all
+	BankIDSupported bool
+	// BankIDLengths are the accepted lengths of the bank id
+	BankIDLengths []int
+	// BankIDNumeric tells if the bank id must only contain digits
+	BankIDNumeric bool
+	// BankIDCode is the bank id code expected when the bank id is given
+	BankIDCode string
+	// BICRequired tells if the bic must be given
+	BICRequired bool
+	// AccountNumberMinLength and AccountNumberMaxLength bound the length of the account number when given
+	AccountNumberMinLength int
+	AccountNumberMaxLength int
+	// IbanSupported tells if the iban can be given
+	IbanSupported bool
+}
+
+var (
+	countryRulesMu sync.RWMutex
+	countryRules   = map[CountryCode]CountryRule{
+		"AU": {BankIDSupported: true, BankIDLengths: []int{6}, BankIDNumeric: true, BankIDCode: "AUBSB", BICRequired: true, AccountNumberMinLength: 6, AccountNumberMaxLength: 10},
+		"BE": {BankIDRequired: true, BankIDSupported: true, BankIDLengths: []int{3}, BankIDNumeric: true, BankIDCode: "BE", AccountNumberMinLength: 7, AccountNumberMaxLength: 7, IbanSupported: true},
+		"CA": {BankIDSupported: true, BankIDLengths: []int{9}, BankIDNumeric: true, BankIDCode: "CACPA", BICRequired: true, AccountNumberMinLength: 7, AccountNumberMaxLength: 12},
+		"CH": {BankIDRequired: true, BankIDSupported: true, BankIDLengths: []int{5}, BankIDNumeric: true, BankIDCode: "CHBCC", AccountNumberMinLength: 12, AccountNumberMaxLength: 12, IbanSupported: true},
+		"DE": {BankIDRequired: true, BankIDSupported: true, BankIDLengths: []int{8}, BankIDNumeric: true, BankIDCode: "DEBLZ", AccountNumberMinLength: 7, AccountNumberMaxLength: 7, IbanSupported: true},
+		"ES": {BankIDRequired: true, BankIDSupported: true, BankIDLengths: []int{8}, BankIDNumeric: true, BankIDCode: "ESNCC", AccountNumberMinLength: 10, AccountNumberMaxLength: 10, IbanSupported: true},
+		"FR": {BankIDRequired: true, BankIDSupported: true, BankIDLengths: []int{10}, BankIDCode: "FR", AccountNumberMinLength: 10, AccountNumberMaxLength: 10, IbanSupported: true},
+		"GB": {BankIDRequired: true, BankIDSupported: true, BankIDCode: "GBDSC", BICRequired: true, AccountNumberMinLength: 8, AccountNumberMaxLength: 8, IbanSupported: true},
+		"GR": {BankIDRequired: true, BankIDSupported: true, BankIDLengths: []int{7}, BankIDNumeric: true, BankIDCode: "GRBIC", AccountNumberMinLength: 16, AccountNumberMaxLength: 16, IbanSupported: true},
+		"HK": {BankIDSupported: true, BankIDLengths: []int{3}, BankIDNumeric: true, BankIDCode: "HKNCC", BICRequired: true, AccountNumberMinLength: 9, AccountNumberMaxLength: 12},
+		"IT": {BankIDRequired: true, BankIDSupported: true, BankIDLengths: []int{10, 11}, BankIDCode: "ITNCC", AccountNumberMinLength: 12, AccountNumberMaxLength: 12, IbanSupported: true},
+		"LU": {BankIDRequired: true, BankIDSupported: true, BankIDLengths: []int{3}, BankIDNumeric: true, BankIDCode: "LULUX", AccountNumberMinLength: 13, AccountNumberMaxLength: 13, IbanSupported: true},
+		"NL": {BICRequired: true, AccountNumberMinLength: 10, AccountNumberMaxLength: 10, IbanSupported: true},
+		"PL": {BankIDRequired: true, BankIDSupported: true, BankIDLengths: []int{8}, BankIDNumeric: true, BankIDCode: "PLKNR", AccountNumberMinLength: 16, AccountNumberMaxLength: 16, IbanSupported: true},
+		"PT": {BankIDRequired: true, BankIDSupported: true, BankIDLengths: []int{8}, BankIDNumeric: true, BankIDCode: "PTNCC", AccountNumberMinLength: 11, AccountNumberMaxLength: 11, IbanSupported: true},
+		"US": {BankIDRequired: true, BankIDSupported: true, BankIDLengths: []int{9}, BankIDNumeric: true, BankIDCode: "USABA", BICRequired: true, AccountNumberMinLength: 6, AccountNumberMaxLength: 17},
+	}
+)
+
+// RegisterCountryRule sets the rule validating the accounts of a country, replacing the built-in one if any
+func RegisterCountryRule(country CountryCode, rule CountryRule) {
+	countryRulesMu.Lock()
+	defer countryRulesMu.Unlock()
+
+	countryRules[country] = rule
+}
+
+func countryRule(country CountryCode) (CountryRule, bool) {
+	countryRulesMu.RLock()
+	defer countryRulesMu.RUnlock()
+
+	rule, ok := countryRules[country]
+	return rule, ok
+}
+
+// ValidationError lists the problems found validating the account data locally
+type ValidationError struct {
+	Problems []string
+}
+
+func (err *ValidationError) Error() string {
+	return fmt.Sprintf("invalid account data: %s", strings.Join(err.Problems, "; "))
+}
+
+// Validate checks the account data against the rules of its country, so the mistakes are caught before reaching form3
+func (accountData *AccountData) Validate() error {
+	var problems []string
+
+	attributes := accountData.Attributes
+	if attributes == nil || attributes.Country == nil || *attributes.Country == "" {
+		problems = append(problems, "country is required")
+	} else if err := attributes.Country.Validate(); err != nil {
+		problems = append(problems, err.Error())
+	} else if rule, ok := countryRule(*attributes.Country); ok {
+		problems = append(problems, rule.check(*attributes.Country, attributes)...)
+	}
+
+	if attributes != nil {
+		problems = append(problems, enumProblems(attributes)...)
+		problems = append(problems, identifierProblems(attributes)...)
+		problems = append(problems, copProblems(attributes)...)
+	}
+
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+
+	return nil
+}
+
+// check returns the problems of the attributes according to the rule
+func (rule CountryRule) check(country CountryCode, attributes *AccountAttributes) []string {
+	var problems []string
+
+	switch {
+	case attributes.BankID == "" && rule.BankIDRequired:
+		problems = append(problems, fmt.Sprintf("%s requires bank_id", country))
+	case attributes.BankID != "" && !rule.BankIDSupported:
+		problems = append(problems, fmt.Sprintf("%s does not support bank_id", country))
+	case attributes.BankID != "":
+		if !hasLength(attributes.BankID, rule.BankIDLengths) {
+			problems = append(problems, fmt.Sprintf("%s requires bank_id to have %s characters", country, joinLengths(rule.BankIDLengths)))
+		}
+		if rule.BankIDNumeric && !isNumeric(attributes.BankID) {
+			problems = append(problems, fmt.Sprintf("%s requires bank_id to only contain digits", country))
+		}
+		if attributes.BankIDCode != rule.BankIDCode {
+			problems = append(problems, fmt.Sprintf("%s requires bank_id_code to be %s", country, rule.BankIDCode))
+		}
+	}
+
+	if attributes.Bic == "" && rule.BICRequired {
+		problems = append(problems, fmt.Sprintf("%s requires bic", country))
+	}
+
+	if length := len(attributes.AccountNumber); length > 0 && (length < rule.AccountNumberMinLength || length > rule.AccountNumberMaxLength) {
+		if rule.AccountNumberMinLength == rule.AccountNumberMaxLength {
+			problems = append(problems, fmt.Sprintf("%s requires account_number to have %d characters", country, rule.AccountNumberMinLength))
+		} else {
+			problems = append(problems, fmt.Sprintf("%s requires account_number to have between %d and %d characters", country, rule.AccountNumberMinLength, rule.AccountNumberMaxLength))
+		}
+	}
+
+	if attributes.Iban != "" && !rule.IbanSupported {
+		problems = append(problems, fmt.Sprintf("%s does not support iban", country))
+	}
+
+	return problems
+}
+
+// enumProblems checks the classification and the currency given are known values
+func enumProblems(attributes *AccountAttributes) []string {
+	var problems []string
+
+	if attributes.AccountClassification != nil {
+		if err := attributes.AccountClassification.Validate(); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+
+	if attributes.BaseCurrency != "" {
+		if err := attributes.BaseCurrency.Validate(); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+
+	if attributes.Status != nil {
+		if err := attributes.Status.Validate(); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+
+	return problems
+}
+
+// identifierProblems checks the format of the bank identifiers given, whatever the country is
+func identifierProblems(attributes *AccountAttributes) []string {
+	var problems []string
+
+	if attributes.BankIDCode == "GBDSC" && attributes.BankID != "" {
+		if err := validation.SortCode(attributes.BankID); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+
+	if attributes.Bic != "" {
+		if err := validation.BIC(attributes.Bic); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+
+	if attributes.Iban != "" {
+		if err := validation.IBAN(attributes.Iban); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+
+	return problems
+}
+
+func hasLength(value string, lengths []int) bool {
+	for _, length := range lengths {
+		if len(value) == length {
+			return true
+		}
+	}
+
+	return len(lengths) == 0
+}
+
+func joinLengths(lengths []int) string {
+	joined := make([]string, len(lengths))
+	for i, length := range lengths {
+		joined[i] = fmt.Sprint(length)
+	}
+
+	return strings.Join(joined, " or ")
+}
+
+func isNumeric(value string) bool {
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// WithValidation validates the account data locally before creating it, the problems are returned as a
+// ValidationError without reaching form3
+func WithValidation() Option {
+	return func(c *Client) error {
+		c.validate = true
+		return nil
+	}
+}
@@ -0,0 +1,177 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountDataValidate(t *testing.T) {
+	country := func(code CountryCode) *CountryCode {
+		return &code
+	}
+
+	tests := []struct {
+		name         string
+		accountData  *AccountData
+		wantProblems []string
+	}{
+		{
+			name:         "Failed to validate an account without attributes",
+			accountData:  &AccountData{},
+			wantProblems: []string{"country is required"},
+		},
+		{
+			name: "Failed to validate a GB account without bic and with an invalid sort code",
+			accountData: &AccountData{Attributes: &AccountAttributes{
+				Country:    country("GB"),
+				BankID:     "40-30",
+				BankIDCode: "GBDSC",
+			}},
+			wantProblems: []string{
+				"GB requires bic",
+				`invalid sort code "40-30", it must have 6 digits without separators`,
+			},
+		},
+		{
+			name: "Failed to validate a GB account without bank id",
+			accountData: &AccountData{Attributes: &AccountAttributes{
+				Country: country("GB"),
+				Bic:     "NWBKGB22",
+			}},
+			wantProblems: []string{"GB requires bank_id"},
+		},
+		{
+			name: "Failed to validate an AU account with iban and a wrong bank id code",
+			accountData: &AccountData{Attributes: &AccountAttributes{
+				Country:       country("AU"),
+				BankID:        "123456",
+				BankIDCode:    "GBDSC",
+				Bic:           "NWBKAU22",
+				AccountNumber: "12345",
+				Iban:          "GB33BUKB20201555555555",
+			}},
+			wantProblems: []string{
+				"AU requires bank_id_code to be AUBSB",
+				"AU requires account_number to have between 6 and 10 characters",
+				"AU does not support iban",
+			},
+		},
+		{
+			name: "Failed to validate a NL account with bank id",
+			accountData: &AccountData{Attributes: &AccountAttributes{
+				Country: country("NL"),
+				BankID:  "123",
+				Bic:     "ABNANL2A",
+			}},
+			wantProblems: []string{"NL does not support bank_id"},
+		},
+		{
+			name: "Failed to validate a GB account with an invalid bic and iban",
+			accountData: &AccountData{Attributes: &AccountAttributes{
+				Country:    country("GB"),
+				BankID:     "400300",
+				BankIDCode: "GBDSC",
+				Bic:        "NWBK",
+				Iban:       "GB34BUKB20201555555555",
+			}},
+			wantProblems: []string{
+				`invalid bic "NWBK", it must have 8 or 11 characters`,
+				`invalid iban "GB34BUKB20201555555555", the check digits do not match`,
+			},
+		},
+		{
+			name: "Failed to validate an account with unknown country, classification and currency",
+			accountData: &AccountData{Attributes: &AccountAttributes{
+				Country:               country("UK"),
+				AccountClassification: func(c Classification) *Classification { return &c }("personal"),
+				BaseCurrency:          "GPB",
+			}},
+			wantProblems: []string{
+				`invalid country "UK", it must be an ISO 3166-1 alpha-2 code`,
+				`invalid account classification "personal", it must be Personal or Business`,
+				`invalid currency "GPB", it must be an ISO 4217 code`,
+			},
+		},
+		{
+			name: "Failed to validate a FR account with a confirmation of payee status",
+			accountData: &AccountData{Attributes: &AccountAttributes{
+				Country:            country("FR"),
+				BankID:             "2004101005",
+				BankIDCode:         "FR",
+				NameMatchingStatus: NameMatchingSupported,
+			}},
+			wantProblems: []string{"FR does not support confirmation of payee"},
+		},
+		{
+			name: "Failed to validate a GB account with an unknown name matching status",
+			accountData: &AccountData{Attributes: &AccountAttributes{
+				Country:            country("GB"),
+				BankID:             "400300",
+				BankIDCode:         "GBDSC",
+				Bic:                "NWBKGB22",
+				NameMatchingStatus: "matched",
+			}},
+			wantProblems: []string{`invalid name matching status "matched", it must be supported, switched, opted_out or not_supported`},
+		},
+		{
+			name: "Successfully validates a GB account",
+			accountData: &AccountData{Attributes: &AccountAttributes{
+				Country:       country("GB"),
+				BankID:        "400300",
+				BankIDCode:    "GBDSC",
+				Bic:           "NWBKGB22",
+				AccountNumber: "41426819",
+			}},
+		},
+		{
+			name: "Successfully validates an account of a country without rules",
+			accountData: &AccountData{Attributes: &AccountAttributes{
+				Country: country("BR"),
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.accountData.Validate()
+			if tt.wantProblems == nil {
+				require.NoError(t, err)
+				return
+			}
+
+			var validationErr *ValidationError
+			require.True(t, errors.As(err, &validationErr))
+			assert.Equal(t, tt.wantProblems, validationErr.Problems)
+		})
+	}
+}
+
+func TestRegisterCountryRule(t *testing.T) {
+	RegisterCountryRule("IE", CountryRule{BICRequired: true})
+	defer func() {
+		countryRulesMu.Lock()
+		delete(countryRules, "IE")
+		countryRulesMu.Unlock()
+	}()
+
+	country := CountryCode("IE")
+	err := (&AccountData{Attributes: &AccountAttributes{Country: &country}}).Validate()
+	assert.EqualError(t, err, "invalid account data: IE requires bic")
+}
+
+func TestCreateResourceWithValidation(t *testing.T) {
+	httpUtilsMock := &mockHttpUtils{}
+
+	accountsClient, err := NewClient(httpUtilsMock, WithValidation())
+	require.NoError(t, err)
+
+	_, err = accountsClient.CreateResource(context.Background(), &AccountData{})
+	assert.EqualError(t, err, "invalid account data: country is required; unable to create resource")
+
+	mock.AssertExpectationsForObjects(t, httpUtilsMock)
+}
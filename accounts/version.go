@@ -0,0 +1,47 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Version is the version of an account resource used for the optimistic locking, form3 increments it on each update
+// and the update and the delete of a stale version fail with a VersionConflictError
+type Version int
+
+// Next returns the version the resource has once it is updated
+func (version Version) Next() Version {
+	return version + 1
+}
+
+// NextVersion returns the version the account has once it is updated
+func (accountData *AccountData) NextVersion() Version {
+	return accountData.Version.Next()
+}
+
+// Ref returns the reference of the account at its current version, such as the one it was fetched with
+func (accountData *AccountData) Ref() (AccountRef, error) {
+	accountID, err := accountData.AccountID()
+	if err != nil {
+		return AccountRef{}, err
+	}
+
+	return AccountRef{ID: accountID, Version: accountData.Version}, nil
+}
+
+// DeleteAccount deletes an account resource at the version of the given account data, the version of a fetched
+// account is propagated to the delete so it is not tracked by hand, a VersionConflictError is returned when the
+// account was updated since
+func (client *Client) DeleteAccount(ctx context.Context, accountData *AccountData, opts ...CallOption) error {
+	if accountData == nil {
+		return errors.New("invalid account data, it must not be nil; unable to delete resource")
+	}
+
+	ref, err := accountData.Ref()
+	if err != nil {
+		return fmt.Errorf("%w; unable to delete resource", err)
+	}
+
+	return client.DeleteResource(ctx, ref.ID, ref.Version, opts...)
+}
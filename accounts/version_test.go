@@ -0,0 +1,70 @@
+package accounts
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountDataNextVersion(t *testing.T) {
+	accountData := &AccountData{Version: 2}
+
+	assert.Equal(t, Version(3), accountData.NextVersion())
+	assert.Equal(t, Version(2), accountData.Version)
+}
+
+func TestDeleteAccount(t *testing.T) {
+	accountID := NewAccountID()
+
+	tests := []struct {
+		name           string
+		accountData    *AccountData
+		httpUtilsSetup func(*mockHttpUtils)
+		wantErr        bool
+		wantErrMsg     string
+	}{
+		{
+			name:        "Successfully deletes an account at the version it was fetched with",
+			accountData: &AccountData{ID: accountID.String(), Version: 4},
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Delete", mock.Anything, "/v1/organisation/accounts/"+accountID.String(), map[string]string{"version": "4"}).Return(nil)
+			},
+		},
+		{
+			name:        "Failed to delete an account with an invalid id",
+			accountData: &AccountData{ID: "invalid"},
+			wantErr:     true,
+			wantErrMsg:  "invalid UUID length: 7; invalid account id; unable to delete resource",
+		},
+		{
+			name:       "Failed to delete a nil account",
+			wantErr:    true,
+			wantErrMsg: "invalid account data, it must not be nil; unable to delete resource",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpUtilsMock := &mockHttpUtils{}
+			if tt.httpUtilsSetup != nil {
+				tt.httpUtilsSetup(httpUtilsMock)
+			}
+
+			accountsClient, err := NewClient(httpUtilsMock)
+			require.NoError(t, err)
+
+			err = accountsClient.DeleteAccount(context.Background(), tt.accountData)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.wantErrMsg)
+			} else {
+				require.NoError(t, err)
+			}
+
+			mock.AssertExpectationsForObjects(t, httpUtilsMock)
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// defaultWaitBackoff is the backoff of WaitForResource when none is given
+var defaultWaitBackoff = ConstantBackoff(time.Second)
+
+// WaitForResource polls an account resource until the predicate is met, such as its status becoming confirmed, or the
+// context is done, a not found account is polled again as it may not be visible yet, the other failures stop the
+// polling, a nil backoff polls every second, the account is always fetched from form3, never from the cache
+func (client *Client) WaitForResource(ctx context.Context, accountID AccountID, predicate func(*AccountData) bool, backoff Backoff, opts ...CallOption) (*AccountData, error) {
+	if predicate == nil {
+		return nil, errors.New("invalid predicate, it must not be nil; unable to wait for resource")
+	}
+
+	if backoff == nil {
+		backoff = defaultWaitBackoff
+	}
+
+	ctx, stop := client.closer.bind(ctx)
+	defer stop()
+
+	opts = uncached(opts)
+	for attempt := 1; ; attempt++ {
+		accountData, err := client.FetchResource(ctx, accountID, opts...)
+		if err == nil && predicate(accountData) {
+			return accountData, nil
+		}
+
+		if err != nil && !isStatus(err, http.StatusNotFound) {
+			return nil, fmt.Errorf("%w; unable to wait for resource", err)
+		}
+
+		if err := client.sleeper.Sleep(ctx, backoff.Delay(attempt)); err != nil {
+			if client.closer.isClosed() {
+				return nil, fmt.Errorf("%w; unable to wait for resource", ErrClientClosed)
+			}
+			return nil, fmt.Errorf("%w; unable to wait for resource", err)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package accounts
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"renatoaraujo/form3-account-api-client/httputils"
+)
+
+func statusPayload(t *testing.T, accountID AccountID, status AccountStatus) []byte {
+	t.Helper()
+
+	payload, err := json.Marshal(&Payload{Data: &AccountData{
+		ID:         accountID.String(),
+		Attributes: &AccountAttributes{Status: &status},
+	}})
+	require.NoError(t, err)
+
+	return payload
+}
+
+func TestClientWaitForResource(t *testing.T) {
+	accountID := NewAccountID()
+	isConfirmed := func(accountData *AccountData) bool {
+		return accountData.Attributes.Status != nil && *accountData.Attributes.Status == "confirmed"
+	}
+
+	tests := []struct {
+		name           string
+		predicate      func(*AccountData) bool
+		timeout        time.Duration
+		httpUtilsSetup func(*mockHttpUtils)
+		wantErr        bool
+		wantErrMsg     string
+	}{
+		{
+			name:      "Successfully waits for the account to be confirmed",
+			predicate: isConfirmed,
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(statusPayload(t, accountID, "pending"), nil).Twice()
+				client.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(statusPayload(t, accountID, "confirmed"), nil).Once()
+			},
+		},
+		{
+			name:      "Successfully waits for the account to be visible",
+			predicate: isConfirmed,
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(nil, &httputils.ResponseError{StatusCode: 404, ErrorMessage: "not found"}).Once()
+				client.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(statusPayload(t, accountID, "confirmed"), nil).Once()
+			},
+		},
+		{
+			name:      "Failed to wait for the account when the fetch fails",
+			predicate: isConfirmed,
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("api failure")).Once()
+			},
+			wantErr:    true,
+			wantErrMsg: "api failure; unable to fetch resource; unable to wait for resource",
+		},
+		{
+			name:      "Failed to wait for the account when the context is done",
+			predicate: isConfirmed,
+			timeout:   20 * time.Millisecond,
+			httpUtilsSetup: func(client *mockHttpUtils) {
+				client.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(statusPayload(t, accountID, "pending"), nil)
+			},
+			wantErr:    true,
+			wantErrMsg: "context deadline exceeded; unable to wait for resource",
+		},
+		{
+			name:       "Failed to wait for the account without a predicate",
+			wantErr:    true,
+			wantErrMsg: "invalid predicate, it must not be nil; unable to wait for resource",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpUtilsMock := &mockHttpUtils{}
+			if tt.httpUtilsSetup != nil {
+				tt.httpUtilsSetup(httpUtilsMock)
+			}
+
+			accountsClient, err := NewClient(httpUtilsMock)
+			require.NoError(t, err)
+
+			ctx := context.Background()
+			if tt.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.timeout)
+				defer cancel()
+			}
+
+			accountData, err := accountsClient.WaitForResource(ctx, accountID, tt.predicate, ConstantBackoff(time.Millisecond))
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.wantErrMsg)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, StatusConfirmed, *accountData.Attributes.Status)
+				mock.AssertExpectationsForObjects(t, httpUtilsMock)
+			}
+		})
+	}
+}
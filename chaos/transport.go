@@ -0,0 +1,210 @@
+// Package chaos injects faults into the http interactions with form3, latency, connection resets, bursts of server
+// errors and malformed bodies, so the retry and circuit breaker configuration of the clients can be verified, the
+// account client retries the connection resets and the server errors while the malformed bodies fail the decoding
+package chaos
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"net"
+	"net/http"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// serverErrorBody is the body of the injected server errors, shaped like the form3 errors
+const serverErrorBody = `{"error_message": "chaos: injected server error"}`
+
+// Option configures the faults of the transport
+type Option func(*Transport) error
+
+// WithLatency delays the given rate of the requests, from 0 to 1, by the latency
+func WithLatency(rate float64, latency time.Duration) Option {
+	return func(t *Transport) error {
+		if err := validateRate(rate); err != nil {
+			return err
+		}
+
+		if latency < 0 {
+			return fmt.Errorf("invalid latency %s, it must not be negative", latency)
+		}
+
+		t.latencyRate, t.latency = rate, latency
+		return nil
+	}
+}
+
+// WithConnectionResets fails the given rate of the requests, from 0 to 1, with a connection reset before they are sent
+func WithConnectionResets(rate float64) Option {
+	return func(t *Transport) error {
+		if err := validateRate(rate); err != nil {
+			return err
+		}
+
+		t.resetRate = rate
+		return nil
+	}
+}
+
+// WithServerErrors answers the given rate of the requests, from 0 to 1, with a 503 without sending them, each injected
+// error starts a burst failing the next requests too until burst requests failed
+func WithServerErrors(rate float64, burst int) Option {
+	return func(t *Transport) error {
+		if err := validateRate(rate); err != nil {
+			return err
+		}
+
+		if burst < 1 {
+			return fmt.Errorf("invalid burst %d, it must be positive", burst)
+		}
+
+		t.serverErrorRate, t.burst = rate, burst
+		return nil
+	}
+}
+
+// WithMalformedBodies truncates the response body of the given rate of the requests, from 0 to 1
+func WithMalformedBodies(rate float64) Option {
+	return func(t *Transport) error {
+		if err := validateRate(rate); err != nil {
+			return err
+		}
+
+		t.malformedRate = rate
+		return nil
+	}
+}
+
+// WithSeed seeds the random faults, so a run can be reproduced
+func WithSeed(seed int64) Option {
+	return func(t *Transport) error {
+		t.random = rand.New(rand.NewSource(seed))
+		return nil
+	}
+}
+
+// Transport injects the configured faults into the requests sent with the round tripper it wraps
+type Transport struct {
+	latencyRate     float64
+	latency         time.Duration
+	resetRate       float64
+	serverErrorRate float64
+	burst           int
+	malformedRate   float64
+
+	mu         sync.Mutex
+	random     *rand.Rand
+	burstsLeft int
+}
+
+// New creates a transport injecting the faults of the options, without options no fault is injected
+func New(opts ...Option) (*Transport, error) {
+	transport := &Transport{
+		random: rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+
+	for _, opt := range opts {
+		if err := opt(transport); err != nil {
+			return nil, fmt.Errorf("%w; invalid option", err)
+		}
+	}
+
+	return transport, nil
+}
+
+// Wrap returns the round tripper injecting the faults into the requests sent with next, see httputils.WithRoundTripper
+func (t *Transport) Wrap(next http.RoundTripper) http.RoundTripper {
+	return roundTripperFunc(func(request *http.Request) (*http.Response, error) {
+		if t.roll(t.latencyRate) {
+			timer := time.NewTimer(t.latency)
+			select {
+			case <-request.Context().Done():
+				timer.Stop()
+				return nil, request.Context().Err()
+			case <-timer.C:
+			}
+		}
+
+		if t.roll(t.resetRate) {
+			return nil, &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}
+		}
+
+		if t.serverError() {
+			return newResponse(request, http.StatusServiceUnavailable, []byte(serverErrorBody)), nil
+		}
+
+		response, err := next.RoundTrip(request)
+		if err != nil || !t.roll(t.malformedRate) {
+			return response, err
+		}
+
+		body, err := ioutil.ReadAll(response.Body)
+		_ = response.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%w; unable to read the body", err)
+		}
+
+		return newResponse(request, response.StatusCode, body[:len(body)/2]), nil
+	})
+}
+
+// roll tells if a fault of the rate is injected
+func (t *Transport) roll(rate float64) bool {
+	if rate == 0 {
+		return false
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.random.Float64() < rate
+}
+
+// serverError tells if a server error is injected, the one of a burst in progress or the start of a new burst
+func (t *Transport) serverError() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.burstsLeft > 0 {
+		t.burstsLeft--
+		return true
+	}
+
+	if t.serverErrorRate == 0 || t.random.Float64() >= t.serverErrorRate {
+		return false
+	}
+
+	t.burstsLeft = t.burst - 1
+	return true
+}
+
+func newResponse(request *http.Request, statusCode int, body []byte) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", statusCode, http.StatusText(statusCode)),
+		StatusCode:    statusCode,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          ioutil.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       request,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+	}
+}
+
+func validateRate(rate float64) error {
+	if rate < 0 || rate > 1 {
+		return fmt.Errorf("invalid rate %v, it must be between 0 and 1", rate)
+	}
+
+	return nil
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
@@ -0,0 +1,158 @@
+package chaos
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"renatoaraujo/form3-account-api-client/accounts"
+	"renatoaraujo/form3-account-api-client/httputils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name       string
+		opts       []Option
+		wantErr    bool
+		wantErrMsg string
+	}{
+		{
+			name: "Successfully creates the transport with all the faults",
+			opts: []Option{
+				WithLatency(0.1, time.Second),
+				WithConnectionResets(0.01),
+				WithServerErrors(0.05, 3),
+				WithMalformedBodies(1),
+				WithSeed(42),
+			},
+		},
+		{
+			name:       "Failed to create the transport with a rate above 1",
+			opts:       []Option{WithConnectionResets(1.5)},
+			wantErr:    true,
+			wantErrMsg: "invalid rate 1.5, it must be between 0 and 1; invalid option",
+		},
+		{
+			name:       "Failed to create the transport with a negative latency",
+			opts:       []Option{WithLatency(0.5, -time.Second)},
+			wantErr:    true,
+			wantErrMsg: "invalid latency -1s, it must not be negative; invalid option",
+		},
+		{
+			name:       "Failed to create the transport with an empty burst",
+			opts:       []Option{WithServerErrors(0.5, 0)},
+			wantErr:    true,
+			wantErrMsg: "invalid burst 0, it must be positive; invalid option",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport, err := New(tt.opts...)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.wantErrMsg)
+			} else {
+				require.NoError(t, err)
+				assert.NotNil(t, transport)
+			}
+		})
+	}
+}
+
+func TestTransportInjectsTheFaults(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data": {"id": "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc"}}`))
+	}))
+	defer server.Close()
+
+	newClient := func(t *testing.T, opts ...Option) *httputils.Client {
+		transport, err := New(append(opts, WithSeed(1))...)
+		require.NoError(t, err)
+
+		client, err := httputils.NewClient(server.URL, 5*time.Second, httputils.WithRoundTripper(transport.Wrap))
+		require.NoError(t, err)
+		return client
+	}
+
+	t.Run("Successfully sends the requests without faults", func(t *testing.T) {
+		body, err := newClient(t).Get(context.Background(), "/v1/organisation/accounts", nil)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"data": {"id": "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc"}}`, string(body))
+	})
+
+	t.Run("Failed with a connection reset", func(t *testing.T) {
+		_, err := newClient(t, WithConnectionResets(1)).Get(context.Background(), "/v1/organisation/accounts", nil)
+		require.Error(t, err)
+
+		var netErr net.Error
+		assert.ErrorAs(t, err, &netErr)
+	})
+
+	t.Run("Failed with a burst of server errors", func(t *testing.T) {
+		transport, err := New(WithServerErrors(1, 3), WithSeed(1))
+		require.NoError(t, err)
+
+		client, err := httputils.NewClient(server.URL, 5*time.Second, httputils.WithRoundTripper(transport.Wrap))
+		require.NoError(t, err)
+
+		for i := 0; i < 3; i++ {
+			_, err = client.Get(context.Background(), "/v1/organisation/accounts", nil)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "503")
+
+			// the burst started by the first error goes on without new ones
+			transport.serverErrorRate = 0
+		}
+
+		_, err = client.Get(context.Background(), "/v1/organisation/accounts", nil)
+		require.NoError(t, err)
+	})
+
+	t.Run("Successfully fetches once the account client retried a burst of server errors", func(t *testing.T) {
+		accountServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"data": {"id": "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc", "type": "accounts"}}`))
+		}))
+		defer accountServer.Close()
+
+		// the seed starts a burst of two server errors on the first request and none on the third
+		transport, err := New(WithServerErrors(0.5, 2), WithSeed(6))
+		require.NoError(t, err)
+
+		client, err := httputils.NewClient(accountServer.URL, 5*time.Second, httputils.WithRoundTripper(transport.Wrap))
+		require.NoError(t, err)
+
+		accountClient, err := accounts.NewClient(client, accounts.WithSLOPolicy(accounts.SLOPaymentCritical, accounts.SLOPolicy{MaxRetries: 2}))
+		require.NoError(t, err)
+
+		result, err := accountClient.Fetch(
+			context.Background(),
+			accounts.MustParseAccountID("ad27e265-9605-4b4b-a0e5-3003ea9cc4dc"),
+			accounts.WithSLOClass(accounts.SLOPaymentCritical),
+		)
+		require.NoError(t, err)
+		assert.Equal(t, 3, result.Meta.Attempts)
+		assert.Equal(t, "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc", result.Data.ID)
+	})
+
+	t.Run("Failed with a malformed body", func(t *testing.T) {
+		body, err := newClient(t, WithMalformedBodies(1)).Get(context.Background(), "/v1/organisation/accounts", nil)
+		require.NoError(t, err)
+		assert.Equal(t, `{"data": {"id": "ad27e265-96`, string(body))
+	})
+
+	t.Run("Failed when the context is done during the latency", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		_, err := newClient(t, WithLatency(1, time.Minute)).Get(ctx, "/v1/organisation/accounts", nil)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
@@ -0,0 +1,299 @@
+// Command form3-accounts performs the account operations of the form3 api from the command line, such as for the
+// support engineers and the scripts, the base uri and the credentials are read from the environment:
+//
+//	FORM3_BASE_URI         the base uri of the api, http://localhost:8080 by default
+//	FORM3_ACCESS_TOKEN     the bearer token sent in the Authorization header, if any
+//	FORM3_ORGANISATION_ID  the organisation the accounts are scoped to, if any
+//	FORM3_TIMEOUT          the timeout of the requests, such as 10s, 10s by default
+//
+// Usage:
+//
+//	form3-accounts create [-output format] -f account.json
+//	form3-accounts fetch [-output format] <account id>
+//	form3-accounts delete [-version n] <account id>
+//	form3-accounts list [-output format] [-filter field=value]... [-page n] [-size n]
+//
+// The output format is json, table or yaml, json by default, the exit code tells the failure so the scripts can
+// branch on it:
+//
+//	0  success
+//	1  any other failure, such as an invalid usage
+//	2  the account is not found
+//	3  the account conflicts with an existing one or the version is stale
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"renatoaraujo/form3-account-api-client/accounts"
+	"renatoaraujo/form3-account-api-client/httputils"
+)
+
+const (
+	defaultBaseURI = "http://localhost:8080"
+	defaultTimeout = 10 * time.Second
+)
+
+// The exit codes of the command
+const (
+	exitOK       = 0
+	exitFailure  = 1
+	exitNotFound = 2
+	exitConflict = 3
+)
+
+const usage = `usage: form3-accounts <command> [flags] [args]
+
+commands:
+  create -f <file>                               create the account of the json file, - reads stdin
+  fetch <account id>                             fetch an account
+  delete [-version n] <account id>               delete an account, the latest version when none is given
+  list [-filter field=value]... [-page n] [-size n]  list the accounts
+
+flags:
+  -output json|table|yaml                        the output format, json by default
+`
+
+func main() {
+	os.Exit(run(context.Background(), os.Args[1:], os.Getenv, os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run runs the command of the args returning the exit code
+func run(ctx context.Context, args []string, getenv func(string) string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return exitFailure
+	}
+
+	client, err := newClient(getenv)
+	if err != nil {
+		fmt.Fprintf(stderr, "form3-accounts: %s\n", err)
+		return exitFailure
+	}
+
+	command, args := args[0], args[1:]
+	flags := newFlagSet(command, stderr)
+	output := outputFormat(outputJSON)
+	flags.Var(&output, "output", "the output format, json, table or yaml")
+
+	var result interface{}
+	switch command {
+	case "create":
+		result, err = create(ctx, client, flags, args, stdin)
+	case "fetch":
+		result, err = fetch(ctx, client, flags, args)
+	case "delete":
+		err = remove(ctx, client, flags, args)
+	case "list":
+		result, err = list(ctx, client, flags, args)
+	default:
+		err = fmt.Errorf("unknown command %q\n%s", command, usage)
+	}
+
+	if err == nil && result != nil {
+		err = output.write(stdout, result)
+	}
+
+	if err != nil {
+		fmt.Fprintf(stderr, "form3-accounts: %s\n", err)
+		return exitCode(err)
+	}
+
+	return exitOK
+}
+
+// exitCode returns the exit code of the error, the not found and the conflict failures of the api have their own
+func exitCode(err error) int {
+	var conflictErr *accounts.VersionConflictError
+	if errors.As(err, &conflictErr) {
+		return exitConflict
+	}
+
+	var respErr *httputils.ResponseError
+	if !errors.As(err, &respErr) {
+		return exitFailure
+	}
+
+	switch respErr.StatusCode {
+	case http.StatusNotFound:
+		return exitNotFound
+	case http.StatusConflict:
+		return exitConflict
+	default:
+		return exitFailure
+	}
+}
+
+// newClient creates the account client from the environment
+func newClient(getenv func(string) string) (*accounts.Client, error) {
+	baseURI := getenv("FORM3_BASE_URI")
+	if baseURI == "" {
+		baseURI = defaultBaseURI
+	}
+
+	timeout := defaultTimeout
+	if value := getenv("FORM3_TIMEOUT"); value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("%w; invalid FORM3_TIMEOUT", err)
+		}
+		timeout = parsed
+	}
+
+	httpClient, err := httputils.NewClient(baseURI, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	if token := getenv("FORM3_ACCESS_TOKEN"); token != "" {
+		httpClient = httpClient.WithHeaders(http.Header{"Authorization": []string{"Bearer " + token}})
+	}
+
+	var opts []accounts.Option
+	if value := getenv("FORM3_ORGANISATION_ID"); value != "" {
+		organisationID, err := uuid.Parse(value)
+		if err != nil {
+			return nil, fmt.Errorf("%w; invalid FORM3_ORGANISATION_ID", err)
+		}
+		opts = append(opts, accounts.WithOrganisationID(organisationID))
+	}
+
+	client, err := accounts.NewClient(httpClient, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &client, nil
+}
+
+func create(ctx context.Context, client *accounts.Client, flags *flag.FlagSet, args []string, stdin io.Reader) (interface{}, error) {
+	file := flags.String("f", "", "the json file of the account, - reads stdin")
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+
+	if *file == "" {
+		return nil, errors.New("the json file of the account is required, see -f")
+	}
+
+	var input io.Reader = stdin
+	if *file != "-" {
+		opened, err := os.Open(*file)
+		if err != nil {
+			return nil, err
+		}
+		defer opened.Close()
+		input = opened
+	}
+
+	accountData := &accounts.AccountData{}
+	if err := json.NewDecoder(input).Decode(accountData); err != nil {
+		return nil, fmt.Errorf("%w; invalid account json", err)
+	}
+
+	return client.CreateResource(ctx, accountData)
+}
+
+func fetch(ctx context.Context, client *accounts.Client, flags *flag.FlagSet, args []string) (interface{}, error) {
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+
+	accountID, err := accountIDArg(flags)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.FetchResource(ctx, accountID)
+}
+
+func remove(ctx context.Context, client *accounts.Client, flags *flag.FlagSet, args []string) error {
+	version := flags.Int("version", -1, "the version of the account, the latest one when not given")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	accountID, err := accountIDArg(flags)
+	if err != nil {
+		return err
+	}
+
+	if *version < 0 {
+		return client.DeleteResourceLatest(ctx, accountID)
+	}
+
+	return client.DeleteResource(ctx, accountID, accounts.Version(*version))
+}
+
+func list(ctx context.Context, client *accounts.Client, flags *flag.FlagSet, args []string) (interface{}, error) {
+	filters := filterFlag{}
+	flags.Var(filters, "filter", "a filter as field=value, such as country=GB, it can be repeated")
+	page := flags.Int("page", 0, "the page number")
+	size := flags.Int("size", 0, "the page size")
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+
+	data, err := client.ListResources(ctx, accounts.ListOptions{
+		Filters:    filters,
+		PageNumber: *page,
+		PageSize:   *size,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if data == nil {
+		data = []*accounts.AccountData{}
+	}
+
+	return data, nil
+}
+
+func newFlagSet(name string, stderr io.Writer) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	return flags
+}
+
+// accountIDArg parses the account id given as the only argument
+func accountIDArg(flags *flag.FlagSet) (accounts.AccountID, error) {
+	if flags.NArg() != 1 {
+		return accounts.AccountID{}, fmt.Errorf("the account id is required, usage: form3-accounts %s <account id>", flags.Name())
+	}
+
+	return accounts.ParseAccountID(flags.Arg(0))
+}
+
+// filterFlag collects the repeated filter flags
+type filterFlag map[accounts.FilterField]string
+
+func (f filterFlag) String() string {
+	filters := make([]string, 0, len(f))
+	for field, value := range f {
+		filters = append(filters, fmt.Sprintf("%s=%s", field, value))
+	}
+
+	return strings.Join(filters, ",")
+}
+
+func (f filterFlag) Set(value string) error {
+	field, filterValue, ok := strings.Cut(value, "=")
+	if !ok {
+		return fmt.Errorf("invalid filter %q, it must be field=value", value)
+	}
+
+	f[accounts.FilterField(field)] = filterValue
+	return nil
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"renatoaraujo/form3-account-api-client/accounts"
+	"renatoaraujo/form3-account-api-client/accounts/accountstest"
+	"renatoaraujo/form3-account-api-client/form3fake"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runCommand runs the command against the base uri returning the exit code, the stdout and the stderr
+func runCommand(t *testing.T, env map[string]string, stdin string, args ...string) (int, string, string) {
+	t.Helper()
+
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), args, func(key string) string {
+		return env[key]
+	}, strings.NewReader(stdin), &stdout, &stderr)
+
+	return code, stdout.String(), stderr.String()
+}
+
+func TestCommands(t *testing.T) {
+	server := form3fake.NewServer()
+	defer server.Close()
+
+	env := map[string]string{"FORM3_BASE_URI": server.URL, "FORM3_ACCESS_TOKEN": "token"}
+	accountData := accountstest.ValidGBAccount()
+	payload, err := json.Marshal(accountData)
+	require.NoError(t, err)
+
+	code, stdout, stderr := runCommand(t, env, string(payload), "create", "-f", "-")
+	require.Equal(t, 0, code, stderr)
+	created := &accounts.AccountData{}
+	require.NoError(t, json.Unmarshal([]byte(stdout), created))
+	assert.Equal(t, accountData.ID, created.ID)
+
+	code, stdout, stderr = runCommand(t, env, "", "fetch", accountData.ID)
+	require.Equal(t, 0, code, stderr)
+	fetched := &accounts.AccountData{}
+	require.NoError(t, json.Unmarshal([]byte(stdout), fetched))
+	assert.Equal(t, accountData.ID, fetched.ID)
+
+	code, stdout, stderr = runCommand(t, env, "", "list", "-filter", "country=GB", "-size", "10")
+	require.Equal(t, 0, code, stderr)
+	var listed []*accounts.AccountData
+	require.NoError(t, json.Unmarshal([]byte(stdout), &listed))
+	assert.Len(t, listed, 1)
+
+	code, stdout, stderr = runCommand(t, env, "", "list", "-filter", "country=FR")
+	require.Equal(t, 0, code, stderr)
+	assert.Equal(t, "[]\n", stdout)
+
+	code, _, stderr = runCommand(t, env, "", "delete", "-version", "0", accountData.ID)
+	require.Equal(t, 0, code, stderr)
+	assert.Equal(t, 0, server.Len())
+}
+
+func TestCommandsWithInvalidUsage(t *testing.T) {
+	env := map[string]string{"FORM3_BASE_URI": "http://localhost:8080"}
+
+	tests := []struct {
+		name       string
+		env        map[string]string
+		args       []string
+		wantErrMsg string
+	}{
+		{
+			name:       "Failed without a command",
+			env:        env,
+			wantErrMsg: "usage: form3-accounts",
+		},
+		{
+			name:       "Failed with an unknown command",
+			env:        env,
+			args:       []string{"update"},
+			wantErrMsg: `unknown command "update"`,
+		},
+		{
+			name:       "Failed to create without the json file",
+			env:        env,
+			args:       []string{"create"},
+			wantErrMsg: "the json file of the account is required, see -f",
+		},
+		{
+			name:       "Failed to fetch without the account id",
+			env:        env,
+			args:       []string{"fetch"},
+			wantErrMsg: "the account id is required, usage: form3-accounts fetch <account id>",
+		},
+		{
+			name:       "Failed to delete with an invalid account id",
+			env:        env,
+			args:       []string{"delete", "invalid"},
+			wantErrMsg: "invalid UUID length: 7",
+		},
+		{
+			name:       "Failed to list with an invalid filter",
+			env:        env,
+			args:       []string{"list", "-filter", "country"},
+			wantErrMsg: `invalid filter "country", it must be field=value`,
+		},
+		{
+			name:       "Failed to list with an invalid output",
+			env:        env,
+			args:       []string{"list", "-output", "xml"},
+			wantErrMsg: `invalid output "xml", it must be one of json, table or yaml`,
+		},
+		{
+			name:       "Failed with an invalid timeout",
+			env:        map[string]string{"FORM3_TIMEOUT": "soon"},
+			args:       []string{"fetch"},
+			wantErrMsg: "invalid FORM3_TIMEOUT",
+		},
+		{
+			name:       "Failed with an invalid organisation id",
+			env:        map[string]string{"FORM3_ORGANISATION_ID": "invalid"},
+			args:       []string{"fetch"},
+			wantErrMsg: "invalid FORM3_ORGANISATION_ID",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, stdout, stderr := runCommand(t, tt.env, "", tt.args...)
+			assert.Equal(t, 1, code)
+			assert.Empty(t, stdout)
+			assert.Contains(t, stderr, tt.wantErrMsg)
+		})
+	}
+}
+
+func TestExitCodes(t *testing.T) {
+	server := form3fake.NewServer()
+	defer server.Close()
+
+	env := map[string]string{"FORM3_BASE_URI": server.URL}
+	accountData := accountstest.ValidGBAccount()
+	payload, err := json.Marshal(accountData)
+	require.NoError(t, err)
+
+	code, _, stderr := runCommand(t, env, string(payload), "create", "-f", "-")
+	require.Equal(t, exitOK, code, stderr)
+
+	tests := []struct {
+		name     string
+		stdin    string
+		args     []string
+		wantCode int
+	}{
+		{
+			name:     "Not found when fetching a missing account",
+			args:     []string{"fetch", accounts.NewAccountID().String()},
+			wantCode: exitNotFound,
+		},
+		{
+			name:     "Not found when deleting the latest version of a missing account",
+			args:     []string{"delete", accounts.NewAccountID().String()},
+			wantCode: exitNotFound,
+		},
+		{
+			name:     "Conflict when creating a duplicate account",
+			stdin:    string(payload),
+			args:     []string{"create", "-f", "-"},
+			wantCode: exitConflict,
+		},
+		{
+			name:     "Conflict when deleting a stale version",
+			args:     []string{"delete", "-version", "3", accountData.ID},
+			wantCode: exitConflict,
+		},
+		{
+			name:     "Failure when the account json is invalid",
+			stdin:    "{",
+			args:     []string{"create", "-f", "-"},
+			wantCode: exitFailure,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, stdout, stderr := runCommand(t, env, tt.stdin, tt.args...)
+			assert.Equal(t, tt.wantCode, code, stderr)
+			assert.Empty(t, stdout)
+		})
+	}
+}
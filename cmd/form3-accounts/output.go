@@ -0,0 +1,131 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v3"
+
+	"renatoaraujo/form3-account-api-client/accounts"
+)
+
+// The output formats of the command
+const (
+	outputJSON  = "json"
+	outputTable = "table"
+	outputYAML  = "yaml"
+)
+
+// tableHeader is the header of the table output, one row per account
+var tableHeader = []string{"ID", "VERSION", "COUNTRY", "BANK ID", "BIC", "ACCOUNT NUMBER", "IBAN", "STATUS"}
+
+// outputFormat is the flag of the output format, an unknown format fails the parsing of the flags so nothing is sent
+// to the api
+type outputFormat string
+
+func (f *outputFormat) String() string {
+	return string(*f)
+}
+
+func (f *outputFormat) Set(value string) error {
+	switch value {
+	case outputJSON, outputTable, outputYAML:
+		*f = outputFormat(value)
+		return nil
+	default:
+		return fmt.Errorf("invalid output %q, it must be one of %s, %s or %s", value, outputJSON, outputTable, outputYAML)
+	}
+}
+
+// write writes the result of the command, an account or a list of accounts, in the output format
+func (f outputFormat) write(w io.Writer, result interface{}) error {
+	switch f {
+	case outputTable:
+		return writeTable(w, result)
+	case outputYAML:
+		return writeYAML(w, result)
+	default:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(result)
+	}
+}
+
+// writeYAML writes the result as yaml, it goes through json so the keys are the json names of the api
+func writeYAML(w io.Writer, result interface{}) error {
+	encoded, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+
+	var decoded interface{}
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		return err
+	}
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(decoded); err != nil {
+		return err
+	}
+
+	return encoder.Close()
+}
+
+func writeTable(w io.Writer, result interface{}) error {
+	var rows []*accounts.AccountData
+	switch data := result.(type) {
+	case *accounts.AccountData:
+		rows = []*accounts.AccountData{data}
+	case []*accounts.AccountData:
+		rows = data
+	default:
+		return fmt.Errorf("unable to write %T as a table", result)
+	}
+
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, strings.Join(tableHeader, "\t"))
+	for _, accountData := range rows {
+		fmt.Fprintln(table, strings.Join(tableRow(accountData), "\t"))
+	}
+
+	return table.Flush()
+}
+
+// tableRow returns the cells of an account, a missing value is a dash so the columns stay aligned
+func tableRow(accountData *accounts.AccountData) []string {
+	attributes := accountData.Attributes
+	if attributes == nil {
+		attributes = &accounts.AccountAttributes{}
+	}
+
+	country, status := "", ""
+	if attributes.Country != nil {
+		country = string(*attributes.Country)
+	}
+	if attributes.Status != nil {
+		status = string(*attributes.Status)
+	}
+
+	row := []string{
+		accountData.ID,
+		strconv.Itoa(int(accountData.Version)),
+		country,
+		attributes.BankID,
+		attributes.Bic,
+		attributes.AccountNumber,
+		attributes.Iban,
+		status,
+	}
+	for i, cell := range row {
+		if cell == "" {
+			row[i] = "-"
+		}
+	}
+
+	return row
+}
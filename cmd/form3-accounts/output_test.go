@@ -0,0 +1,77 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"renatoaraujo/form3-account-api-client/accounts"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutputFormat(t *testing.T) {
+	country := accounts.CountryCode("GB")
+	accountData := &accounts.AccountData{
+		ID:      "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc",
+		Version: 1,
+		Type:    "accounts",
+		Attributes: &accounts.AccountAttributes{
+			Country:       &country,
+			BankID:        "400300",
+			AccountNumber: "41426819",
+		},
+	}
+
+	tests := []struct {
+		name       string
+		output     outputFormat
+		result     interface{}
+		want       string
+		wantErr    bool
+		wantErrMsg string
+	}{
+		{
+			name:   "Successfully writes an account as json",
+			output: outputJSON,
+			result: &accounts.AccountData{ID: accountData.ID},
+			want:   "{\n  \"id\": \"ad27e265-9605-4b4b-a0e5-3003ea9cc4dc\"\n}\n",
+		},
+		{
+			name:   "Successfully writes an account as yaml with the json names",
+			output: outputYAML,
+			result: accountData,
+			want: "attributes:\n  account_number: \"41426819\"\n  bank_id: \"400300\"\n  country: GB\n" +
+				"id: ad27e265-9605-4b4b-a0e5-3003ea9cc4dc\ntype: accounts\nversion: 1\n",
+		},
+		{
+			name:   "Successfully writes the accounts as a table",
+			output: outputTable,
+			result: []*accounts.AccountData{accountData, {ID: "f199fe08-90b4-4756-9c1f-3a2352ea4933"}},
+			want: "ID                                    VERSION  COUNTRY  BANK ID  BIC  ACCOUNT NUMBER  IBAN  STATUS\n" +
+				"ad27e265-9605-4b4b-a0e5-3003ea9cc4dc  1        GB       400300   -    41426819        -     -\n" +
+				"f199fe08-90b4-4756-9c1f-3a2352ea4933  0        -        -        -    -               -     -\n",
+		},
+		{
+			name:       "Failed to write an unknown result as a table",
+			output:     outputTable,
+			result:     "account",
+			wantErr:    true,
+			wantErrMsg: "unable to write string as a table",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := tt.output.write(&buf, tt.result)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.wantErrMsg)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, buf.String())
+			}
+		})
+	}
+}
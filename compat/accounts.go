@@ -0,0 +1,48 @@
+// Package compat exposes the method shapes of the client before the context-first signatures, so existing consumers
+// can upgrade the module and migrate the call sites gradually
+package compat
+
+import (
+	"context"
+
+	"renatoaraujo/form3-account-api-client/accounts"
+
+	"github.com/google/uuid"
+)
+
+type accountsClient interface {
+	CreateResource(ctx context.Context, accountData *accounts.AccountData, opts ...accounts.CallOption) (*accounts.AccountData, error)
+	FetchResource(ctx context.Context, accountID accounts.AccountID, opts ...accounts.CallOption) (*accounts.AccountData, error)
+	DeleteResource(ctx context.Context, accountID accounts.AccountID, version accounts.Version, opts ...accounts.CallOption) error
+}
+
+// AccountsClient wraps the account client with the old method shapes, every call uses a background context
+type AccountsClient struct {
+	client accountsClient
+}
+
+// NewAccountsClient creates the compatibility wrapper around an account client
+func NewAccountsClient(client accountsClient) AccountsClient {
+	return AccountsClient{client: client}
+}
+
+// CreateResource creates a new account resource.
+//
+// Deprecated: use accounts.Client.CreateResource with a context instead.
+func (c AccountsClient) CreateResource(accountData *accounts.AccountData) (*accounts.AccountData, error) {
+	return c.client.CreateResource(context.Background(), accountData)
+}
+
+// FetchResource fetches an account resource by an account id.
+//
+// Deprecated: use accounts.Client.FetchResource with a context and an accounts.AccountID instead.
+func (c AccountsClient) FetchResource(accountID uuid.UUID) (*accounts.AccountData, error) {
+	return c.client.FetchResource(context.Background(), accounts.AccountID(accountID))
+}
+
+// DeleteResource deletes an account resource by an account id and version.
+//
+// Deprecated: use accounts.Client.DeleteResource with a context and an accounts.AccountID instead.
+func (c AccountsClient) DeleteResource(accountID uuid.UUID, version int) error {
+	return c.client.DeleteResource(context.Background(), accounts.AccountID(accountID), accounts.Version(version))
+}
@@ -0,0 +1,83 @@
+package compat
+
+import (
+	"errors"
+	"testing"
+
+	"renatoaraujo/form3-account-api-client/accounts"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountsClient(t *testing.T) {
+	accountID := uuid.New()
+	accountData := &accounts.AccountData{ID: accountID.String()}
+
+	tests := []struct {
+		name        string
+		clientSetup func(*mockAccountsClient)
+		call        func(AccountsClient) (*accounts.AccountData, error)
+		want        *accounts.AccountData
+		wantErr     bool
+	}{
+		{
+			name: "Successfully creates an account with the old method shape",
+			clientSetup: func(client *mockAccountsClient) {
+				client.On("CreateResource", mock.Anything, accountData).Return(accountData, nil)
+			},
+			call: func(c AccountsClient) (*accounts.AccountData, error) {
+				return c.CreateResource(accountData)
+			},
+			want: accountData,
+		},
+		{
+			name: "Successfully fetches an account with the old method shape",
+			clientSetup: func(client *mockAccountsClient) {
+				client.On("FetchResource", mock.Anything, accounts.AccountID(accountID)).Return(accountData, nil)
+			},
+			call: func(c AccountsClient) (*accounts.AccountData, error) {
+				return c.FetchResource(accountID)
+			},
+			want: accountData,
+		},
+		{
+			name: "Successfully deletes an account with the old method shape",
+			clientSetup: func(client *mockAccountsClient) {
+				client.On("DeleteResource", mock.Anything, accounts.AccountID(accountID), accounts.Version(3)).Return(nil)
+			},
+			call: func(c AccountsClient) (*accounts.AccountData, error) {
+				return nil, c.DeleteResource(accountID, 3)
+			},
+		},
+		{
+			name: "Failed to fetch an account with the old method shape",
+			clientSetup: func(client *mockAccountsClient) {
+				client.On("FetchResource", mock.Anything, accounts.AccountID(accountID)).Return(nil, errors.New("not found"))
+			},
+			call: func(c AccountsClient) (*accounts.AccountData, error) {
+				return c.FetchResource(accountID)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientMock := &mockAccountsClient{}
+			tt.clientSetup(clientMock)
+
+			got, err := tt.call(NewAccountsClient(clientMock))
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.want, got)
+			mock.AssertExpectationsForObjects(t, clientMock)
+		})
+	}
+}
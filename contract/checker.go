@@ -0,0 +1,165 @@
+// Package contract checks the payloads exchanged with form3 against the api specification, so the drift between the
+// models of the library and the api is caught in the tests
+package contract
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync/atomic"
+)
+
+// Direction tells if a violation was found in a request or in a response
+type Direction string
+
+const (
+	// DirectionRequest is a violation of the request payload
+	DirectionRequest Direction = "request"
+	// DirectionResponse is a violation of the response payload
+	DirectionResponse Direction = "response"
+)
+
+// Violation describes the payload of an interaction not matching the specification
+type Violation struct {
+	Method    string
+	Path      string
+	Direction Direction
+	Problems  []string
+}
+
+// ViolationError is returned by the round tripper when the checker fails on violations
+type ViolationError struct {
+	Violation Violation
+}
+
+func (err *ViolationError) Error() string {
+	return fmt.Sprintf("contract violation in the %s of %s %s: %s", err.Violation.Direction, err.Violation.Method,
+		err.Violation.Path, strings.Join(err.Violation.Problems, "; "))
+}
+
+// Option configures the checker
+type Option func(*Checker)
+
+// WithViolationHook sets the hook called on every violation
+func WithViolationHook(hook func(Violation)) Option {
+	return func(c *Checker) {
+		c.onViolation = hook
+	}
+}
+
+// WithFailOnViolation makes the round tripper return a ViolationError instead of only reporting the violations, the
+// invalid requests are not sent
+func WithFailOnViolation() Option {
+	return func(c *Checker) {
+		c.failOnViolation = true
+	}
+}
+
+// Checker validates the requests and the responses against a specification, it can be toggled at runtime
+type Checker struct {
+	spec            *Spec
+	onViolation     func(Violation)
+	failOnViolation bool
+	enabled         int32
+}
+
+// NewChecker creates an enabled checker of the specification
+func NewChecker(spec *Spec, opts ...Option) *Checker {
+	checker := &Checker{
+		spec:        spec,
+		onViolation: func(Violation) {},
+		enabled:     1,
+	}
+
+	for _, opt := range opts {
+		opt(checker)
+	}
+
+	return checker
+}
+
+// SetEnabled toggles the checks, a disabled checker passes the interactions through
+func (c *Checker) SetEnabled(enabled bool) {
+	var value int32
+	if enabled {
+		value = 1
+	}
+	atomic.StoreInt32(&c.enabled, value)
+}
+
+// Wrap returns the round tripper checking the interactions sent with next, see httputils.WithRoundTripper
+func (c *Checker) Wrap(next http.RoundTripper) http.RoundTripper {
+	return roundTripperFunc(func(request *http.Request) (*http.Response, error) {
+		if atomic.LoadInt32(&c.enabled) == 0 {
+			return next.RoundTrip(request)
+		}
+
+		if request.Body != nil && request.Body != http.NoBody {
+			body, err := ioutil.ReadAll(request.Body)
+			_ = request.Body.Close()
+			if err != nil {
+				return nil, fmt.Errorf("%w; unable to read the request body", err)
+			}
+			request.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+			problems, err := c.spec.ValidateRequest(request.Method, request.URL.Path, body)
+			if err := c.check(request, DirectionRequest, problems, err); err != nil {
+				return nil, err
+			}
+		}
+
+		response, err := next.RoundTrip(request)
+		if err != nil {
+			return nil, err
+		}
+
+		body, err := ioutil.ReadAll(response.Body)
+		_ = response.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%w; unable to read the response body", err)
+		}
+		response.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+		if len(body) > 0 {
+			problems, err := c.spec.ValidateResponse(request.Method, request.URL.Path, response.StatusCode, body)
+			if err := c.check(request, DirectionResponse, problems, err); err != nil {
+				return nil, err
+			}
+		}
+
+		return response, nil
+	})
+}
+
+// check reports the problems found, returning an error when the checker fails on violations
+func (c *Checker) check(request *http.Request, direction Direction, problems []string, err error) error {
+	if err != nil {
+		problems = append(problems, err.Error())
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+
+	violation := Violation{
+		Method:    request.Method,
+		Path:      request.URL.Path,
+		Direction: direction,
+		Problems:  problems,
+	}
+	c.onViolation(violation)
+
+	if c.failOnViolation {
+		return &ViolationError{Violation: violation}
+	}
+
+	return nil
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
@@ -0,0 +1,132 @@
+package contract
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"renatoaraujo/form3-account-api-client/accounts"
+	"renatoaraujo/form3-account-api-client/accounts/accountstest"
+	"renatoaraujo/form3-account-api-client/form3fake"
+	"renatoaraujo/form3-account-api-client/httputils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newAccountsClient(t *testing.T, baseURI string, checker *Checker) *accounts.Client {
+	httpClient, err := httputils.NewClient(baseURI, 5*time.Second, httputils.WithRoundTripper(checker.Wrap))
+	require.NoError(t, err)
+
+	accountsClient, err := accounts.NewClient(httpClient)
+	require.NoError(t, err)
+
+	return &accountsClient
+}
+
+func TestCheckerWithValidInteractions(t *testing.T) {
+	server := form3fake.NewServer()
+	defer server.Close()
+
+	var violations []Violation
+	checker := NewChecker(AccountsSpec(), WithFailOnViolation(), WithViolationHook(func(violation Violation) {
+		violations = append(violations, violation)
+	}))
+	client := newAccountsClient(t, server.URL, checker)
+	ctx := context.Background()
+
+	accountData := accountstest.ValidGBAccount()
+	created, err := client.CreateResource(ctx, accountData)
+	require.NoError(t, err)
+
+	created.Attributes.Name = []string{"Sam Holder"}
+	_, err = client.UpdateResource(ctx, created)
+	require.NoError(t, err)
+
+	_, err = client.ListResources(ctx, accounts.ListOptions{})
+	require.NoError(t, err)
+
+	require.NoError(t, client.DeleteResourceLatest(ctx, accounts.MustParseAccountID(accountData.ID)))
+	assert.Empty(t, violations)
+}
+
+func TestCheckerWithInvalidRequest(t *testing.T) {
+	server := form3fake.NewServer()
+	defer server.Close()
+
+	var violations []Violation
+	hook := WithViolationHook(func(violation Violation) {
+		violations = append(violations, violation)
+	})
+	accountData := accountstest.ValidGBAccount(accountstest.WithAttributes(func(attributes *accounts.AccountAttributes) {
+		attributes.Bic = "nwbkgb22"
+		attributes.Name = nil
+	}))
+
+	client := newAccountsClient(t, server.URL, NewChecker(AccountsSpec(), hook))
+	_, err := client.CreateResource(context.Background(), accountData)
+	require.NoError(t, err, "the violations are only reported by default")
+	require.Len(t, violations, 2, "the fake echoes the invalid bic back in the response")
+	assert.Equal(t, DirectionRequest, violations[0].Direction)
+	assert.Equal(t, []string{
+		"data.attributes.bic must match ^([A-Z]{6}[A-Z0-9]{2}|[A-Z]{6}[A-Z0-9]{5})$",
+		"data.attributes.name is required",
+	}, violations[0].Problems)
+	assert.Equal(t, DirectionResponse, violations[1].Direction)
+
+	created := server.Len()
+	client = newAccountsClient(t, server.URL, NewChecker(AccountsSpec(), WithFailOnViolation()))
+	_, err = client.CreateResource(context.Background(), accountstest.ValidGBAccount(accountstest.WithAttributes(func(attributes *accounts.AccountAttributes) {
+		attributes.Name = nil
+	})))
+	var violationErr *ViolationError
+	require.True(t, errors.As(err, &violationErr))
+	assert.Contains(t, err.Error(), "contract violation in the request of POST /v1/organisation/accounts: data.attributes.name is required")
+	assert.Equal(t, created, server.Len(), "the invalid request must not be sent")
+}
+
+func TestCheckerWithResponseDrift(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"data": {"id": "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc", "type": "accounts", "version": "1", "attributes": {"name": "john doe"}}}`))
+	}))
+	defer server.Close()
+
+	checker := NewChecker(AccountsSpec(), WithFailOnViolation())
+	client := newAccountsClient(t, server.URL, checker)
+
+	_, err := client.FetchResource(context.Background(), accounts.MustParseAccountID("ad27e265-9605-4b4b-a0e5-3003ea9cc4dc"))
+	var violationErr *ViolationError
+	require.True(t, errors.As(err, &violationErr))
+	assert.Equal(t, DirectionResponse, violationErr.Violation.Direction)
+	assert.Equal(t, []string{
+		"data.attributes.name must be of type array",
+		"data.version must be of type integer",
+	}, violationErr.Violation.Problems)
+
+	checker.SetEnabled(false)
+	_, err = client.FetchResource(context.Background(), accounts.MustParseAccountID("ad27e265-9605-4b4b-a0e5-3003ea9cc4dc"))
+	assert.False(t, errors.As(err, &violationErr), "a disabled checker must not check the interactions")
+}
+
+func TestLoadSpec(t *testing.T) {
+	_, err := LoadSpec([]byte(`{`))
+	assert.Error(t, err)
+
+	spec, err := LoadSpec([]byte(`{"basePath": "/v1", "paths": {"/things/{id}": {"get": {"responses": {"200": {"schema": {"$ref": "#/definitions/Missing"}}}}}}}`))
+	require.NoError(t, err)
+
+	problems, err := spec.ValidateResponse(http.MethodGet, "/v1/things/1", http.StatusOK, []byte(`{}`))
+	require.NoError(t, err)
+	assert.Equal(t, []string{" unknown reference #/definitions/Missing"}, problems)
+
+	problems, err = spec.ValidateResponse(http.MethodGet, "/v1/other", http.StatusOK, []byte(`{}`))
+	require.NoError(t, err)
+	assert.Empty(t, problems)
+
+	_, err = spec.ValidateResponse(http.MethodGet, "/v1/things/1", http.StatusOK, []byte(`{`))
+	assert.Error(t, err)
+}
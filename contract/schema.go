@@ -0,0 +1,200 @@
+package contract
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Schema is the subset of the swagger schema object the contract checks support
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *bool              `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AllOf                []*Schema          `json:"allOf,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+}
+
+// validator validates the decoded json values against the schemas, resolving the references with the definitions
+type validator struct {
+	definitions map[string]*Schema
+	patterns    map[string]*regexp.Regexp
+	problems    []string
+}
+
+func (v *validator) validate(schema *Schema, value interface{}, path string) {
+	if schema == nil {
+		return
+	}
+
+	if schema.Ref != "" {
+		definition, ok := v.definitions[strings.TrimPrefix(schema.Ref, "#/definitions/")]
+		if !ok {
+			v.report(path, "unknown reference %s", schema.Ref)
+			return
+		}
+		v.validate(definition, value, path)
+		return
+	}
+
+	for _, sub := range schema.AllOf {
+		v.validate(sub, value, path)
+	}
+
+	if value == nil {
+		return
+	}
+
+	if schema.Type != "" && !hasType(value, schema.Type) {
+		v.report(path, "must be of type %s", schema.Type)
+		return
+	}
+
+	if len(schema.Enum) > 0 && !inEnum(value, schema.Enum) {
+		v.report(path, "must be one of %v", schema.Enum)
+	}
+
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		v.validateObject(schema, typed, path)
+	case []interface{}:
+		if schema.MinItems != nil && len(typed) < *schema.MinItems {
+			v.report(path, "must have at least %d items", *schema.MinItems)
+		}
+		if schema.MaxItems != nil && len(typed) > *schema.MaxItems {
+			v.report(path, "must have at most %d items", *schema.MaxItems)
+		}
+		for i, item := range typed {
+			v.validate(schema.Items, item, fmt.Sprintf("%s[%d]", path, i))
+		}
+	case string:
+		v.validateString(schema, typed, path)
+	case float64:
+		if schema.Minimum != nil && typed < *schema.Minimum {
+			v.report(path, "must not be less than %v", *schema.Minimum)
+		}
+	}
+}
+
+func (v *validator) validateObject(schema *Schema, object map[string]interface{}, path string) {
+	for _, name := range schema.Required {
+		if _, ok := object[name]; !ok {
+			v.report(join(path, name), "is required")
+		}
+	}
+
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		property, ok := schema.Properties[name]
+		if !ok {
+			if schema.AdditionalProperties != nil && !*schema.AdditionalProperties {
+				v.report(join(path, name), "is not defined in the specification")
+			}
+			continue
+		}
+		v.validate(property, object[name], join(path, name))
+	}
+}
+
+func (v *validator) validateString(schema *Schema, value string, path string) {
+	if schema.MinLength != nil && len(value) < *schema.MinLength {
+		v.report(path, "must have at least %d characters", *schema.MinLength)
+	}
+
+	if schema.MaxLength != nil && len(value) > *schema.MaxLength {
+		v.report(path, "must have at most %d characters", *schema.MaxLength)
+	}
+
+	if schema.Pattern != "" {
+		pattern, ok := v.patterns[schema.Pattern]
+		if !ok {
+			var err error
+			if pattern, err = regexp.Compile(schema.Pattern); err != nil {
+				v.report(path, "has an invalid pattern %s in the specification", schema.Pattern)
+				return
+			}
+			v.patterns[schema.Pattern] = pattern
+		}
+
+		if !pattern.MatchString(value) {
+			v.report(path, "must match %s", schema.Pattern)
+		}
+	}
+
+	switch schema.Format {
+	case "uuid":
+		if _, err := uuid.Parse(value); err != nil {
+			v.report(path, "must be an uuid")
+		}
+	case "date-time":
+		if _, err := time.Parse(time.RFC3339Nano, value); err != nil {
+			v.report(path, "must be a RFC 3339 date time")
+		}
+	}
+}
+
+func (v *validator) report(path, format string, args ...interface{}) {
+	v.problems = append(v.problems, fmt.Sprintf("%s %s", path, fmt.Sprintf(format, args...)))
+}
+
+func hasType(value interface{}, schemaType string) bool {
+	switch schemaType {
+	case "object":
+		_, ok := value.(map[string]interface{})
+		return ok
+	case "array":
+		_, ok := value.([]interface{})
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	case "integer":
+		number, ok := value.(float64)
+		return ok && number == float64(int64(number))
+	}
+
+	return true
+}
+
+func inEnum(value interface{}, enum []interface{}) bool {
+	for _, allowed := range enum {
+		if allowed == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func join(path, name string) string {
+	if path == "" {
+		return name
+	}
+
+	return path + "." + name
+}
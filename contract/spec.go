@@ -0,0 +1,121 @@
+package contract
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+//go:embed schemas/accounts.json
+var accountsSpec []byte
+
+// Spec is the subset of a swagger 2.0 specification the contract checks use, the paths and the definitions
+type Spec struct {
+	BasePath    string                          `json:"basePath"`
+	Paths       map[string]map[string]Operation `json:"paths"`
+	Definitions map[string]*Schema              `json:"definitions"`
+}
+
+// Operation is an operation of a path, with the schemas of the request body and of the responses by status code
+type Operation struct {
+	Parameters []Parameter          `json:"parameters"`
+	Responses  map[string]*Response `json:"responses"`
+}
+
+// Parameter is a parameter of an operation, only the body parameters are checked
+type Parameter struct {
+	In     string  `json:"in"`
+	Name   string  `json:"name"`
+	Schema *Schema `json:"schema"`
+}
+
+// Response is a response of an operation
+type Response struct {
+	Schema *Schema `json:"schema"`
+}
+
+// LoadSpec decodes a swagger 2.0 specification, such as the one published by form3
+func LoadSpec(raw []byte) (*Spec, error) {
+	spec := &Spec{}
+	if err := json.Unmarshal(raw, spec); err != nil {
+		return nil, fmt.Errorf("%w; unable to decode the specification", err)
+	}
+
+	return spec, nil
+}
+
+// AccountsSpec returns the specification of the organisation accounts embedded in the library
+func AccountsSpec() *Spec {
+	spec, err := LoadSpec(accountsSpec)
+	if err != nil {
+		panic(err)
+	}
+
+	return spec
+}
+
+// operation finds the operation of the method and request path, the path templates such as {id} match any segment
+func (spec *Spec) operation(method, requestPath string) (Operation, bool) {
+	for template, operations := range spec.Paths {
+		if !pathPattern(spec.BasePath + template).MatchString(requestPath) {
+			continue
+		}
+
+		operation, ok := operations[strings.ToLower(method)]
+		return operation, ok
+	}
+
+	return Operation{}, false
+}
+
+// ValidateRequest validates the body of a request of the method and path, the problems are returned as a slice
+func (spec *Spec) ValidateRequest(method, requestPath string, body []byte) ([]string, error) {
+	operation, ok := spec.operation(method, requestPath)
+	if !ok {
+		return nil, nil
+	}
+
+	for _, parameter := range operation.Parameters {
+		if parameter.In == "body" {
+			return spec.validate(parameter.Schema, body)
+		}
+	}
+
+	return nil, nil
+}
+
+// ValidateResponse validates the body of the response of the method and path with the status code, the responses
+// without a schema in the specification, such as the errors, are not validated
+func (spec *Spec) ValidateResponse(method, requestPath string, statusCode int, body []byte) ([]string, error) {
+	operation, ok := spec.operation(method, requestPath)
+	if !ok {
+		return nil, nil
+	}
+
+	response, ok := operation.Responses[fmt.Sprint(statusCode)]
+	if !ok || response == nil || response.Schema == nil {
+		return nil, nil
+	}
+
+	return spec.validate(response.Schema, body)
+}
+
+func (spec *Spec) validate(schema *Schema, body []byte) ([]string, error) {
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return nil, fmt.Errorf("%w; unable to decode the body", err)
+	}
+
+	v := &validator{definitions: spec.Definitions, patterns: map[string]*regexp.Regexp{}}
+	v.validate(schema, value, "")
+
+	return v.problems, nil
+}
+
+var templateSegment = regexp.MustCompile(`\\\{[^/]+\\\}`)
+
+func pathPattern(template string) *regexp.Regexp {
+	return regexp.MustCompile("^" + templateSegment.ReplaceAllString(regexp.QuoteMeta(template), "[^/]+") + "$")
+}
@@ -0,0 +1,120 @@
+package form3
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/url"
+	"time"
+
+	"renatoaraujo/form3-account-api-client/accounts"
+	"renatoaraujo/form3-account-api-client/httputils"
+)
+
+const (
+	productionBaseURI = "https://api.form3.tech"
+	stagingBaseURI    = "https://api.staging-form3.tech"
+	localHost         = "localhost:8080"
+)
+
+// Environment is a preconfigured form3 environment, the base uri, the TLS expectations and the timeout are set to
+// sensible values so the services don't have to repeat them, and get wrong, in every deployment
+type Environment struct {
+	// Name identifies the environment, such as in the logs
+	Name string
+	// BaseURI is the base uri of the form3 api of the environment
+	BaseURI string
+	// Timeout is the overall timeout of the requests
+	Timeout time.Duration
+	// RequireTLS makes the clients refuse a base uri that is not https and a TLS version older than 1.2
+	RequireTLS bool
+}
+
+// Production is the form3 production environment
+func Production() Environment {
+	return Environment{
+		Name:       "production",
+		BaseURI:    productionBaseURI,
+		Timeout:    10 * time.Second,
+		RequireTLS: true,
+	}
+}
+
+// Staging is the form3 staging environment
+func Staging() Environment {
+	return Environment{
+		Name:       "staging",
+		BaseURI:    stagingBaseURI,
+		Timeout:    15 * time.Second,
+		RequireTLS: true,
+	}
+}
+
+// Local is the form3 api running locally, such as the one of the docker compose file, on the host and port given,
+// an empty host means localhost:8080
+func Local(host string) Environment {
+	if host == "" {
+		host = localHost
+	}
+
+	return Environment{
+		Name:    "local",
+		BaseURI: "http://" + host,
+		Timeout: 5 * time.Second,
+	}
+}
+
+// httpOptions returns the options of the environment followed by the ones given, it fails when the environment
+// requires TLS and the base uri is not https
+func (env Environment) httpOptions(opts []httputils.Option) ([]httputils.Option, error) {
+	if !env.RequireTLS {
+		return opts, nil
+	}
+
+	parsedBaseURI, err := url.ParseRequestURI(env.BaseURI)
+	if err != nil {
+		return nil, fmt.Errorf("%w; invalid base uri", err)
+	}
+
+	if parsedBaseURI.Scheme != "https" {
+		return nil, fmt.Errorf("invalid base uri %q, the %s environment requires https", env.BaseURI, env.Name)
+	}
+
+	return append([]httputils.Option{httputils.WithMinTLSVersion(tls.VersionTLS12)}, opts...), nil
+}
+
+// HTTPClient creates the http client of the environment, the options given are applied after the ones of the
+// environment
+func (env Environment) HTTPClient(opts ...httputils.Option) (*httputils.Client, error) {
+	opts, err := env.httpOptions(opts)
+	if err != nil {
+		return nil, fmt.Errorf("%w; unable to create the %s http client", err, env.Name)
+	}
+
+	return httputils.NewClient(env.BaseURI, env.Timeout, opts...)
+}
+
+// Accounts creates the account client of the environment
+func (env Environment) Accounts(opts ...accounts.Option) (*accounts.Client, error) {
+	httpClient, err := env.HTTPClient()
+	if err != nil {
+		return nil, err
+	}
+
+	accountClient, err := accounts.NewClient(httpClient, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &accountClient, nil
+}
+
+// Lean creates the lean client of the environment, a misconfigured environment is reported on the first use as
+// any other configuration error, see NewLean
+func (env Environment) Lean(opts ...LeanOption) *Lean {
+	envOpts, err := env.httpOptions(nil)
+	if err != nil {
+		envOpts = []httputils.Option{func(*httputils.Client) error { return err }}
+	}
+
+	return NewLean(env.BaseURI, env.Timeout, append([]LeanOption{WithHTTPOptions(envOpts...)}, opts...)...)
+}
@@ -0,0 +1,80 @@
+package form3
+
+import (
+	"testing"
+	"time"
+
+	"renatoaraujo/form3-account-api-client/accounts"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnvironments(t *testing.T) {
+	tests := []struct {
+		name string
+		env  Environment
+		want Environment
+	}{
+		{
+			name: "Successfully presets the production environment",
+			env:  Production(),
+			want: Environment{Name: "production", BaseURI: "https://api.form3.tech", Timeout: 10 * time.Second, RequireTLS: true},
+		},
+		{
+			name: "Successfully presets the staging environment",
+			env:  Staging(),
+			want: Environment{Name: "staging", BaseURI: "https://api.staging-form3.tech", Timeout: 15 * time.Second, RequireTLS: true},
+		},
+		{
+			name: "Successfully presets the local environment with the default host",
+			env:  Local(""),
+			want: Environment{Name: "local", BaseURI: "http://localhost:8080", Timeout: 5 * time.Second},
+		},
+		{
+			name: "Successfully presets the local environment with a host",
+			env:  Local("accountapi:8080"),
+			want: Environment{Name: "local", BaseURI: "http://accountapi:8080", Timeout: 5 * time.Second},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.env)
+
+			accountClient, err := tt.env.Accounts(accounts.WithMaxPayloadSize(1024))
+			require.NoError(t, err)
+			assert.NotNil(t, accountClient)
+
+			accountClient, err = tt.env.Lean().Accounts()
+			require.NoError(t, err)
+			assert.NotNil(t, accountClient)
+		})
+	}
+}
+
+func TestEnvironmentRequiresTLS(t *testing.T) {
+	env := Production()
+	env.BaseURI = "http://api.form3.tech"
+
+	_, err := env.HTTPClient()
+	require.Error(t, err)
+	assert.EqualError(t, err, `invalid base uri "http://api.form3.tech", the production environment requires https; unable to create the production http client`)
+
+	_, err = env.Accounts()
+	require.Error(t, err)
+
+	_, err = env.Lean().Accounts()
+	require.Error(t, err)
+	assert.EqualError(t, err, `invalid base uri "http://api.form3.tech", the production environment requires https; invalid option`)
+}
+
+func TestEnvironmentTLSOptions(t *testing.T) {
+	opts, err := Production().httpOptions(nil)
+	require.NoError(t, err)
+	assert.Len(t, opts, 1, "the min tls version must be set in the environments requiring tls")
+
+	opts, err = Local("").httpOptions(nil)
+	require.NoError(t, err)
+	assert.Empty(t, opts)
+}
@@ -0,0 +1,93 @@
+// Package form3 wires the http client and the resource clients of the organisation api together
+package form3
+
+import (
+	"sync"
+	"time"
+
+	"renatoaraujo/form3-account-api-client/accounts"
+	"renatoaraujo/form3-account-api-client/httputils"
+)
+
+// Lean is the client profile of the serverless functions, the clients are built on the first call to Accounts instead
+// of the cold start, it is meant to be created at package level so the warm invocations share its connections
+//
+// NewLean only records the configuration and starts no background workers, the first call to Accounts builds the
+// clients without any network call, the first request then pays the dns lookup, the TLS handshake and the auth of the
+// credentials, the warm invocations reuse the clients and the kept alive connections, see the README
+type Lean struct {
+	baseURI        string
+	timeout        time.Duration
+	httpOptions    []httputils.Option
+	accountOptions []accounts.Option
+
+	once     sync.Once
+	accounts *accounts.Client
+	err      error
+}
+
+// LeanOption configures the lean client created by NewLean
+type LeanOption func(*Lean)
+
+// WithHTTPOptions sets the options applied to the http client on the first use
+func WithHTTPOptions(opts ...httputils.Option) LeanOption {
+	return func(l *Lean) {
+		l.httpOptions = append(l.httpOptions, opts...)
+	}
+}
+
+// WithAccountOptions sets the options applied to the account client on the first use
+func WithAccountOptions(opts ...accounts.Option) LeanOption {
+	return func(l *Lean) {
+		l.accountOptions = append(l.accountOptions, opts...)
+	}
+}
+
+// NewLean creates a lean client with the base uri and the timeout, the configuration errors are returned on the first use
+func NewLean(baseURI string, timeout time.Duration, opts ...LeanOption) *Lean {
+	lean := &Lean{
+		baseURI: baseURI,
+		timeout: timeout,
+	}
+
+	for _, opt := range opts {
+		opt(lean)
+	}
+
+	return lean
+}
+
+// Accounts returns the account client, initialising the underlying clients on the first call
+func (l *Lean) Accounts() (*accounts.Client, error) {
+	l.once.Do(func() {
+		httpClient, err := httputils.NewClient(l.baseURI, l.timeout, l.httpOptions...)
+		if err != nil {
+			l.err = err
+			return
+		}
+
+		accountClient, err := accounts.NewClient(httpClient, l.accountOptions...)
+		if err != nil {
+			l.err = err
+			return
+		}
+
+		l.accounts = &accountClient
+	})
+
+	return l.accounts, l.err
+}
+
+// Close closes the account client and its http client when they were built, a lean client closed before its first
+// use never builds them and Accounts returns accounts.ErrClientClosed
+func (l *Lean) Close() error {
+	l.once.Do(func() {
+		l.err = accounts.ErrClientClosed
+	})
+
+	if l.accounts == nil {
+		return nil
+	}
+
+	return l.accounts.Close()
+}
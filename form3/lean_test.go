@@ -0,0 +1,82 @@
+package form3
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"renatoaraujo/form3-account-api-client/accounts"
+	"renatoaraujo/form3-account-api-client/httputils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLean(t *testing.T) {
+	tests := []struct {
+		name    string
+		baseURI string
+		opts    []LeanOption
+		wantErr bool
+	}{
+		{
+			name:    "Successfully creates the account client on the first use",
+			baseURI: "https://api.form3.tech",
+			opts: []LeanOption{
+				WithHTTPOptions(httputils.WithDisableKeepAlives(false)),
+				WithAccountOptions(accounts.WithMaxPayloadSize(1024)),
+			},
+			wantErr: false,
+		},
+		{
+			name:    "Failed to create the account client with an invalid base uri",
+			baseURI: "not-valid-url",
+			wantErr: true,
+		},
+		{
+			name:    "Failed to create the account client with an invalid option",
+			baseURI: "https://api.form3.tech",
+			opts:    []LeanOption{WithAccountOptions(accounts.WithMaxPayloadSize(0))},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lean := NewLean(tt.baseURI, 10*time.Second, tt.opts...)
+			assert.Nil(t, lean.accounts)
+
+			first, err := lean.Accounts()
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Nil(t, first)
+				return
+			}
+			require.NoError(t, err)
+
+			second, err := lean.Accounts()
+			require.NoError(t, err)
+			assert.Same(t, first, second)
+		})
+	}
+}
+
+func TestLeanClose(t *testing.T) {
+	lean := NewLean("https://api.form3.tech", 10*time.Second)
+	accountClient, err := lean.Accounts()
+	require.NoError(t, err)
+
+	require.NoError(t, lean.Close())
+
+	_, err = accountClient.FetchResource(context.Background(), accounts.NewAccountID())
+	assert.ErrorIs(t, err, accounts.ErrClientClosed)
+}
+
+func TestLeanCloseBeforeTheFirstUse(t *testing.T) {
+	lean := NewLean("https://api.form3.tech", 10*time.Second)
+	require.NoError(t, lean.Close())
+
+	accountClient, err := lean.Accounts()
+	assert.Nil(t, accountClient)
+	assert.ErrorIs(t, err, accounts.ErrClientClosed)
+}
@@ -0,0 +1,417 @@
+// Package form3fake is an in-memory fake of the form3 api served by a httptest.Server, so the consumers can run
+// integration style tests against the real clients without the docker environment
+package form3fake
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	accountsPath = "/v1/organisation/accounts"
+	healthPath   = "/v1/health"
+)
+
+// listFilters are the filters of the account list, the organisation id is a field of the data and the others are
+// attributes
+var listFilters = map[string]bool{
+	"bank_id":         true,
+	"account_number":  true,
+	"iban":            true,
+	"customer_id":     true,
+	"country":         true,
+	"organisation_id": true,
+}
+
+// sortFields are the fields the account list can be sorted by, prefixed with a dash for the descending order
+var sortFields = map[string]bool{
+	"created_on":  true,
+	"modified_on": true,
+	"id":          true,
+}
+
+// record is a stored account, the data is kept as decoded json so the fields the client sends are returned untouched
+type record struct {
+	data      map[string]interface{}
+	version   int
+	createdOn time.Time
+}
+
+// Server is the fake form3 api, its URL is the base uri of the http client
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	accounts map[string]*record
+	now      func() time.Time
+}
+
+// NewServer starts a fake form3 api with an empty account store, it must be closed by the caller
+func NewServer() *Server {
+	server := &Server{
+		accounts: map[string]*record{},
+		now:      time.Now,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(accountsPath, server.handleAccounts)
+	mux.HandleFunc(accountsPath+"/", server.handleAccount)
+	mux.HandleFunc(healthPath, handleHealth)
+	server.Server = httptest.NewServer(mux)
+
+	return server
+}
+
+// Reset removes all the stored accounts
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.accounts = map[string]*record{}
+}
+
+// Len returns the number of stored accounts
+func (s *Server) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.accounts)
+}
+
+func handleHealth(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	writeData(w, http.StatusOK, map[string]string{"status": "up"})
+}
+
+func (s *Server) handleAccounts(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		s.create(w, r)
+	case http.MethodGet:
+		s.list(w, r)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, fmt.Sprintf("method %s is not allowed", r.Method))
+	}
+}
+
+func (s *Server) handleAccount(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimPrefix(r.URL.Path, accountsPath+"/")
+	if _, err := uuid.Parse(id); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("id is not a valid uuid: %s", id))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		s.fetch(w, id)
+	case http.MethodPatch:
+		s.update(w, r, id)
+	case http.MethodDelete:
+		s.delete(w, r, id)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, fmt.Sprintf("method %s is not allowed", r.Method))
+	}
+}
+
+func (s *Server) create(w http.ResponseWriter, r *http.Request) {
+	data, ok := decodeData(w, r)
+	if !ok {
+		return
+	}
+
+	id, _ := data["id"].(string)
+	if _, err := uuid.Parse(id); err != nil {
+		writeError(w, http.StatusBadRequest, "validation failure list:\nid in body must be of type uuid")
+		return
+	}
+
+	if _, ok := data["attributes"].(map[string]interface{}); !ok {
+		writeError(w, http.StatusBadRequest, "validation failure list:\nattributes in body is required")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.accounts[id]; exists {
+		writeError(w, http.StatusConflict, "Account cannot be created as it violates a duplicate constraint")
+		return
+	}
+
+	stored := &record{data: data, createdOn: s.now().UTC()}
+	stored.data["created_on"] = stored.createdOn.Format(time.RFC3339Nano)
+	stored.data["modified_on"] = stored.data["created_on"]
+	s.accounts[id] = stored
+
+	writeData(w, http.StatusCreated, stored.payload(id))
+}
+
+func (s *Server) fetch(w http.ResponseWriter, id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, ok := s.accounts[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("record %s does not exist", id))
+		return
+	}
+
+	writeData(w, http.StatusOK, stored.payload(id))
+}
+
+func (s *Server) update(w http.ResponseWriter, r *http.Request, id string) {
+	data, ok := decodeData(w, r)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, ok := s.accounts[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("record %s does not exist", id))
+		return
+	}
+
+	if version, _ := data["version"].(float64); int(version) != stored.version {
+		writeError(w, http.StatusConflict, "invalid version")
+		return
+	}
+
+	if attributes, ok := data["attributes"].(map[string]interface{}); ok {
+		storedAttributes := stored.data["attributes"].(map[string]interface{})
+		for name, value := range attributes {
+			storedAttributes[name] = value
+		}
+	}
+
+	stored.version++
+	stored.data["modified_on"] = s.now().UTC().Format(time.RFC3339Nano)
+
+	writeData(w, http.StatusOK, stored.payload(id))
+}
+
+func (s *Server) delete(w http.ResponseWriter, r *http.Request, id string) {
+	version, err := strconv.Atoi(r.URL.Query().Get("version"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid version number")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, ok := s.accounts[id]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	if version != stored.version {
+		writeError(w, http.StatusConflict, "invalid version")
+		return
+	}
+
+	delete(s.accounts, id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) list(w http.ResponseWriter, r *http.Request) {
+	filters := map[string]string{}
+	pageNumber, pageSize := 0, 100
+	lastPage := false
+	var sorts []string
+
+	for name, values := range r.URL.Query() {
+		switch {
+		case strings.HasPrefix(name, "filter[") && strings.HasSuffix(name, "]"):
+			field := strings.TrimSuffix(strings.TrimPrefix(name, "filter["), "]")
+			if !listFilters[field] {
+				writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid filter %s", field))
+				return
+			}
+			filters[field] = values[0]
+		case name == "page[number]" && (values[0] == "first" || values[0] == "last"):
+			lastPage = values[0] == "last"
+		case name == "page[number]":
+			number, err := strconv.Atoi(values[0])
+			if err != nil || number < 0 {
+				writeError(w, http.StatusBadRequest, "invalid page number")
+				return
+			}
+			pageNumber = number
+		case name == "page[size]":
+			size, err := strconv.Atoi(values[0])
+			if err != nil || size < 1 || size > 100 {
+				writeError(w, http.StatusBadRequest, "invalid page size")
+				return
+			}
+			pageSize = size
+		case name == "sort":
+			sorts = strings.Split(values[0], ",")
+			for _, field := range sorts {
+				if !sortFields[strings.TrimPrefix(field, "-")] {
+					writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid sort %s", field))
+					return
+				}
+			}
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ids := make([]string, 0, len(s.accounts))
+	for id, stored := range s.accounts {
+		if stored.matches(filters) {
+			ids = append(ids, id)
+		}
+	}
+	sorts = append(sorts, "created_on", "id")
+	sort.Slice(ids, func(i, j int) bool {
+		for _, field := range sorts {
+			if order := s.compare(ids[i], ids[j], strings.TrimPrefix(field, "-")); order != 0 {
+				return order < 0 != strings.HasPrefix(field, "-")
+			}
+		}
+		return false
+	})
+
+	pages := (len(ids) + pageSize - 1) / pageSize
+	if lastPage && pages > 0 {
+		pageNumber = pages - 1
+	}
+
+	data := []interface{}{}
+	for i := pageNumber * pageSize; i < len(ids) && i < (pageNumber+1)*pageSize; i++ {
+		data = append(data, s.accounts[ids[i]].payload(ids[i])["data"])
+	}
+
+	writeData(w, http.StatusOK, map[string]interface{}{
+		"data":  data,
+		"links": pageLinks(r.URL, pageNumber, pages),
+	})
+}
+
+// pageLinks returns the links to the first, last, next and prev pages of a list with the pages given, the next and
+// prev links are left out on the last and the first pages
+func pageLinks(requestURL *url.URL, pageNumber, pages int) map[string]string {
+	link := func(number string) string {
+		query := requestURL.Query()
+		query.Set("page[number]", number)
+		return accountsPath + "?" + query.Encode()
+	}
+
+	links := map[string]string{
+		"self":  requestURL.RequestURI(),
+		"first": link("first"),
+		"last":  link("last"),
+	}
+	if pageNumber+1 < pages {
+		links["next"] = link(strconv.Itoa(pageNumber + 1))
+	}
+	if pageNumber > 0 {
+		links["prev"] = link(strconv.Itoa(pageNumber - 1))
+	}
+
+	return links
+}
+
+// compare compares the field of the accounts, it returns a negative number when the first sorts before the second
+func (s *Server) compare(firstID, secondID, field string) int {
+	first, second := s.accounts[firstID], s.accounts[secondID]
+	switch field {
+	case "created_on":
+		return compareTimes(first.createdOn, second.createdOn)
+	case "modified_on":
+		return compareTimes(first.modifiedOn(), second.modifiedOn())
+	default:
+		return strings.Compare(firstID, secondID)
+	}
+}
+
+func compareTimes(first, second time.Time) int {
+	switch {
+	case first.Before(second):
+		return -1
+	case first.After(second):
+		return 1
+	default:
+		return 0
+	}
+}
+
+// modifiedOn returns when the account was last modified
+func (stored *record) modifiedOn() time.Time {
+	modifiedOn, _ := time.Parse(time.RFC3339Nano, fmt.Sprint(stored.data["modified_on"]))
+	return modifiedOn
+}
+
+// matches tells if the account matches all the filters
+func (stored *record) matches(filters map[string]string) bool {
+	attributes, _ := stored.data["attributes"].(map[string]interface{})
+	for field, value := range filters {
+		actual := attributes[field]
+		if field == "organisation_id" {
+			actual = stored.data[field]
+		}
+
+		if fmt.Sprint(actual) != value {
+			return false
+		}
+	}
+
+	return true
+}
+
+// payload returns the response payload of the account
+func (stored *record) payload(id string) map[string]interface{} {
+	data := map[string]interface{}{}
+	for name, value := range stored.data {
+		data[name] = value
+	}
+	data["version"] = stored.version
+
+	return map[string]interface{}{
+		"data":  data,
+		"links": map[string]string{"self": accountsPath + "/" + id},
+	}
+}
+
+// decodeData decodes the data of the request payload, writing a bad request when it is not valid
+func decodeData(w http.ResponseWriter, r *http.Request) (map[string]interface{}, bool) {
+	var payload struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil || payload.Data == nil {
+		writeError(w, http.StatusBadRequest, "validation failure list:\ndata in body is required")
+		return nil, false
+	}
+
+	return payload.Data, true
+}
+
+func writeData(w http.ResponseWriter, statusCode int, payload interface{}) {
+	w.Header().Set("Content-Type", "application/vnd.api+json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(payload)
+}
+
+func writeError(w http.ResponseWriter, statusCode int, message string) {
+	writeData(w, statusCode, map[string]string{"error_message": message})
+}
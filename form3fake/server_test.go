@@ -0,0 +1,203 @@
+package form3fake
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"renatoaraujo/form3-account-api-client/accounts"
+	"renatoaraujo/form3-account-api-client/httputils"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newAccountsClient(t *testing.T, server *Server) *accounts.Client {
+	httpClient, err := httputils.NewClient(server.URL, 5*time.Second)
+	require.NoError(t, err)
+
+	accountsClient, err := accounts.NewClient(httpClient)
+	require.NoError(t, err)
+
+	return &accountsClient
+}
+
+func newAccountData(bankID string) *accounts.AccountData {
+	country := accounts.CountryUnitedKingdom
+
+	return &accounts.AccountData{
+		ID:             uuid.New().String(),
+		OrganisationID: "eb0bd6f5-c3f5-44b2-b677-acd23cdde73c",
+		Type:           "accounts",
+		Attributes: &accounts.AccountAttributes{
+			BankID:     bankID,
+			BankIDCode: "GBDSC",
+			Bic:        "NWBKGB22",
+			Country:    &country,
+			Name:       []string{"john doe"},
+		},
+	}
+}
+
+func TestServerAccountLifecycle(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	client := newAccountsClient(t, server)
+	ctx := context.Background()
+	accountData := newAccountData("400300")
+	accountID := accounts.MustParseAccountID(accountData.ID)
+
+	created, err := client.CreateResource(ctx, accountData)
+	require.NoError(t, err)
+	assert.Equal(t, accountData.ID, created.ID)
+	assert.Equal(t, accounts.Version(0), created.Version)
+	assert.NotNil(t, created.CreatedOn)
+
+	_, err = client.CreateResource(ctx, accountData)
+	var responseErr *httputils.ResponseError
+	require.True(t, errors.As(err, &responseErr))
+	assert.Equal(t, http.StatusConflict, responseErr.StatusCode)
+
+	created.Attributes.Name = []string{"jane doe"}
+	updated, err := client.UpdateResource(ctx, created)
+	require.NoError(t, err)
+	assert.Equal(t, accounts.Version(1), updated.Version)
+	assert.Equal(t, []string{"jane doe"}, updated.Attributes.Name)
+	assert.Equal(t, "400300", updated.Attributes.BankID)
+
+	_, err = client.UpdateResource(ctx, created)
+	var conflictErr *accounts.VersionConflictError
+	assert.True(t, errors.As(err, &conflictErr))
+
+	fetched, err := client.FetchResource(ctx, accountID)
+	require.NoError(t, err)
+	assert.Equal(t, updated.Attributes, fetched.Attributes)
+
+	err = client.DeleteResource(ctx, accountID, 0)
+	assert.True(t, errors.As(err, &conflictErr))
+
+	require.NoError(t, client.DeleteResource(ctx, accountID, 1))
+	assert.Equal(t, 0, server.Len())
+
+	exists, err := client.ExistsResource(ctx, accountID)
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestServerList(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	client := newAccountsClient(t, server)
+	ctx := context.Background()
+
+	for _, bankID := range []string{"400300", "400300", "400301"} {
+		_, err := client.CreateResource(ctx, newAccountData(bankID))
+		require.NoError(t, err)
+	}
+
+	listed, err := client.ListResources(ctx, accounts.ListOptions{
+		Filters: map[accounts.FilterField]string{accounts.FilterBankID: "400300"},
+	})
+	require.NoError(t, err)
+	assert.Len(t, listed, 2)
+
+	listed, err = client.ListResources(ctx, accounts.ListOptions{PageNumber: 1, PageSize: 2})
+	require.NoError(t, err)
+	assert.Len(t, listed, 1)
+
+	first, err := client.List(ctx, accounts.ListOptions{PageSize: 2})
+	require.NoError(t, err)
+	assert.Equal(t, "/v1/organisation/accounts?page%5Bnumber%5D=1&page%5Bsize%5D=2", first.Meta.Links.Next)
+	assert.Empty(t, first.Meta.Links.Prev)
+
+	last, err := client.List(ctx, accounts.ListOptions{PageNumber: 1, PageSize: 2})
+	require.NoError(t, err)
+	assert.Empty(t, last.Meta.Links.Next)
+	assert.Equal(t, "/v1/organisation/accounts?page%5Bnumber%5D=0&page%5Bsize%5D=2", last.Meta.Links.Prev)
+
+	streamed := 0
+	for cursor := client.ListStream(ctx, accounts.ListOptions{PageSize: 2}); cursor.Next(); {
+		streamed++
+	}
+	assert.Equal(t, 3, streamed)
+
+	server.Reset()
+	listed, err = client.ListResources(ctx, accounts.ListOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, listed)
+}
+
+func TestServerBadRequests(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	client := newAccountsClient(t, server)
+	ctx := context.Background()
+
+	_, err := client.CreateResource(ctx, &accounts.AccountData{ID: "not-an-uuid", Attributes: &accounts.AccountAttributes{}})
+	var responseErr *httputils.ResponseError
+	require.True(t, errors.As(err, &responseErr))
+	assert.Equal(t, http.StatusBadRequest, responseErr.StatusCode)
+
+	_, err = client.CreateResource(ctx, &accounts.AccountData{ID: uuid.New().String()})
+	require.True(t, errors.As(err, &responseErr))
+	assert.Equal(t, "validation failure list:\nattributes in body is required", responseErr.ErrorMessage)
+	assert.Equal(t, []httputils.FieldError{{Pointer: "/attributes", Message: "is required"}}, responseErr.FieldErrors)
+
+	_, err = client.FetchResource(ctx, accounts.NewAccountID())
+	require.True(t, errors.As(err, &responseErr))
+	assert.Equal(t, http.StatusNotFound, responseErr.StatusCode)
+}
+
+func TestServerHealth(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	httpClient, err := httputils.NewClient(server.URL, 5*time.Second)
+	require.NoError(t, err)
+
+	status, err := httpClient.HealthCheck(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, httputils.HealthUp, status)
+}
+
+func TestServerListSorted(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	server.now = func() time.Time {
+		now = now.Add(time.Minute)
+		return now
+	}
+
+	client := newAccountsClient(t, server)
+	ctx := context.Background()
+
+	var created []string
+	for _, bankID := range []string{"400300", "400301", "400302"} {
+		accountData, err := client.CreateResource(ctx, newAccountData(bankID))
+		require.NoError(t, err)
+		created = append(created, accountData.ID)
+	}
+
+	listed, err := client.ListResources(ctx, accounts.ListOptions{Sort: []accounts.Sort{accounts.SortByCreatedOnDesc}})
+	require.NoError(t, err)
+	require.Len(t, listed, 3)
+	assert.Equal(t, []string{created[2], created[1], created[0]}, []string{listed[0].ID, listed[1].ID, listed[2].ID})
+
+	listed, err = client.ListResources(ctx, accounts.ListOptions{Sort: []accounts.Sort{accounts.SortByCreatedOn}})
+	require.NoError(t, err)
+	require.Len(t, listed, 3)
+	assert.Equal(t, created, []string{listed[0].ID, listed[1].ID, listed[2].ID})
+
+	var responseErr *httputils.ResponseError
+	_, err = client.ListResources(ctx, accounts.ListOptions{Sort: []accounts.Sort{"bank_id"}})
+	require.Error(t, err)
+	assert.False(t, errors.As(err, &responseErr), "the sort is validated before the request")
+}
@@ -0,0 +1,119 @@
+package httputils
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptrace"
+	"sync"
+	"time"
+)
+
+// Phase is the phase a request has reached, tracked with httptrace
+type Phase string
+
+const (
+	// PhaseGettingConn is the phase before a connection is obtained
+	PhaseGettingConn Phase = "getting_connection"
+	// PhaseDNS is the phase resolving the host
+	PhaseDNS Phase = "dns"
+	// PhaseConnecting is the phase dialing the host
+	PhaseConnecting Phase = "connecting"
+	// PhaseTLSHandshake is the phase performing the TLS handshake
+	PhaseTLSHandshake Phase = "tls_handshake"
+	// PhaseWritingRequest is the phase writing the request on an obtained connection
+	PhaseWritingRequest Phase = "writing_request"
+	// PhaseWaitingResponse is the phase waiting for the response once the request is written
+	PhaseWaitingResponse Phase = "waiting_response"
+	// PhaseReadingResponse is the phase reading the response once the first byte is received
+	PhaseReadingResponse Phase = "reading_response"
+)
+
+// AbortEvent describes a request aborted because the context of the caller was cancelled or its deadline exceeded,
+// so client-side aborts can be told apart from form3 slowness
+type AbortEvent struct {
+	Method  string
+	Path    string
+	Elapsed time.Duration
+	Phase   Phase
+	Err     error
+}
+
+type hooks struct {
+	onAbort  func(AbortEvent)
+	onTiming func(RequestTiming)
+	onTrace  func(*http.Request) *httptrace.ClientTrace
+}
+
+// WithAbortHook registers the hook called when a request is aborted by the context of the caller
+func WithAbortHook(hook func(AbortEvent)) Option {
+	return func(c *Client) error {
+		c.hooks.onAbort = hook
+		return nil
+	}
+}
+
+// phaseTracker keeps the last phase reached by a request
+type phaseTracker struct {
+	mu      sync.Mutex
+	request *http.Request
+	started time.Time
+	phase   Phase
+}
+
+func (t *phaseTracker) set(phase Phase) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.phase = phase
+}
+
+func (t *phaseTracker) current() Phase {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.phase
+}
+
+// trackPhases attaches a httptrace to the request tracking its phases, only when an abort hook is registered
+func (c Client) trackPhases(request *http.Request) (*http.Request, *phaseTracker) {
+	if c.hooks.onAbort == nil {
+		return request, nil
+	}
+
+	tracker := &phaseTracker{started: time.Now(), phase: PhaseGettingConn}
+	trace := &httptrace.ClientTrace{
+		DNSStart:          func(httptrace.DNSStartInfo) { tracker.set(PhaseDNS) },
+		ConnectStart:      func(string, string) { tracker.set(PhaseConnecting) },
+		TLSHandshakeStart: func() { tracker.set(PhaseTLSHandshake) },
+		TLSHandshakeDone:  func(tls.ConnectionState, error) { tracker.set(PhaseWritingRequest) },
+		GotConn:           func(httptrace.GotConnInfo) { tracker.set(PhaseWritingRequest) },
+		WroteRequest:      func(httptrace.WroteRequestInfo) { tracker.set(PhaseWaitingResponse) },
+		GotFirstResponseByte: func() {
+			tracker.set(PhaseReadingResponse)
+		},
+	}
+
+	tracker.request = request.WithContext(httptrace.WithClientTrace(request.Context(), trace))
+	return tracker.request, tracker
+}
+
+// reportAbort calls the abort hook when the request failed because the context of the caller is done
+func (c Client) reportAbort(tracker *phaseTracker, err error) {
+	if tracker == nil || err == nil {
+		return
+	}
+
+	ctxErr := tracker.request.Context().Err()
+	if ctxErr == nil {
+		return
+	}
+	defer ignorePanic()
+
+	c.hooks.onAbort(AbortEvent{
+		Method:  tracker.request.Method,
+		Path:    tracker.request.URL.Path,
+		Elapsed: time.Since(tracker.started),
+		Phase:   tracker.current(),
+		Err:     ctxErr,
+	})
+}
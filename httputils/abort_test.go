@@ -0,0 +1,90 @@
+package httputils
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAbortHook(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	defer close(release)
+
+	tests := []struct {
+		name      string
+		ctx       func() (context.Context, context.CancelFunc)
+		wantErr   error
+		wantPhase Phase
+	}{
+		{
+			name: "Successfully reports a request cancelled by the caller while waiting for the response",
+			ctx: func() (context.Context, context.CancelFunc) {
+				ctx, cancel := context.WithCancel(context.Background())
+				time.AfterFunc(50*time.Millisecond, cancel)
+				return ctx, cancel
+			},
+			wantErr:   context.Canceled,
+			wantPhase: PhaseWaitingResponse,
+		},
+		{
+			name: "Successfully reports a request whose deadline exceeded while waiting for the response",
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.WithTimeout(context.Background(), 50*time.Millisecond)
+			},
+			wantErr:   context.DeadlineExceeded,
+			wantPhase: PhaseWaitingResponse,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events := make(chan AbortEvent, 1)
+			client, err := NewClient(server.URL, time.Minute, WithAbortHook(func(event AbortEvent) {
+				events <- event
+			}))
+			require.NoError(t, err)
+
+			ctx, cancel := tt.ctx()
+			defer cancel()
+
+			_, err = client.Get(ctx, "/v1/organisation/accounts", nil)
+			require.Error(t, err)
+
+			select {
+			case event := <-events:
+				assert.Equal(t, http.MethodGet, event.Method)
+				assert.Equal(t, "/v1/organisation/accounts", event.Path)
+				assert.Equal(t, tt.wantPhase, event.Phase)
+				assert.ErrorIs(t, event.Err, tt.wantErr)
+				assert.GreaterOrEqual(t, event.Elapsed, 50*time.Millisecond)
+			default:
+				t.Fatal("expected an abort event")
+			}
+		})
+	}
+}
+
+func TestAbortHookIgnoresFailuresNotCausedByTheCaller(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close()
+
+	called := false
+	client, err := NewClient(server.URL, time.Minute, WithAbortHook(func(AbortEvent) {
+		called = true
+	}))
+	require.NoError(t, err)
+
+	_, err = client.Get(context.Background(), "/v1/organisation/accounts", nil)
+	require.Error(t, err)
+	assert.False(t, called)
+}
@@ -0,0 +1,149 @@
+package httputils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// AuthError is returned when form3 refuses the credentials of the request, with a 401 when the request is not
+// authenticated and a 403 when the credentials are not allowed to perform it
+type AuthError struct {
+	StatusCode int
+	// WWWAuthenticate is the WWW-Authenticate header of the response, it tells the scheme form3 expects
+	WWWAuthenticate string
+	ErrorMessage    string
+}
+
+func (err *AuthError) Error() string {
+	message := fmt.Sprintf("auth failure with status code %d: %s", err.StatusCode, err.Guidance())
+	if err.ErrorMessage != "" {
+		message += fmt.Sprintf(" (%s)", err.ErrorMessage)
+	}
+
+	return message
+}
+
+// Guidance tells what to check to solve the failure
+func (err *AuthError) Guidance() string {
+	if err.StatusCode == http.StatusForbidden {
+		return "the credentials are not allowed to perform the operation, check the permissions of the user on the organisation"
+	}
+
+	return "the request is not authenticated, check the credentials and that they have not expired"
+}
+
+// newAuthError creates the auth error of the response, the error message is taken from the body when it has one
+func (c Client) newAuthError(response *http.Response, respBody []byte) *AuthError {
+	authErr := &AuthError{
+		StatusCode:      response.StatusCode,
+		WWWAuthenticate: response.Header.Get("WWW-Authenticate"),
+	}
+
+	var errRes ResponseError
+	if len(respBody) > 0 && c.respUnmarshaller(respBody, &errRes) == nil {
+		authErr.ErrorMessage = errRes.ErrorMessage
+	}
+
+	return authErr
+}
+
+// WithAuthRefresh registers the refresh of the credentials, such as a token, called when form3 answers a request
+// with a 401, the request is then sent once more through the transport, so the credentials must be applied by a round
+// tripper, see WithRoundTripper, to be refreshed on the second attempt
+func WithAuthRefresh(refresh func(ctx context.Context) error) Option {
+	return func(c *Client) error {
+		if refresh == nil {
+			return errors.New("invalid auth refresh, it must not be nil")
+		}
+
+		c.authRefresh = refresh
+		return nil
+	}
+}
+
+// send sends the request paced by the throttling, refreshing the credentials and sending it once more when it is
+// unauthorized and an auth refresh is registered
+func (c Client) send(request *http.Request) (*http.Response, error) {
+	if c.closer.isClosed() {
+		return nil, ErrClientClosed
+	}
+
+	ctx, cancel := c.closer.bind(request.Context())
+	request = request.WithContext(ctx)
+
+	response, err := c.sendBound(request)
+	if err != nil {
+		cancel()
+		if c.closer.isClosed() {
+			return nil, ErrClientClosed
+		}
+		return nil, err
+	}
+
+	if response.Body == nil || response.Body == http.NoBody {
+		cancel()
+		return response, nil
+	}
+
+	response.Body = &cancelBody{ReadCloser: response.Body, cancel: cancel}
+	return response, nil
+}
+
+// sendBound sends the request once it is bound to the shutdown of the client
+func (c Client) sendBound(request *http.Request) (*http.Response, error) {
+	if err := c.rateLimiter.wait(request.Context()); err != nil {
+		return nil, err
+	}
+
+	response, err := c.roundTrip(request)
+	if err != nil || response.StatusCode != http.StatusUnauthorized || c.authRefresh == nil {
+		return response, err
+	}
+
+	if err := c.refreshAuth(request.Context()); err != nil {
+		return response, nil
+	}
+
+	retry := request.Clone(request.Context())
+	if request.GetBody != nil {
+		body, err := request.GetBody()
+		if err != nil {
+			return response, nil
+		}
+		retry.Body = body
+	}
+
+	closeBody(response)
+
+	return c.roundTrip(retry)
+}
+
+// refreshAuth refreshes the credentials, a panic of the refresh is returned as a PanicError
+func (c Client) refreshAuth(ctx context.Context) (err error) {
+	defer recoverPanic(&err)
+
+	return c.authRefresh(ctx)
+}
+
+// roundTrip authenticates and sends the request once, reporting its timing and keeping the rate limit state of the
+// response
+func (c Client) roundTrip(request *http.Request) (_ *http.Response, err error) {
+	defer recoverPanic(&err)
+
+	if err := c.credentials.authenticate(request); err != nil {
+		return nil, err
+	}
+
+	request, timing := c.traceTiming(c.attachTrace(request))
+	response, err := c.httpClient.Do(request)
+	timing.finish(response, err)
+	if err == nil {
+		c.rateLimiter.observe(response.Header)
+	} else if request.Context().Err() == nil {
+		c.baseURIs.fail(request.URL)
+	}
+
+	return response, err
+}
@@ -0,0 +1,137 @@
+package httputils
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientAuthError(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		call       func(*Client) error
+		wantErrMsg string
+	}{
+		{
+			name:       "Failed to post data without credentials",
+			statusCode: http.StatusUnauthorized,
+			call: func(c *Client) error {
+				_, err := c.Post(context.Background(), "/v1/organisation/accounts", []byte(`{}`), nil)
+				return err
+			},
+			wantErrMsg: "POST /v1/organisation/accounts: auth failure with status code 401: the request is not authenticated, check the credentials and that they have not expired (invalid token)",
+		},
+		{
+			name:       "Failed to patch data without permission",
+			statusCode: http.StatusForbidden,
+			call: func(c *Client) error {
+				_, err := c.Patch(context.Background(), "/v1/organisation/accounts/1", []byte(`{}`), nil)
+				return err
+			},
+			wantErrMsg: "PATCH /v1/organisation/accounts/1: auth failure with status code 403: the credentials are not allowed to perform the operation, check the permissions of the user on the organisation (invalid token)",
+		},
+		{
+			name:       "Failed to get data without credentials",
+			statusCode: http.StatusUnauthorized,
+			call: func(c *Client) error {
+				_, err := c.Get(context.Background(), "/v1/organisation/accounts/1", nil)
+				return err
+			},
+			wantErrMsg: "GET /v1/organisation/accounts/1: auth failure with status code 401: the request is not authenticated, check the credentials and that they have not expired (invalid token)",
+		},
+		{
+			name:       "Failed to delete data without permission",
+			statusCode: http.StatusForbidden,
+			call: func(c *Client) error {
+				return c.Delete(context.Background(), "/v1/organisation/accounts/1", nil)
+			},
+			wantErrMsg: "DELETE /v1/organisation/accounts/1: auth failure with status code 403: the credentials are not allowed to perform the operation, check the permissions of the user on the organisation (invalid token)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="form3"`)
+				w.WriteHeader(tt.statusCode)
+				_, _ = w.Write([]byte(`{"error_message": "invalid token"}`))
+			}))
+			defer server.Close()
+
+			client, err := NewClient(server.URL, time.Second)
+			require.NoError(t, err)
+
+			err = tt.call(client)
+			require.Error(t, err)
+			assert.EqualError(t, err, tt.wantErrMsg)
+
+			var authErr *AuthError
+			require.True(t, errors.As(err, &authErr))
+			assert.Equal(t, tt.statusCode, authErr.StatusCode)
+			assert.Equal(t, `Bearer realm="form3"`, authErr.WWWAuthenticate)
+		})
+	}
+}
+
+func TestClientWithAuthRefresh(t *testing.T) {
+	var token atomic.Value
+	token.Store("expired")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer fresh" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		body, _ := ioutil.ReadAll(r.Body)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write(body)
+	}))
+	defer server.Close()
+
+	authorize := func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(request *http.Request) (*http.Response, error) {
+			request = request.Clone(request.Context())
+			request.Header.Set("Authorization", "Bearer "+token.Load().(string))
+			return next.RoundTrip(request)
+		})
+	}
+
+	var refreshes int32
+	client, err := NewClient(server.URL, time.Second, WithRoundTripper(authorize), WithAuthRefresh(func(ctx context.Context) error {
+		atomic.AddInt32(&refreshes, 1)
+		token.Store("fresh")
+		return nil
+	}))
+	require.NoError(t, err)
+
+	got, err := client.Post(context.Background(), "/v1/organisation/accounts", []byte(`{"data": {}}`), nil)
+	require.NoError(t, err)
+	assert.Equal(t, `{"data": {}}`, string(got), "the body must be sent again on the second attempt")
+	assert.Equal(t, int32(1), atomic.LoadInt32(&refreshes))
+
+	failing, err := NewClient(server.URL, time.Second, WithAuthRefresh(func(ctx context.Context) error {
+		return errors.New("refresh failed")
+	}))
+	require.NoError(t, err)
+
+	_, err = failing.Post(context.Background(), "/v1/organisation/accounts", []byte(`{}`), nil)
+	var authErr *AuthError
+	require.True(t, errors.As(err, &authErr))
+	assert.Equal(t, http.StatusUnauthorized, authErr.StatusCode)
+}
+
+func TestClientWithNilAuthRefresh(t *testing.T) {
+	_, err := NewClient("https://valid-url.com", time.Second, WithAuthRefresh(nil))
+	require.Error(t, err)
+	assert.EqualError(t, err, "invalid auth refresh, it must not be nil; invalid option")
+}
@@ -0,0 +1,115 @@
+package httputils
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// Clock tells the time to the internals measuring windows and deadlines, such as the rate limiting
+type Clock interface {
+	Now() time.Time
+}
+
+// Sleeper waits for the delays of the internals, such as the rate limiting, it returns the context error when the
+// context is done before the delay is over
+type Sleeper interface {
+	Sleep(ctx context.Context, delay time.Duration) error
+}
+
+// SystemClock is the real clock and sleeper, the default of the clients
+type SystemClock struct{}
+
+// Now returns the current time
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// Sleep waits for the delay or the context to be done
+func (SystemClock) Sleep(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// ManualClock is a clock and a sleeper meant for the tests, its time only moves when it is advanced and sleeping
+// advances it by the delay without waiting, so the tests fast-forward the time instead of sleeping for real
+type ManualClock struct {
+	mu    sync.Mutex
+	now   time.Time
+	slept time.Duration
+}
+
+// NewManualClock creates a manual clock set at the time given
+func NewManualClock(now time.Time) *ManualClock {
+	return &ManualClock{now: now}
+}
+
+// Now returns the time of the clock
+func (clock *ManualClock) Now() time.Time {
+	clock.mu.Lock()
+	defer clock.mu.Unlock()
+
+	return clock.now
+}
+
+// Advance moves the time of the clock forward
+func (clock *ManualClock) Advance(delay time.Duration) {
+	clock.mu.Lock()
+	defer clock.mu.Unlock()
+
+	clock.now = clock.now.Add(delay)
+}
+
+// Sleep advances the clock by the delay without waiting, unless the context is already done
+func (clock *ManualClock) Sleep(ctx context.Context, delay time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	clock.mu.Lock()
+	defer clock.mu.Unlock()
+
+	clock.now = clock.now.Add(delay)
+	clock.slept += delay
+	return nil
+}
+
+// Slept returns the sum of the delays slept on the clock
+func (clock *ManualClock) Slept() time.Duration {
+	clock.mu.Lock()
+	defer clock.mu.Unlock()
+
+	return clock.slept
+}
+
+// WithClock sets the clock of the rate limiting and of the DNS cache, the system clock is used by default
+func WithClock(clock Clock) Option {
+	return func(c *Client) error {
+		if clock == nil {
+			return errors.New("invalid clock, it must not be nil")
+		}
+
+		c.rateLimiter.clock = clock
+		return nil
+	}
+}
+
+// WithSleeper sets the sleeper waiting for the delays of the rate limiting, the system clock is used by default
+func WithSleeper(sleeper Sleeper) Option {
+	return func(c *Client) error {
+		if sleeper == nil {
+			return errors.New("invalid sleeper, it must not be nil")
+		}
+
+		c.rateLimiter.sleeper = sleeper
+		return nil
+	}
+}
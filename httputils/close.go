@@ -0,0 +1,90 @@
+package httputils
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+)
+
+// ErrClientClosed is returned by the requests made once the client is closed
+var ErrClientClosed = errors.New("client is closed")
+
+// closer is the shutdown state shared by the client and the clients derived from it
+type closer struct {
+	once sync.Once
+	done chan struct{}
+}
+
+func newCloser() *closer {
+	return &closer{done: make(chan struct{})}
+}
+
+// close marks the client as closed waking up everything waiting on it, closing it more than once is a no-op
+func (c *closer) close() {
+	if c == nil {
+		return
+	}
+
+	c.once.Do(func() {
+		close(c.done)
+	})
+}
+
+// closed returns the channel closed once the client is closed, a nil closer is never closed
+func (c *closer) closed() <-chan struct{} {
+	if c == nil {
+		return nil
+	}
+
+	return c.done
+}
+
+// bind returns a context cancelled once the client is closed, the stop function must be called to release it
+func (c *closer) bind(ctx context.Context) (context.Context, context.CancelFunc) {
+	if c == nil {
+		return ctx, func() {}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	go func() {
+		select {
+		case <-c.done:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	return ctx, cancel
+}
+
+// isClosed tells if the client is closed
+func (c *closer) isClosed() bool {
+	select {
+	case <-c.closed():
+		return true
+	default:
+		return false
+	}
+}
+
+// Close shuts the client down for a graceful shutdown, the requests waiting on the throttling are released, the
+// requests in flight are cancelled, the idle connections are closed and the requests made afterwards fail with
+// ErrClientClosed, it is shared by the clients derived from this one
+func (c Client) Close() error {
+	c.closer.close()
+	c.httpClient.CloseIdleConnections()
+	return nil
+}
+
+// cancelBody releases the context bound to the shutdown of the client once the body is closed
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
@@ -0,0 +1,89 @@
+package httputils
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientClose(t *testing.T) {
+	tests := []struct {
+		name        string
+		handler     http.HandlerFunc
+		opts        []Option
+		warmUp      bool
+		closeBefore bool
+	}{
+		{
+			name: "Failed to get data once the client is closed",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(`{"data": {}}`))
+			},
+			closeBefore: true,
+		},
+		{
+			name: "Failed to get data throttled when the client is closed",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("X-RateLimit-Remaining", "0")
+				w.Header().Set("X-RateLimit-Reset", "60")
+				_, _ = w.Write([]byte(`{"data": {}}`))
+			},
+			opts:   []Option{WithAdaptiveThrottling()},
+			warmUp: true,
+		},
+		{
+			name: "Failed to get data in flight when the client is closed",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				<-r.Context().Done()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.handler)
+			defer server.Close()
+
+			client, err := NewClient(server.URL, time.Minute, tt.opts...)
+			require.NoError(t, err)
+
+			if tt.warmUp {
+				_, err := client.Get(context.Background(), "/v1/organisation/accounts", nil)
+				require.NoError(t, err)
+			}
+
+			if tt.closeBefore {
+				require.NoError(t, client.Close())
+			} else {
+				time.AfterFunc(20*time.Millisecond, func() {
+					_ = client.Close()
+				})
+			}
+
+			started := time.Now()
+			_, err = client.Get(context.Background(), "/v1/organisation/accounts", nil)
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, ErrClientClosed))
+			assert.Less(t, time.Since(started), 5*time.Second)
+		})
+	}
+}
+
+func TestClientCloseIsIdempotent(t *testing.T) {
+	client, err := NewClient("https://api.form3.tech", time.Second)
+	require.NoError(t, err)
+
+	derived := client.WithHeaders(http.Header{"X-Request-Id": []string{"1"}})
+
+	require.NoError(t, client.Close())
+	require.NoError(t, client.Close())
+
+	err = derived.Delete(context.Background(), "/v1/organisation/accounts/1", nil)
+	assert.EqualError(t, err, "DELETE /v1/organisation/accounts/1: client is closed")
+}
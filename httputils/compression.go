@@ -0,0 +1,48 @@
+package httputils
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// WithCompression asks form3 for a gzip or deflate compressed response, reducing the bandwidth of the large account
+// lists, the responses are decompressed when the body is read
+func WithCompression() Option {
+	return func(c *Client) error {
+		if c.header == nil {
+			c.header = http.Header{}
+		}
+
+		c.header.Set("Accept-Encoding", "gzip, deflate")
+		return nil
+	}
+}
+
+// readBody reads the body of the response decompressing it when it is gzip or deflate encoded, which happens when
+// the encoding is asked explicitly or a custom transport disables the automatic decompression
+func (c Client) readBody(response *http.Response) ([]byte, error) {
+	var body io.Reader = response.Body
+
+	switch strings.ToLower(strings.TrimSpace(response.Header.Get("Content-Encoding"))) {
+	case "gzip":
+		gzipReader, err := gzip.NewReader(response.Body)
+		if err != nil {
+			return nil, fmt.Errorf("%w; failed to decompress gzip body", err)
+		}
+		defer gzipReader.Close()
+		body = gzipReader
+	case "deflate":
+		zlibReader, err := zlib.NewReader(response.Body)
+		if err != nil {
+			return nil, fmt.Errorf("%w; failed to decompress deflate body", err)
+		}
+		defer zlibReader.Close()
+		body = zlibReader
+	}
+
+	return c.bodyReader(body)
+}
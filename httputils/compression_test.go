@@ -0,0 +1,115 @@
+package httputils
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const compressedBody = `{"data": [{"id": "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc", "type": "accounts"}]}`
+
+func compress(t *testing.T, encoding string) []byte {
+	var buf bytes.Buffer
+	var writer io.WriteCloser
+	switch encoding {
+	case "gzip":
+		writer = gzip.NewWriter(&buf)
+	case "deflate":
+		writer = zlib.NewWriter(&buf)
+	}
+
+	_, err := writer.Write([]byte(compressedBody))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	return buf.Bytes()
+}
+
+func TestClientWithCompression(t *testing.T) {
+	tests := []struct {
+		name       string
+		encoding   string
+		body       func(t *testing.T) []byte
+		wantErr    bool
+		wantErrMsg string
+	}{
+		{
+			name:     "Successfully decompresses a gzip response",
+			encoding: "gzip",
+			body:     func(t *testing.T) []byte { return compress(t, "gzip") },
+		},
+		{
+			name:     "Successfully decompresses a deflate response",
+			encoding: "deflate",
+			body:     func(t *testing.T) []byte { return compress(t, "deflate") },
+		},
+		{
+			name:     "Successfully reads a response that is not compressed",
+			encoding: "",
+			body:     func(t *testing.T) []byte { return []byte(compressedBody) },
+		},
+		{
+			name:       "Failed to decompress an invalid gzip response",
+			encoding:   "gzip",
+			body:       func(t *testing.T) []byte { return []byte(compressedBody) },
+			wantErr:    true,
+			wantErrMsg: "GET /v1/organisation/accounts: gzip: invalid header; failed to decompress gzip body; failed to read response body",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "gzip, deflate", r.Header.Get("Accept-Encoding"))
+				if tt.encoding != "" {
+					w.Header().Set("Content-Encoding", tt.encoding)
+				}
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write(tt.body(t))
+			}))
+			defer server.Close()
+
+			client, err := NewClient(server.URL, time.Second, WithCompression())
+			require.NoError(t, err)
+
+			got, err := client.Get(context.Background(), "/v1/organisation/accounts", nil)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.wantErrMsg)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, compressedBody, string(got))
+			}
+		})
+	}
+}
+
+func TestClientDecompressesWithoutAutomaticDecompression(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write(compress(t, "gzip"))
+	}))
+	defer server.Close()
+
+	disableDecompression := func(next http.RoundTripper) http.RoundTripper {
+		next.(*http.Transport).DisableCompression = true
+		return next
+	}
+
+	client, err := NewClient(server.URL, time.Second, WithRoundTripper(disableDecompression))
+	require.NoError(t, err)
+
+	got, err := client.Post(context.Background(), "/v1/organisation/accounts", []byte(`{}`), nil)
+	require.NoError(t, err)
+	assert.Equal(t, compressedBody, string(got))
+}
@@ -0,0 +1,157 @@
+package httputils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// Credentials authenticate the requests sent to form3, such as by setting a bearer token or by signing the request
+type Credentials interface {
+	Authenticate(request *http.Request) error
+}
+
+// CredentialsFunc is a function authenticating the requests
+type CredentialsFunc func(request *http.Request) error
+
+// Authenticate calls the function with the request
+func (f CredentialsFunc) Authenticate(request *http.Request) error {
+	return f(request)
+}
+
+// BearerToken authenticates the requests with the token in the Authorization header
+type BearerToken string
+
+// Authenticate sets the Authorization header of the request
+func (token BearerToken) Authenticate(request *http.Request) error {
+	request.Header.Set("Authorization", "Bearer "+string(token))
+	return nil
+}
+
+// credentialStore holds the default credentials and the ones of the organisations, it is shared by the derived
+// clients and can be changed at runtime
+type credentialStore struct {
+	mu            sync.RWMutex
+	fallback      Credentials
+	organisations map[uuid.UUID]Credentials
+}
+
+func newCredentialStore() *credentialStore {
+	return &credentialStore{organisations: map[uuid.UUID]Credentials{}}
+}
+
+func (store *credentialStore) set(credentials Credentials) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	store.fallback = credentials
+}
+
+func (store *credentialStore) setOrganisation(organisationID uuid.UUID, credentials Credentials) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	store.organisations[organisationID] = credentials
+}
+
+// lookup returns the credentials of the request, the ones of its organisation or the default ones, the flag tells
+// if any credentials are registered
+func (store *credentialStore) lookup(organisationID uuid.UUID) (Credentials, bool) {
+	store.mu.RLock()
+	defer store.mu.RUnlock()
+
+	if credentials, ok := store.organisations[organisationID]; ok {
+		return credentials, true
+	}
+
+	return store.fallback, store.fallback != nil || len(store.organisations) > 0
+}
+
+// authenticate authenticates the request with the credentials of its organisation, see ContextWithOrganisation, or
+// with the default credentials, a request of an organisation without credentials fails when there are no default ones
+// and the requests are sent as they are when no credentials are registered at all
+func (store *credentialStore) authenticate(request *http.Request) error {
+	if store == nil {
+		return nil
+	}
+
+	organisationID, ok := OrganisationFromContext(request.Context())
+	credentials, registered := store.lookup(organisationID)
+	if !registered {
+		return nil
+	}
+
+	if credentials == nil {
+		if ok {
+			return fmt.Errorf("no credentials for the organisation %s", organisationID)
+		}
+		return errors.New("no credentials for the request without an organisation")
+	}
+
+	if err := credentials.Authenticate(request); err != nil {
+		return fmt.Errorf("%w; unable to authenticate the request", err)
+	}
+
+	return nil
+}
+
+// WithCredentials sets the credentials of the requests, the ones of an organisation registered with
+// WithOrganisationCredentials take precedence for its requests
+func WithCredentials(credentials Credentials) Option {
+	return func(c *Client) error {
+		return c.SetCredentials(credentials)
+	}
+}
+
+// WithOrganisationCredentials registers the credentials of an organisation, picked for the requests whose context
+// carries it, so a client instance can serve many organisations, see ContextWithOrganisation
+func WithOrganisationCredentials(organisationID uuid.UUID, credentials Credentials) Option {
+	return func(c *Client) error {
+		return c.SetOrganisationCredentials(organisationID, credentials)
+	}
+}
+
+// SetCredentials swaps the default credentials at runtime, such as on the rotation of the token or of the signing key,
+// the requests sent from then on use them without recreating the client nor dropping its connections, the derived
+// clients share the credentials so they are swapped on them too
+func (c Client) SetCredentials(credentials Credentials) error {
+	if credentials == nil {
+		return errors.New("invalid credentials, they must not be nil")
+	}
+
+	c.credentials.set(credentials)
+	return nil
+}
+
+// SetOrganisationCredentials swaps the credentials of an organisation at runtime, registering them when the
+// organisation has none, see SetCredentials
+func (c Client) SetOrganisationCredentials(organisationID uuid.UUID, credentials Credentials) error {
+	if organisationID == uuid.Nil {
+		return errors.New("invalid organisation id of the credentials, it must not be nil")
+	}
+
+	if credentials == nil {
+		return fmt.Errorf("invalid credentials of the organisation %s, they must not be nil", organisationID)
+	}
+
+	c.credentials.setOrganisation(organisationID, credentials)
+	return nil
+}
+
+type organisationKey struct{}
+
+// ContextWithOrganisation returns a context carrying the organisation the requests are sent on behalf of, their
+// credentials are the ones registered for it
+func ContextWithOrganisation(ctx context.Context, organisationID uuid.UUID) context.Context {
+	return context.WithValue(ctx, organisationKey{}, organisationID)
+}
+
+// OrganisationFromContext returns the organisation the context carries, if any
+func OrganisationFromContext(ctx context.Context) (uuid.UUID, bool) {
+	organisationID, ok := ctx.Value(organisationKey{}).(uuid.UUID)
+	return organisationID, ok && organisationID != uuid.Nil
+}
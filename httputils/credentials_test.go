@@ -0,0 +1,208 @@
+package httputils
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientCredentials(t *testing.T) {
+	tenantA := uuid.MustParse("eb0bd6f5-c3f5-44b2-b677-acd23cdde73c")
+	tenantB := uuid.MustParse("f199fe08-90b4-4756-9c1f-3a2352ea4933")
+
+	tests := []struct {
+		name          string
+		opts          []Option
+		ctx           context.Context
+		wantAuth      string
+		wantErr       bool
+		wantErrMsg    string
+		wantNoRequest bool
+	}{
+		{
+			name:     "Successfully sends the request without credentials",
+			ctx:      context.Background(),
+			wantAuth: "",
+		},
+		{
+			name:     "Successfully authenticates the request with the default credentials",
+			opts:     []Option{WithCredentials(BearerToken("default"))},
+			ctx:      context.Background(),
+			wantAuth: "Bearer default",
+		},
+		{
+			name: "Successfully authenticates the request with the credentials of its organisation",
+			opts: []Option{
+				WithCredentials(BearerToken("default")),
+				WithOrganisationCredentials(tenantA, BearerToken("tenant-a")),
+				WithOrganisationCredentials(tenantB, BearerToken("tenant-b")),
+			},
+			ctx:      ContextWithOrganisation(context.Background(), tenantB),
+			wantAuth: "Bearer tenant-b",
+		},
+		{
+			name: "Successfully falls back to the default credentials for an unknown organisation",
+			opts: []Option{
+				WithCredentials(BearerToken("default")),
+				WithOrganisationCredentials(tenantA, BearerToken("tenant-a")),
+			},
+			ctx:      ContextWithOrganisation(context.Background(), tenantB),
+			wantAuth: "Bearer default",
+		},
+		{
+			name: "Successfully authenticates the request with a credentials func",
+			opts: []Option{WithCredentials(CredentialsFunc(func(request *http.Request) error {
+				request.Header.Set("Authorization", "Signature keyId=\"key\"")
+				return nil
+			}))},
+			ctx:      context.Background(),
+			wantAuth: "Signature keyId=\"key\"",
+		},
+		{
+			name:          "Failed to authenticate the request of an organisation without credentials",
+			opts:          []Option{WithOrganisationCredentials(tenantA, BearerToken("tenant-a"))},
+			ctx:           ContextWithOrganisation(context.Background(), tenantB),
+			wantErr:       true,
+			wantErrMsg:    "GET /v1/organisation/accounts: no credentials for the organisation f199fe08-90b4-4756-9c1f-3a2352ea4933",
+			wantNoRequest: true,
+		},
+		{
+			name:          "Failed to authenticate the request without an organisation",
+			opts:          []Option{WithOrganisationCredentials(tenantA, BearerToken("tenant-a"))},
+			ctx:           context.Background(),
+			wantErr:       true,
+			wantErrMsg:    "GET /v1/organisation/accounts: no credentials for the request without an organisation",
+			wantNoRequest: true,
+		},
+		{
+			name: "Failed to authenticate the request when the credentials fail",
+			opts: []Option{WithCredentials(CredentialsFunc(func(*http.Request) error {
+				return errors.New("signing failure")
+			}))},
+			ctx:           context.Background(),
+			wantErr:       true,
+			wantErrMsg:    "GET /v1/organisation/accounts: signing failure; unable to authenticate the request",
+			wantNoRequest: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			var gotAuth string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				gotAuth = r.Header.Get("Authorization")
+				_, _ = w.Write([]byte(`{}`))
+			}))
+			defer server.Close()
+
+			client, err := NewClient(server.URL, time.Second, tt.opts...)
+			require.NoError(t, err)
+
+			_, err = client.Get(tt.ctx, "/v1/organisation/accounts", nil)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.wantErrMsg)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantAuth, gotAuth)
+			}
+
+			if tt.wantNoRequest {
+				assert.Zero(t, requests)
+			}
+		})
+	}
+}
+
+func TestCredentialsOptions(t *testing.T) {
+	tests := []struct {
+		name       string
+		opt        Option
+		wantErrMsg string
+	}{
+		{
+			name:       "Failed with nil credentials",
+			opt:        WithCredentials(nil),
+			wantErrMsg: "invalid credentials, they must not be nil; invalid option",
+		},
+		{
+			name:       "Failed with the credentials of a nil organisation",
+			opt:        WithOrganisationCredentials(uuid.Nil, BearerToken("token")),
+			wantErrMsg: "invalid organisation id of the credentials, it must not be nil; invalid option",
+		},
+		{
+			name:       "Failed with nil credentials of an organisation",
+			opt:        WithOrganisationCredentials(uuid.MustParse("eb0bd6f5-c3f5-44b2-b677-acd23cdde73c"), nil),
+			wantErrMsg: "invalid credentials of the organisation eb0bd6f5-c3f5-44b2-b677-acd23cdde73c, they must not be nil; invalid option",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewClient("https://api.form3.tech", time.Second, tt.opt)
+			require.Error(t, err)
+			assert.EqualError(t, err, tt.wantErrMsg)
+		})
+	}
+}
+
+func TestOrganisationFromContext(t *testing.T) {
+	_, ok := OrganisationFromContext(context.Background())
+	assert.False(t, ok)
+
+	_, ok = OrganisationFromContext(ContextWithOrganisation(context.Background(), uuid.Nil))
+	assert.False(t, ok)
+
+	organisationID := uuid.New()
+	got, ok := OrganisationFromContext(ContextWithOrganisation(context.Background(), organisationID))
+	assert.True(t, ok)
+	assert.Equal(t, organisationID, got)
+}
+
+func TestClientSetCredentials(t *testing.T) {
+	tenant := uuid.MustParse("eb0bd6f5-c3f5-44b2-b677-acd23cdde73c")
+
+	var gotAuth []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = append(gotAuth, r.Header.Get("Authorization"))
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, time.Second)
+	require.NoError(t, err)
+	derived := client.WithHeaders(http.Header{"X-Team": []string{"payments"}})
+
+	get := func(ctx context.Context) {
+		t.Helper()
+		_, err := derived.Get(ctx, "/v1/organisation/accounts", nil)
+		require.NoError(t, err)
+	}
+
+	get(context.Background())
+	require.NoError(t, client.SetCredentials(BearerToken("old")))
+	get(context.Background())
+	require.NoError(t, client.SetCredentials(BearerToken("rotated")))
+	get(context.Background())
+	require.NoError(t, client.SetOrganisationCredentials(tenant, BearerToken("tenant")))
+	get(ContextWithOrganisation(context.Background(), tenant))
+
+	assert.Equal(t, []string{"", "Bearer old", "Bearer rotated", "Bearer tenant"}, gotAuth)
+
+	err = client.SetCredentials(nil)
+	require.Error(t, err)
+	assert.EqualError(t, err, "invalid credentials, they must not be nil")
+
+	err = client.SetOrganisationCredentials(uuid.Nil, BearerToken("tenant"))
+	require.Error(t, err)
+	assert.EqualError(t, err, "invalid organisation id of the credentials, it must not be nil")
+}
@@ -0,0 +1,47 @@
+package httputils
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// WithTimeout returns a derived client with the timeout given, the derived client shares the transport and so the
+// connections of the client it derives from, so the call sites needing a different timeout don't reconstruct them
+func (c Client) WithTimeout(timeout time.Duration) (*Client, error) {
+	if timeout < 0 {
+		return nil, fmt.Errorf("invalid timeout %s, it must not be negative", timeout)
+	}
+
+	derived := c.derive()
+	derived.timeout = timeout
+	if httpClient, ok := c.httpClient.(*http.Client); ok {
+		timedClient := *httpClient
+		timedClient.Timeout = timeout
+		derived.httpClient = &timedClient
+	}
+
+	return derived, nil
+}
+
+// WithHeaders returns a derived client sending the header given on every request, the values override the ones of
+// the same keys in the header of the client it derives from and the header given to a request is added on top of them
+func (c Client) WithHeaders(header http.Header) *Client {
+	derived := c.derive()
+	for key, values := range header {
+		derived.header[http.CanonicalHeaderKey(key)] = append([]string(nil), values...)
+	}
+
+	return derived
+}
+
+// derive returns a copy of the client with its own header, the transport is shared
+func (c Client) derive() *Client {
+	derived := c
+	derived.header = c.header.Clone()
+	if derived.header == nil {
+		derived.header = http.Header{}
+	}
+
+	return &derived
+}
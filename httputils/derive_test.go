@@ -0,0 +1,56 @@
+package httputils
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientWithTimeout(t *testing.T) {
+	client, err := NewClient("https://valid-url.com", 15*time.Second)
+	require.NoError(t, err)
+
+	derived, err := client.WithTimeout(time.Second)
+	require.NoError(t, err)
+
+	assert.Equal(t, time.Second, derived.httpClient.(*http.Client).Timeout)
+	assert.Equal(t, 15*time.Second, client.httpClient.(*http.Client).Timeout, "the client derived from must keep its timeout")
+	assert.Same(t, client.httpClient.(*http.Client).Transport, derived.httpClient.(*http.Client).Transport)
+
+	_, err = client.WithTimeout(-time.Second)
+	require.Error(t, err)
+	assert.EqualError(t, err, "invalid timeout -1s, it must not be negative")
+}
+
+func TestClientWithHeaders(t *testing.T) {
+	var received []http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = append(received, r.Header.Clone())
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, time.Second)
+	require.NoError(t, err)
+
+	tenant := client.WithHeaders(http.Header{"x-tenant": {"a"}, "X-Team": {"payments"}})
+	other := tenant.WithHeaders(http.Header{"X-Tenant": {"b"}})
+
+	for _, c := range []*Client{client, tenant, other} {
+		_, err := c.Get(context.Background(), "/v1/organisation/accounts", nil)
+		require.NoError(t, err)
+	}
+
+	require.Len(t, received, 3)
+	assert.Empty(t, received[0].Get("X-Tenant"))
+	assert.Equal(t, "a", received[1].Get("X-Tenant"))
+	assert.Equal(t, "payments", received[1].Get("X-Team"))
+	assert.Equal(t, []string{"b"}, received[2].Values("X-Tenant"))
+	assert.Equal(t, "payments", received[2].Get("X-Team"))
+}
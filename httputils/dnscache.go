@@ -0,0 +1,151 @@
+package httputils
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+)
+
+// DNSCacheStats are the counters of the DNS cache, see WithDNSCache
+type DNSCacheStats struct {
+	Hits    uint64
+	Misses  uint64
+	Entries int
+}
+
+// HitRate returns the ratio of the lookups served from the cache, zero when there was no lookup
+func (stats DNSCacheStats) HitRate() float64 {
+	total := stats.Hits + stats.Misses
+	if total == 0 {
+		return 0
+	}
+
+	return float64(stats.Hits) / float64(total)
+}
+
+type dnsEntry struct {
+	addrs   []string
+	expires time.Time
+}
+
+// dnsCache caches the addresses of the hosts for the ttl given, the failed lookups are not cached
+type dnsCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	clock   Clock
+	lookup  func(ctx context.Context, host string) ([]string, error)
+	entries map[string]dnsEntry
+	hits    uint64
+	misses  uint64
+}
+
+func newDNSCache(ttl time.Duration) *dnsCache {
+	return &dnsCache{
+		ttl:     ttl,
+		clock:   SystemClock{},
+		lookup:  net.DefaultResolver.LookupHost,
+		entries: map[string]dnsEntry{},
+	}
+}
+
+// resolve returns the addresses of the host, the cached ones while they are fresh
+func (cache *dnsCache) resolve(ctx context.Context, host string) ([]string, error) {
+	cache.mu.Lock()
+	entry, ok := cache.entries[host]
+	if ok && cache.clock.Now().Before(entry.expires) {
+		cache.hits++
+		cache.mu.Unlock()
+		return entry.addrs, nil
+	}
+	cache.misses++
+	cache.mu.Unlock()
+
+	addrs, err := cache.lookup(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	cache.entries[host] = dnsEntry{addrs: addrs, expires: cache.clock.Now().Add(cache.ttl)}
+	return addrs, nil
+}
+
+// dialContext dials the address with the dial given once its host is resolved, the addresses are tried in order
+// until one connects, a host resolved to no address fails the dial
+func (cache *dnsCache) dialContext(dial func(ctx context.Context, network, addr string) (net.Conn, error)) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(addr)
+		if err != nil || net.ParseIP(host) != nil {
+			return dial(ctx, network, addr)
+		}
+
+		addrs, err := cache.resolve(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+		if len(addrs) == 0 {
+			return nil, fmt.Errorf("no addresses for host %s", host)
+		}
+
+		for _, resolved := range addrs {
+			var conn net.Conn
+			conn, err = dial(ctx, network, net.JoinHostPort(resolved, port))
+			if err == nil {
+				return conn, nil
+			}
+		}
+
+		return nil, err
+	}
+}
+
+func (cache *dnsCache) flush() {
+	if cache == nil {
+		return
+	}
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	cache.entries = map[string]dnsEntry{}
+}
+
+func (cache *dnsCache) stats() DNSCacheStats {
+	if cache == nil {
+		return DNSCacheStats{}
+	}
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	return DNSCacheStats{Hits: cache.hits, Misses: cache.misses, Entries: len(cache.entries)}
+}
+
+// WithDNSCache caches the addresses of the resolved hosts for the ttl given, overriding the ttl of the records, so the
+// high throughput deployments don't resolve the host on every new connection, the resolver of the dialer is used for
+// the lookups and the cache is bypassed when the connections are dialed by WithDialContext or WithUnixSocket
+func WithDNSCache(ttl time.Duration) Option {
+	return func(c *Client) error {
+		if ttl <= 0 {
+			return fmt.Errorf("invalid dns cache ttl %s, it must be positive", ttl)
+		}
+
+		c.dnsCache = newDNSCache(ttl)
+		return nil
+	}
+}
+
+// DNSCacheStats returns the counters of the DNS cache, such as to export its hit rate, they are zero without a cache
+func (c Client) DNSCacheStats() DNSCacheStats {
+	return c.dnsCache.stats()
+}
+
+// FlushDNSCache drops the cached addresses so the hosts are resolved again, such as on an incident moving form3 to
+// other addresses, the open connections are kept, see CloseIdleConnections
+func (c Client) FlushDNSCache() {
+	c.dnsCache.flush()
+}
@@ -0,0 +1,108 @@
+package httputils
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDNSCacheResolve(t *testing.T) {
+	clock := NewManualClock(time.Date(2021, 10, 15, 0, 0, 0, 0, time.UTC))
+	lookups := 0
+
+	cache := newDNSCache(time.Minute)
+	cache.clock = clock
+	cache.lookup = func(ctx context.Context, host string) ([]string, error) {
+		lookups++
+		if host == "unknown.form3.tech" {
+			return nil, errors.New("no such host")
+		}
+		return []string{"10.0.0.1"}, nil
+	}
+
+	addrs, err := cache.resolve(context.Background(), "api.form3.tech")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.1"}, addrs)
+
+	_, err = cache.resolve(context.Background(), "api.form3.tech")
+	require.NoError(t, err)
+	assert.Equal(t, 1, lookups)
+
+	clock.Advance(time.Minute)
+	_, err = cache.resolve(context.Background(), "api.form3.tech")
+	require.NoError(t, err)
+	assert.Equal(t, 2, lookups)
+
+	_, err = cache.resolve(context.Background(), "unknown.form3.tech")
+	require.Error(t, err)
+	_, err = cache.resolve(context.Background(), "unknown.form3.tech")
+	require.Error(t, err)
+	assert.Equal(t, 4, lookups)
+
+	stats := cache.stats()
+	assert.Equal(t, DNSCacheStats{Hits: 1, Misses: 4, Entries: 1}, stats)
+	assert.Equal(t, 0.2, stats.HitRate())
+
+	cache.flush()
+	_, err = cache.resolve(context.Background(), "api.form3.tech")
+	require.NoError(t, err)
+	assert.Equal(t, 5, lookups)
+}
+
+func TestDNSCacheDialContext(t *testing.T) {
+	cache := newDNSCache(time.Minute)
+	cache.lookup = func(ctx context.Context, host string) ([]string, error) {
+		return nil, nil
+	}
+
+	dials := 0
+	dial := cache.dialContext(func(ctx context.Context, network, addr string) (net.Conn, error) {
+		dials++
+		return nil, errors.New("unexpected dial")
+	})
+
+	conn, err := dial(context.Background(), "tcp", "api.form3.tech:443")
+	require.Error(t, err)
+	assert.EqualError(t, err, "no addresses for host api.form3.tech")
+	assert.Nil(t, conn)
+	assert.Equal(t, 0, dials)
+}
+
+func TestClientWithDNSCache(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(strings.Replace(server.URL, "127.0.0.1", "localhost", 1), time.Second,
+		WithDNSCache(time.Minute),
+		WithDisableKeepAlives(true),
+	)
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		_, err = client.Get(context.Background(), "/v1/organisation/accounts", nil)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, DNSCacheStats{Hits: 2, Misses: 1, Entries: 1}, client.DNSCacheStats())
+
+	client.FlushDNSCache()
+	assert.Equal(t, 0, client.DNSCacheStats().Entries)
+
+	_, err = NewClient("https://api.form3.tech", time.Second, WithDNSCache(0))
+	require.Error(t, err)
+	assert.EqualError(t, err, "invalid dns cache ttl 0s, it must be positive; invalid option")
+}
+
+func TestDNSCacheStatsHitRate(t *testing.T) {
+	assert.Equal(t, float64(0), DNSCacheStats{}.HitRate())
+	assert.Equal(t, 0.75, DNSCacheStats{Hits: 3, Misses: 1}.HitRate())
+}
@@ -0,0 +1,155 @@
+package httputils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sync"
+)
+
+// baseURIs holds the base uris the requests are sent to, the primary one first and then the failover ones, it is
+// shared by the derived clients and can be switched at runtime
+type baseURIs struct {
+	mu      sync.RWMutex
+	uris    []url.URL
+	current int
+}
+
+func newBaseURIs(primary url.URL) *baseURIs {
+	return &baseURIs{uris: []url.URL{primary}}
+}
+
+// parseBaseURI parses a base uri keeping only its scheme and its host
+func parseBaseURI(baseURI string) (url.URL, error) {
+	parsed, err := url.ParseRequestURI(baseURI)
+	if err != nil {
+		return url.URL{}, err
+	}
+
+	return url.URL{Scheme: parsed.Scheme, Host: parsed.Host}, nil
+}
+
+// get returns the base uri the requests are sent to
+func (b *baseURIs) get() url.URL {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return b.uris[b.current]
+}
+
+// list returns the base uris, the primary one first
+func (b *baseURIs) list() []url.URL {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return append([]url.URL(nil), b.uris...)
+}
+
+// index returns the position of the base uri, -1 when it is unknown, the lock must be held
+func (b *baseURIs) index(uri url.URL) int {
+	for i, known := range b.uris {
+		if known.Scheme == uri.Scheme && known.Host == uri.Host {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// add registers a failover base uri, a known one is ignored
+func (b *baseURIs) add(uri url.URL) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.index(uri) < 0 {
+		b.uris = append(b.uris, uri)
+	}
+}
+
+// set switches the requests to the base uri, registering it when it is unknown
+func (b *baseURIs) set(uri url.URL) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	i := b.index(uri)
+	if i < 0 {
+		b.uris = append(b.uris, uri)
+		i = len(b.uris) - 1
+	}
+	b.current = i
+}
+
+// fail switches the requests to the next base uri when the one failing is still the current one, so the concurrent
+// failures of the same base uri switch it once
+func (b *baseURIs) fail(uri *url.URL) {
+	if b == nil || uri == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(b.uris) > 1 && b.index(*uri) == b.current {
+		b.current = (b.current + 1) % len(b.uris)
+	}
+}
+
+// WithFailoverBaseURIs registers the base uris the requests fail over to, such as the secondary region of form3, the
+// client switches to the next one when the current one is unreachable, see SelectHealthyBaseURI to switch back
+func WithFailoverBaseURIs(uris ...string) Option {
+	return func(c *Client) error {
+		if len(uris) == 0 {
+			return errors.New("invalid failover base uris, they must not be empty")
+		}
+
+		for _, uri := range uris {
+			parsed, err := parseBaseURI(uri)
+			if err != nil {
+				return fmt.Errorf("%w; invalid failover base uri", err)
+			}
+			c.baseURIs.add(parsed)
+		}
+
+		return nil
+	}
+}
+
+// BaseURI returns the base uri the requests are sent to
+func (c Client) BaseURI() string {
+	uri := c.baseURIs.get()
+	return uri.String()
+}
+
+// SetBaseURI switches the requests to the base uri at runtime without recreating the client, the requests in flight
+// complete on the previous one, the derived clients share the base uri so they are switched too
+func (c Client) SetBaseURI(baseURI string) error {
+	parsed, err := parseBaseURI(baseURI)
+	if err != nil {
+		return fmt.Errorf("%w; invalid base uri", err)
+	}
+
+	c.baseURIs.set(parsed)
+	return nil
+}
+
+// SelectHealthyBaseURI checks the health of the base uris, the primary one first, and switches the requests to the
+// first one which is up, so it fails back to the primary one once it recovers, it is meant to be called periodically
+// or on an incident, the current base uri is kept when none of them is up and the error is the one of the last checked
+func (c Client) SelectHealthyBaseURI(ctx context.Context) (string, error) {
+	var err error
+	for _, uri := range c.baseURIs.list() {
+		probe := c
+		probe.baseURIs = newBaseURIs(uri)
+
+		if err = probe.Ping(ctx); err != nil {
+			err = fmt.Errorf("%w; base uri %s is not healthy", err, uri.String())
+			continue
+		}
+
+		c.baseURIs.set(uri)
+		return uri.String(), nil
+	}
+
+	return "", fmt.Errorf("%w; unable to select a healthy base uri", err)
+}
@@ -0,0 +1,140 @@
+package httputils
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRegionServer(t *testing.T, health string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/health" {
+			_, _ = w.Write([]byte(`{"status": "` + health + `"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestWithFailoverBaseURIs(t *testing.T) {
+	tests := []struct {
+		name       string
+		uris       []string
+		wantErrMsg string
+	}{
+		{
+			name:       "Failed to create the client without failover base uris",
+			wantErrMsg: "invalid failover base uris, they must not be empty; invalid option",
+		},
+		{
+			name:       "Failed to create the client with an invalid failover base uri",
+			uris:       []string{"not-valid-url"},
+			wantErrMsg: `parse "not-valid-url": invalid URI for request; invalid failover base uri; invalid option`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewClient("https://api.form3.tech", time.Second, WithFailoverBaseURIs(tt.uris...))
+
+			require.Error(t, err)
+			assert.EqualError(t, err, tt.wantErrMsg)
+		})
+	}
+}
+
+func TestClientFailsOverWhenTheBaseURIIsUnreachable(t *testing.T) {
+	primary := newRegionServer(t, "up")
+	primary.Close()
+	secondary := newRegionServer(t, "up")
+
+	client, err := NewClient(primary.URL, time.Second, WithFailoverBaseURIs(secondary.URL))
+	require.NoError(t, err)
+
+	_, err = client.Get(context.Background(), "/v1/organisation/accounts", nil)
+	require.Error(t, err)
+	assert.Equal(t, secondary.URL, client.BaseURI())
+
+	_, err = client.Get(context.Background(), "/v1/organisation/accounts", nil)
+	require.NoError(t, err)
+}
+
+func TestClientSetBaseURI(t *testing.T) {
+	primary := newRegionServer(t, "up")
+	secondary := newRegionServer(t, "up")
+
+	client, err := NewClient(primary.URL, time.Second)
+	require.NoError(t, err)
+	derived := client.WithHeaders(http.Header{"X-Source": {"test"}})
+
+	require.NoError(t, client.SetBaseURI(secondary.URL+"/ignored/path"))
+	assert.Equal(t, secondary.URL, client.BaseURI())
+	assert.Equal(t, secondary.URL, derived.BaseURI())
+
+	err = client.SetBaseURI("not-valid-url")
+	require.Error(t, err)
+	assert.EqualError(t, err, `parse "not-valid-url": invalid URI for request; invalid base uri`)
+	assert.Equal(t, secondary.URL, client.BaseURI())
+}
+
+func TestClientSelectHealthyBaseURI(t *testing.T) {
+	tests := []struct {
+		name       string
+		primary    string
+		secondary  string
+		wantIndex  int
+		wantErr    bool
+		wantErrMsg string
+	}{
+		{
+			name:      "Successfully selects the primary base uri when it is up",
+			primary:   "up",
+			secondary: "up",
+			wantIndex: 0,
+		},
+		{
+			name:      "Successfully selects the secondary base uri when the primary one is down",
+			primary:   "down",
+			secondary: "up",
+			wantIndex: 1,
+		},
+		{
+			name:       "Failed to select a base uri when none is up",
+			primary:    "down",
+			secondary:  "down",
+			wantErr:    true,
+			wantErrMsg: `form3 reported the status "down"; unable to ping; base uri %s is not healthy; unable to select a healthy base uri`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			servers := []*httptest.Server{newRegionServer(t, tt.primary), newRegionServer(t, tt.secondary)}
+
+			client, err := NewClient(servers[0].URL, time.Second, WithFailoverBaseURIs(servers[1].URL))
+			require.NoError(t, err)
+			require.NoError(t, client.SetBaseURI(servers[1].URL))
+
+			selected, err := client.SelectHealthyBaseURI(context.Background())
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.EqualError(t, err, fmt.Sprintf(tt.wantErrMsg, servers[1].URL))
+				assert.Equal(t, servers[1].URL, client.BaseURI())
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, servers[tt.wantIndex].URL, selected)
+			assert.Equal(t, servers[tt.wantIndex].URL, client.BaseURI())
+		})
+	}
+}
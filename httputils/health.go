@@ -0,0 +1,52 @@
+package httputils
+
+import (
+	"context"
+	"fmt"
+)
+
+const healthPath = "/health"
+
+// HealthStatus is the status reported by the form3 health endpoint
+type HealthStatus string
+
+const (
+	// HealthUp means form3 is reachable and serving requests
+	HealthUp HealthStatus = "up"
+	// HealthDown means form3 is unreachable or reported itself as unhealthy
+	HealthDown HealthStatus = "down"
+)
+
+type healthResponse struct {
+	Status HealthStatus `json:"status"`
+}
+
+// HealthCheck calls the form3 health endpoint and returns the reported status, an unreachable api or an unexpected
+// response is reported as HealthDown along with the error
+func (c Client) HealthCheck(ctx context.Context) (HealthStatus, error) {
+	respBody, err := c.Get(ctx, c.apiVersion.Path(healthPath), nil)
+	if err != nil {
+		return HealthDown, fmt.Errorf("%w; unable to check health", err)
+	}
+
+	var health healthResponse
+	if err := c.respUnmarshaller(respBody, &health); err != nil {
+		return HealthDown, fmt.Errorf("%w; failed to unmarshal health response", err)
+	}
+
+	return health.Status, nil
+}
+
+// Ping tells if form3 is reachable and healthy, it is meant for the readiness probes of the services using the client
+func (c Client) Ping(ctx context.Context) error {
+	status, err := c.HealthCheck(ctx)
+	if err != nil {
+		return err
+	}
+
+	if status != HealthUp {
+		return fmt.Errorf("form3 reported the status %q; unable to ping", status)
+	}
+
+	return nil
+}
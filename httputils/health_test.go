@@ -0,0 +1,106 @@
+package httputils
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientHealthCheck(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		body       string
+		wantStatus HealthStatus
+		wantErr    bool
+		wantErrMsg string
+	}{
+		{
+			name:       "Successfully checks a healthy api",
+			status:     http.StatusOK,
+			body:       `{"status": "up"}`,
+			wantStatus: HealthUp,
+		},
+		{
+			name:       "Successfully checks an unhealthy api",
+			status:     http.StatusOK,
+			body:       `{"status": "down"}`,
+			wantStatus: HealthDown,
+			wantErr:    true,
+			wantErrMsg: `form3 reported the status "down"; unable to ping`,
+		},
+		{
+			name:       "Failed to check the health with an unexpected status code",
+			status:     http.StatusServiceUnavailable,
+			wantStatus: HealthDown,
+			wantErr:    true,
+			wantErrMsg: "GET /v1/health: unexpected status code 503; unable to check health",
+		},
+		{
+			name:       "Failed to check the health with an invalid response",
+			status:     http.StatusOK,
+			body:       `{`,
+			wantStatus: HealthDown,
+			wantErr:    true,
+			wantErrMsg: "unexpected end of JSON input; failed to unmarshal health response",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/v1/health", r.URL.Path)
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			client, err := NewClient(server.URL, time.Second)
+			require.NoError(t, err)
+
+			status, _ := client.HealthCheck(context.Background())
+			assert.Equal(t, tt.wantStatus, status)
+
+			err = client.Ping(context.Background())
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.wantErrMsg)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestClientHealthCheckUnreachable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close()
+
+	client, err := NewClient(server.URL, time.Second)
+	require.NoError(t, err)
+
+	status, err := client.HealthCheck(context.Background())
+	require.Error(t, err)
+	assert.Equal(t, HealthDown, status)
+}
+
+func TestClientHealthCheckWithAPIVersion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/gateway/v2/health", r.URL.Path)
+		_, _ = w.Write([]byte(`{"status": "up"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, time.Second, WithAPIVersion("gateway/v2"))
+	require.NoError(t, err)
+	assert.Equal(t, APIVersion("gateway/v2"), client.APIVersion())
+
+	status, err := client.HealthCheck(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, HealthUp, status)
+}
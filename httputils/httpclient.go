@@ -2,10 +2,11 @@ package httputils
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
-	"io/ioutil"
+	"net"
 	"net/http"
 	"net/url"
 	"time"
@@ -13,152 +14,387 @@ import (
 
 type httpClient interface {
 	Do(req *http.Request) (*http.Response, error)
+	CloseIdleConnections()
 }
 
 // Client is the representation of the client to perform some http operations
 type Client struct {
-	httpClient       httpClient
-	baseURI          url.URL
-	bodyReader       bodyReader
-	respUnmarshaller respUnmarshaller
-	reqCreator       reqCreator
+	httpClient        httpClient
+	transport         *http.Transport
+	timeout           time.Duration
+	baseURIs          *baseURIs
+	bodyReader        bodyReader
+	respUnmarshaller  respUnmarshaller
+	reqCreator        reqCreator
+	hooks             hooks
+	roundTripperWraps []func(http.RoundTripper) http.RoundTripper
+	header            http.Header
+	authRefresh       func(ctx context.Context) error
+	successStatuses   map[string]map[int]bool
+	checkRedirect     func(request *http.Request, via []*http.Request) error
+	dialer            *net.Dialer
+	dialContext       func(ctx context.Context, network, addr string) (net.Conn, error)
+	rateLimiter       *rateLimiter
+	closer            *closer
+	credentials       *credentialStore
+	apiVersion        APIVersion
+	dnsCache          *dnsCache
 }
 
 type bodyReader func(io.Reader) ([]byte, error)
 type respUnmarshaller func([]byte, interface{}) error
-type reqCreator func(method, url string, body io.Reader) (*http.Request, error)
+type reqCreator func(ctx context.Context, method, url string, body io.Reader) (*http.Request, error)
 
 // NewClient creates a new http client with the base URI and the timeout for the requests made by this client
-func NewClient(baseURI string, timeout int) (*Client, error) {
-	parsedBaseURI, err := url.ParseRequestURI(baseURI)
+func NewClient(baseURI string, timeout time.Duration, opts ...Option) (*Client, error) {
+	parsedBaseURI, err := parseBaseURI(baseURI)
 	if err != nil {
 		return nil, fmt.Errorf("%w; invalid base uri", err)
 	}
 
-	client := &http.Client{
-		Timeout: time.Duration(timeout) * time.Second,
+	if timeout < 0 {
+		return nil, fmt.Errorf("invalid timeout %s, it must not be negative", timeout)
 	}
 
-	return &Client{
-		httpClient: client,
-		baseURI: url.URL{
-			Scheme: parsedBaseURI.Scheme,
-			Host:   parsedBaseURI.Host,
+	client := &Client{
+		transport: http.DefaultTransport.(*http.Transport).Clone(),
+		dialer: &net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
 		},
-		bodyReader:       ioutil.ReadAll,
+		timeout:          timeout,
+		rateLimiter:      newRateLimiter(),
+		closer:           newCloser(),
+		credentials:      newCredentialStore(),
+		apiVersion:       DefaultAPIVersion,
+		baseURIs:         newBaseURIs(parsedBaseURI),
+		bodyReader:       readAllPooled,
 		respUnmarshaller: json.Unmarshal,
-		reqCreator:       http.NewRequest,
-	}, nil
+		reqCreator:       http.NewRequestWithContext,
+	}
+
+	for _, opt := range opts {
+		if err := opt(client); err != nil {
+			return nil, fmt.Errorf("%w; invalid option", err)
+		}
+	}
+
+	client.transport.DialContext = client.dialer.DialContext
+	if client.dnsCache != nil {
+		client.dnsCache.clock = client.rateLimiter.clock
+		if client.dialer.Resolver != nil {
+			client.dnsCache.lookup = client.dialer.Resolver.LookupHost
+		}
+		client.transport.DialContext = client.dnsCache.dialContext(client.dialer.DialContext)
+	}
+	if client.dialContext != nil {
+		client.transport.DialContext = client.dialContext
+	}
+
+	var roundTripper http.RoundTripper = client.transport
+	for _, wrap := range client.roundTripperWraps {
+		roundTripper = wrap(roundTripper)
+	}
+
+	client.httpClient = &http.Client{
+		Timeout:       client.timeout,
+		Transport:     roundTripper,
+		CheckRedirect: client.checkRedirect,
+	}
+
+	return client, nil
+}
+
+// NewClientWithSeconds creates a new http client with the timeout in seconds.
+//
+// Deprecated: use NewClient with a time.Duration timeout instead.
+func NewClientWithSeconds(baseURI string, timeout int, opts ...Option) (*Client, error) {
+	return NewClient(baseURI, time.Duration(timeout)*time.Second, opts...)
+}
+
+// CloseIdleConnections closes the idle keep-alive connections kept by the client, useful on graceful shutdown
+func (c Client) CloseIdleConnections() {
+	c.httpClient.CloseIdleConnections()
+}
+
+// Post data to an API endpoint with given path, body content and additional request header
+func (c Client) Post(ctx context.Context, resourcePath string, body []byte, header http.Header) ([]byte, error) {
+	response, err := c.PostResponse(ctx, resourcePath, body, header)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Body, nil
 }
 
-// Post data to an API endpoint with given path and body content
-func (c Client) Post(resourcePath string, body []byte) ([]byte, error) {
-	requestURL := c.baseURI.ResolveReference(&url.URL{Path: resourcePath})
-	request, err := c.reqCreator(http.MethodPost, requestURL.String(), bytes.NewBuffer(body))
+// PostResponse posts data to an API endpoint with given path, body content and additional request header returning
+// the response envelope, it is returned along with the error when the API answered with a failure status
+func (c Client) PostResponse(ctx context.Context, resourcePath string, body []byte, header http.Header) (_ *Response, err error) {
+	defer func() {
+		err = newRequestError(http.MethodPost, resourcePath, err)
+	}()
+
+	baseURI := c.baseURIs.get()
+	requestURL := baseURI.ResolveReference(&url.URL{Path: resourcePath})
+	request, err := c.reqCreator(ctx, http.MethodPost, requestURL.String(), bytes.NewBuffer(body))
 	if err != nil {
 		return nil, err
 	}
+	addHeader(request, c.header)
+	addHeader(request, header)
 
-	response, err := c.httpClient.Do(request)
+	started := time.Now()
+	request, tracker := c.trackPhases(request)
+	response, err := c.send(request)
 	if err != nil {
+		c.reportAbort(tracker, err)
 		return nil, fmt.Errorf("%w; failed to post data", err)
 	}
-	defer response.Body.Close()
+	defer closeBody(response)
 
-	respBody, err := c.bodyReader(response.Body)
+	respBody, err := c.readResponse(response)
 	if err != nil {
+		c.reportAbort(tracker, err)
 		return nil, fmt.Errorf("%w; failed to read response body", err)
 	}
+	result := newResponse(request, response, respBody, started)
+
+	if c.isSuccess(http.MethodPost, response.StatusCode) {
+		return result, nil
+	}
 
 	switch response.StatusCode {
-	case http.StatusCreated:
-		return respBody, nil
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return result, c.newAuthError(response, respBody)
 	case http.StatusConflict, http.StatusBadRequest:
-		var errRes ResponseError
-		if err := c.respUnmarshaller(respBody, &errRes); err != nil {
-			return nil, err
-		}
+		return result, c.responseError(response.StatusCode, respBody)
+	default:
+		return result, &StatusError{StatusCode: response.StatusCode}
+	}
+}
+
+// Patch data of an API resource with given path, body content and additional request header
+func (c Client) Patch(ctx context.Context, resourcePath string, body []byte, header http.Header) ([]byte, error) {
+	response, err := c.PatchResponse(ctx, resourcePath, body, header)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Body, nil
+}
+
+// PatchResponse patches data of an API resource with given path, body content and additional request header
+// returning the response envelope, it is returned along with the error when the API answered with a failure status
+func (c Client) PatchResponse(ctx context.Context, resourcePath string, body []byte, header http.Header) (_ *Response, err error) {
+	defer func() {
+		err = newRequestError(http.MethodPatch, resourcePath, err)
+	}()
 
-		errRes.StatusCode = response.StatusCode
-		return nil, &errRes
+	baseURI := c.baseURIs.get()
+	requestURL := baseURI.ResolveReference(&url.URL{Path: resourcePath})
+	request, err := c.reqCreator(ctx, http.MethodPatch, requestURL.String(), bytes.NewBuffer(body))
+	if err != nil {
+		return nil, err
+	}
+	addHeader(request, c.header)
+	addHeader(request, header)
+
+	started := time.Now()
+	request, tracker := c.trackPhases(request)
+	response, err := c.send(request)
+	if err != nil {
+		c.reportAbort(tracker, err)
+		return nil, fmt.Errorf("%w; failed to patch data", err)
+	}
+	defer closeBody(response)
+
+	respBody, err := c.readResponse(response)
+	if err != nil {
+		c.reportAbort(tracker, err)
+		return nil, fmt.Errorf("%w; failed to read response body", err)
+	}
+	result := newResponse(request, response, respBody, started)
+
+	if c.isSuccess(http.MethodPatch, response.StatusCode) {
+		return result, nil
+	}
+
+	switch response.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return result, c.newAuthError(response, respBody)
+	case http.StatusConflict, http.StatusNotFound, http.StatusBadRequest:
+		return result, c.responseError(response.StatusCode, respBody)
 	default:
-		return nil, fmt.Errorf("unexpected status code %d", response.StatusCode)
+		return result, &StatusError{StatusCode: response.StatusCode}
 	}
 }
 
-// Get data from an API endpoint with given path
-func (c Client) Get(resourcePath string) ([]byte, error) {
-	requestURL := c.baseURI.ResolveReference(&url.URL{Path: resourcePath})
-	request, err := c.reqCreator(http.MethodGet, requestURL.String(), nil)
+// Get data from an API endpoint with given path and query string
+func (c Client) Get(ctx context.Context, resourcePath string, query map[string]string) ([]byte, error) {
+	response, err := c.GetResponse(ctx, resourcePath, query)
 	if err != nil {
 		return nil, err
 	}
 
-	response, err := c.httpClient.Do(request)
+	return response.Body, nil
+}
+
+// GetResponse gets data from an API endpoint with given path and query string returning the response envelope, it
+// is returned along with the error when the API answered with a failure status
+func (c Client) GetResponse(ctx context.Context, resourcePath string, query map[string]string) (_ *Response, err error) {
+	defer func() {
+		err = newRequestError(http.MethodGet, resourcePath, err)
+	}()
+
+	request, err := c.reqCreator(ctx, http.MethodGet, c.requestURL(resourcePath, query), nil)
 	if err != nil {
 		return nil, err
 	}
-	defer response.Body.Close()
+	addHeader(request, c.header)
 
-	respBody, err := c.bodyReader(response.Body)
+	started := time.Now()
+	request, tracker := c.trackPhases(request)
+	response, err := c.send(request)
 	if err != nil {
+		c.reportAbort(tracker, err)
+		return nil, err
+	}
+	defer closeBody(response)
+
+	respBody, err := c.readResponse(response)
+	if err != nil {
+		c.reportAbort(tracker, err)
 		return nil, fmt.Errorf("%w; failed to read response body", err)
 	}
+	result := newResponse(request, response, respBody, started)
+
+	if c.isSuccess(http.MethodGet, response.StatusCode) {
+		return result, nil
+	}
 
 	switch response.StatusCode {
-	case http.StatusOK:
-		return respBody, nil
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return result, c.newAuthError(response, respBody)
 	case http.StatusNotFound, http.StatusBadRequest:
-		var errRes ResponseError
-		if err := c.respUnmarshaller(respBody, &errRes); err != nil {
-			return nil, err
-		}
+		return result, c.responseError(response.StatusCode, respBody)
+	default:
+		return result, &StatusError{StatusCode: response.StatusCode}
+	}
+}
 
-		errRes.StatusCode = response.StatusCode
-		return nil, &errRes
+// Head checks an API endpoint with given path and query string without transferring the body, it returns the header
+// of the response, such as to tell if a resource exists
+func (c Client) Head(ctx context.Context, resourcePath string, query map[string]string) (http.Header, error) {
+	response, err := c.HeadResponse(ctx, resourcePath, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Header, nil
+}
+
+// HeadResponse checks an API endpoint with given path and query string without transferring the body returning the
+// response envelope, it is returned along with the error when the API answered with a failure status
+func (c Client) HeadResponse(ctx context.Context, resourcePath string, query map[string]string) (_ *Response, err error) {
+	defer func() {
+		err = newRequestError(http.MethodHead, resourcePath, err)
+	}()
+
+	request, err := c.reqCreator(ctx, http.MethodHead, c.requestURL(resourcePath, query), nil)
+	if err != nil {
+		return nil, err
+	}
+	addHeader(request, c.header)
+
+	started := time.Now()
+	request, tracker := c.trackPhases(request)
+	response, err := c.send(request)
+	if err != nil {
+		c.reportAbort(tracker, err)
+		return nil, err
+	}
+	defer closeBody(response)
+	result := newResponse(request, response, nil, started)
+
+	if c.isSuccess(http.MethodHead, response.StatusCode) {
+		return result, nil
+	}
+
+	switch response.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return result, c.newAuthError(response, nil)
+	case http.StatusNotFound, http.StatusBadRequest:
+		return result, c.responseError(response.StatusCode, nil)
 	default:
-		return nil, fmt.Errorf("unexpected status code %d", response.StatusCode)
+		return result, &StatusError{StatusCode: response.StatusCode}
 	}
 }
 
 // Delete data from an API endpoint with given path and query string
-func (c Client) Delete(resourcePath string, query map[string]string) error {
-	rawQuery := url.Values{}
-	for key, value := range query {
-		rawQuery.Add(key, value)
+func (c Client) Delete(ctx context.Context, resourcePath string, query map[string]string) error {
+	_, err := c.DeleteResponse(ctx, resourcePath, query)
+	return err
+}
+
+// DeleteResponse deletes data from an API endpoint with given path and query string returning the response envelope,
+// it is returned along with the error when the API answered with a failure status
+func (c Client) DeleteResponse(ctx context.Context, resourcePath string, query map[string]string) (_ *Response, err error) {
+	defer func() {
+		err = newRequestError(http.MethodDelete, resourcePath, err)
+	}()
+
+	request, err := c.reqCreator(ctx, http.MethodDelete, c.requestURL(resourcePath, query), nil)
+	if err != nil {
+		return nil, err
 	}
-	requestURL := c.baseURI.ResolveReference(&url.URL{Path: resourcePath, RawQuery: rawQuery.Encode()})
-	request, err := c.reqCreator(http.MethodDelete, requestURL.String(), nil)
+	addHeader(request, c.header)
+
+	started := time.Now()
+	request, tracker := c.trackPhases(request)
+	response, err := c.send(request)
 	if err != nil {
-		return err
+		c.reportAbort(tracker, err)
+		return nil, err
 	}
+	defer closeBody(response)
 
-	response, err := c.httpClient.Do(request)
+	if c.isSuccess(http.MethodDelete, response.StatusCode) {
+		return newResponse(request, response, nil, started), nil
+	}
+
+	respBody, err := c.readResponse(response)
 	if err != nil {
-		return err
+		c.reportAbort(tracker, err)
+		return nil, fmt.Errorf("%w; failed to read response body", err)
 	}
+	result := newResponse(request, response, respBody, started)
 
 	switch response.StatusCode {
-	case http.StatusNoContent:
-		return nil
-	case http.StatusBadRequest:
-		respBody, err := c.bodyReader(response.Body)
-		if err != nil {
-			return fmt.Errorf("%w; failed to read response body", err)
-		}
-		var errRes ResponseError
-		if err := c.respUnmarshaller(respBody, &errRes); err != nil {
-			return err
-		}
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return result, c.newAuthError(response, respBody)
+	case http.StatusConflict, http.StatusNotFound, http.StatusBadRequest:
+		return result, c.responseError(response.StatusCode, respBody)
+	default:
+		return result, &StatusError{StatusCode: response.StatusCode}
+	}
+}
 
-		errRes.StatusCode = response.StatusCode
-		return &errRes
-	case http.StatusNotFound:
-		return &ResponseError{
-			ErrorMessage: "not found",
-			StatusCode:   404,
+// requestURL returns the url of the resource path with the query string
+func (c Client) requestURL(resourcePath string, query map[string]string) string {
+	rawQuery := url.Values{}
+	for key, value := range query {
+		rawQuery.Add(key, value)
+	}
+
+	baseURI := c.baseURIs.get()
+	return baseURI.ResolveReference(&url.URL{Path: resourcePath, RawQuery: rawQuery.Encode()}).String()
+}
+
+// addHeader adds the additional header values to the request
+func addHeader(request *http.Request, header http.Header) {
+	for key, values := range header {
+		for _, value := range values {
+			request.Header.Add(key, value)
 		}
-	default:
-		return fmt.Errorf("unexpected status code %d", response.StatusCode)
 	}
 }
@@ -2,6 +2,7 @@ package httputils
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"io"
@@ -9,6 +10,7 @@ import (
 	"net/http"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -19,7 +21,7 @@ func TestClient(t *testing.T) {
 	tests := []struct {
 		name    string
 		baseURI string
-		timeout int
+		timeout time.Duration
 		wantErr bool
 	}{
 		{
@@ -27,10 +29,16 @@ func TestClient(t *testing.T) {
 			baseURI: "not-valid-url",
 			wantErr: true,
 		},
+		{
+			name:    "Failed to create client with a negative timeout",
+			baseURI: "https://valid-url.com",
+			timeout: -time.Second,
+			wantErr: true,
+		},
 		{
 			name:    "Successfully creates new client",
 			baseURI: "https://valid-url.com",
-			timeout: 15,
+			timeout: 15 * time.Second,
 			wantErr: false,
 		},
 	}
@@ -50,13 +58,20 @@ func TestClient(t *testing.T) {
 	}
 }
 
+func TestClientWithSeconds(t *testing.T) {
+	got, err := NewClientWithSeconds("https://valid-url.com", 15)
+	require.NoError(t, err)
+
+	assert.Equal(t, 15*time.Second, got.timeout)
+}
+
 func TestClientPost(t *testing.T) {
 	tests := []struct {
 		name             string
 		httpClientSetup  func(*mockHttpClient)
 		bodyReader       func(io.Reader) ([]byte, error)
 		respUnmarshaller func([]byte, interface{}) error
-		reqCreator       func(method, url string, body io.Reader) (*http.Request, error)
+		reqCreator       func(ctx context.Context, method, url string, body io.Reader) (*http.Request, error)
 		want             []byte
 		wantErr          bool
 		wantErrMsg       string
@@ -64,7 +79,9 @@ func TestClientPost(t *testing.T) {
 		{
 			name: "Successfully perform the post request and receive 201 status code with a valid json data in body",
 			httpClientSetup: func(client *mockHttpClient) {
-				client.On("Do", mock.Anything).Return(
+				client.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+					return req.Header.Get("X-Batch-Id") == "batch-1"
+				})).Return(
 					&http.Response{
 						StatusCode: 201,
 						Body: ioutil.NopCloser(
@@ -142,7 +159,7 @@ func TestClientPost(t *testing.T) {
 		},
 		{
 			name: "Failed to create the request",
-			reqCreator: func(string, string, io.Reader) (*http.Request, error) {
+			reqCreator: func(context.Context, string, string, io.Reader) (*http.Request, error) {
 				return nil, errors.New("failed to create the request")
 			},
 			wantErr:    true,
@@ -200,10 +217,168 @@ func TestClientPost(t *testing.T) {
 			}
 			client := createFakeHttpClient(httpClientMock, tt.bodyReader, tt.respUnmarshaller, tt.reqCreator)
 
-			got, err := client.Post("/a-valid-path", []byte("something"))
+			got, err := client.Post(context.Background(), "/a-valid-path", []byte("something"), http.Header{"X-Batch-Id": []string{"batch-1"}})
 			if tt.wantErr {
 				require.Error(t, err)
-				assert.EqualError(t, err, tt.wantErrMsg)
+				assert.EqualError(t, err, "POST /a-valid-path: "+tt.wantErrMsg)
+			} else {
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.want, got)
+			mock.AssertExpectationsForObjects(t, httpClientMock)
+		})
+	}
+}
+
+func TestClientPatch(t *testing.T) {
+	tests := []struct {
+		name             string
+		httpClientSetup  func(*mockHttpClient)
+		bodyReader       func(io.Reader) ([]byte, error)
+		respUnmarshaller func([]byte, interface{}) error
+		reqCreator       func(ctx context.Context, method, url string, body io.Reader) (*http.Request, error)
+		want             []byte
+		wantErr          bool
+		wantErrMsg       string
+	}{
+		{
+			name: "Successfully perform the patch request and receive 200 status code with a valid json data in body",
+			httpClientSetup: func(client *mockHttpClient) {
+				client.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+					return req.Method == http.MethodPatch
+				})).Return(
+					&http.Response{
+						StatusCode: 200,
+						Body: ioutil.NopCloser(
+							bytes.NewBufferString(
+								`{"data":"some valid json data"}`,
+							),
+						),
+					},
+					nil,
+				)
+			},
+			want:    []byte(`{"data":"some valid json data"}`),
+			wantErr: false,
+		},
+		{
+			name: "Failed to perform the patch request and receive 409 status code with a valid json data in body",
+			httpClientSetup: func(client *mockHttpClient) {
+				client.On("Do", mock.Anything).Return(
+					&http.Response{
+						StatusCode: 409,
+						Body: ioutil.NopCloser(
+							bytes.NewBufferString(
+								`{"error_message":"invalid version"}`,
+							),
+						),
+					},
+					nil,
+				)
+			},
+			wantErr:    true,
+			wantErrMsg: "api failure with status code 409 and message: invalid version",
+		},
+		{
+			name: "Failed to perform the patch request and receive 404 status code with a valid json data in body",
+			httpClientSetup: func(client *mockHttpClient) {
+				client.On("Do", mock.Anything).Return(
+					&http.Response{
+						StatusCode: 404,
+						Body: ioutil.NopCloser(
+							bytes.NewBufferString(
+								`{"error_message":"record xxx-xxx does not exist"}`,
+							),
+						),
+					},
+					nil,
+				)
+			},
+			wantErr:    true,
+			wantErrMsg: "api failure with status code 404 and message: record xxx-xxx does not exist",
+		},
+		{
+			name: "Failed to perform the patch request and receive 500 status code with an empty body",
+			httpClientSetup: func(client *mockHttpClient) {
+				client.On("Do", mock.Anything).Return(
+					&http.Response{
+						StatusCode: 500,
+						Body:       ioutil.NopCloser(bytes.NewBufferString("")),
+					},
+					nil,
+				)
+			},
+			wantErr:    true,
+			wantErrMsg: "unexpected status code 500",
+		},
+		{
+			name: "Failed to perform the request failing the http client",
+			httpClientSetup: func(client *mockHttpClient) {
+				client.On("Do", mock.Anything).Return(
+					nil,
+					errors.New("failed to perform request"),
+				)
+			},
+			wantErr:    true,
+			wantErrMsg: "failed to perform request; failed to patch data",
+		},
+		{
+			name: "Failed to create the request",
+			reqCreator: func(context.Context, string, string, io.Reader) (*http.Request, error) {
+				return nil, errors.New("failed to create the request")
+			},
+			wantErr:    true,
+			wantErrMsg: "failed to create the request",
+		},
+		{
+			name: "Failed to read the response body",
+			httpClientSetup: func(client *mockHttpClient) {
+				client.On("Do", mock.Anything).Return(
+					&http.Response{
+						StatusCode: 200,
+						Body:       ioutil.NopCloser(bytes.NewBufferString(`{"data":"some valid data"}`)),
+					},
+					nil,
+				)
+			},
+			bodyReader: func(io.Reader) ([]byte, error) {
+				return nil, errors.New("failed to read body for some reason")
+			},
+			wantErr:    true,
+			wantErrMsg: "failed to read body for some reason; failed to read response body",
+		},
+		{
+			name: "Failed to convert the error response body",
+			httpClientSetup: func(client *mockHttpClient) {
+				client.On("Do", mock.Anything).Return(
+					&http.Response{
+						StatusCode: 409,
+						Body:       ioutil.NopCloser(bytes.NewBufferString(`{"error":"this is not the structure expected"}`)),
+					},
+					nil,
+				)
+			},
+			respUnmarshaller: func([]byte, interface{}) error {
+				return errors.New("failed to unmarshal")
+			},
+			wantErr:    true,
+			wantErrMsg: "failed to unmarshal",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpClientMock := &mockHttpClient{}
+			if tt.httpClientSetup != nil {
+				tt.httpClientSetup(httpClientMock)
+			}
+			client := createFakeHttpClient(httpClientMock, tt.bodyReader, tt.respUnmarshaller, tt.reqCreator)
+
+			got, err := client.Patch(context.Background(), "/a-valid-path", []byte("something"), nil)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.EqualError(t, err, "PATCH /a-valid-path: "+tt.wantErrMsg)
 			} else {
 				require.NoError(t, err)
 			}
@@ -220,7 +395,7 @@ func TestClientGet(t *testing.T) {
 		httpClientSetup  func(*mockHttpClient)
 		bodyReader       func(io.Reader) ([]byte, error)
 		respUnmarshaller func([]byte, interface{}) error
-		reqCreator       func(method, url string, body io.Reader) (*http.Request, error)
+		reqCreator       func(ctx context.Context, method, url string, body io.Reader) (*http.Request, error)
 		want             []byte
 		wantErr          bool
 		wantErrMsg       string
@@ -228,7 +403,9 @@ func TestClientGet(t *testing.T) {
 		{
 			name: "Successfully perform the get request and receive 200 status code with a valid json data in body",
 			httpClientSetup: func(client *mockHttpClient) {
-				client.On("Do", mock.Anything).Return(
+				client.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+					return req.URL.String() == "https://api.form3.tech/a-valid-path?filter%5Bcountry%5D=GB"
+				})).Return(
 					&http.Response{
 						StatusCode: 200,
 						Body: ioutil.NopCloser(
@@ -306,7 +483,7 @@ func TestClientGet(t *testing.T) {
 		},
 		{
 			name: "Failed to create the request",
-			reqCreator: func(method, url string, body io.Reader) (*http.Request, error) {
+			reqCreator: func(ctx context.Context, method, url string, body io.Reader) (*http.Request, error) {
 				return nil, errors.New("failed to create the request")
 			},
 			wantErr:    true,
@@ -365,10 +542,105 @@ func TestClientGet(t *testing.T) {
 
 			client := createFakeHttpClient(httpClientMock, tt.bodyReader, tt.respUnmarshaller, tt.reqCreator)
 
-			got, err := client.Get("/a-valid-path")
+			query := map[string]string{
+				"filter[country]": "GB",
+			}
+
+			got, err := client.Get(context.Background(), "/a-valid-path", query)
 			if tt.wantErr {
 				require.Error(t, err)
-				assert.EqualError(t, err, tt.wantErrMsg)
+				assert.EqualError(t, err, "GET /a-valid-path: "+tt.wantErrMsg)
+			} else {
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.want, got)
+			mock.AssertExpectationsForObjects(t, httpClientMock)
+		})
+	}
+}
+
+func TestClientHead(t *testing.T) {
+	tests := []struct {
+		name            string
+		httpClientSetup func(*mockHttpClient)
+		reqCreator      func(ctx context.Context, method, url string, body io.Reader) (*http.Request, error)
+		want            http.Header
+		wantErr         bool
+		wantErrMsg      string
+	}{
+		{
+			name: "Successfully perform the head request and receive 200 status code",
+			httpClientSetup: func(client *mockHttpClient) {
+				client.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+					return req.Method == http.MethodHead && req.URL.String() == "https://api.form3.tech/a-valid-path?filter%5Bcountry%5D=GB"
+				})).Return(
+					&http.Response{
+						StatusCode: 200,
+						Header:     http.Header{"Content-Length": []string{"512"}},
+						Body:       http.NoBody,
+					},
+					nil,
+				)
+			},
+			want:    http.Header{"Content-Length": []string{"512"}},
+			wantErr: false,
+		},
+		{
+			name: "Failed to perform the head request and receive 404 status code",
+			httpClientSetup: func(client *mockHttpClient) {
+				client.On("Do", mock.Anything).Return(&http.Response{StatusCode: 404, Body: http.NoBody}, nil)
+			},
+			wantErr:    true,
+			wantErrMsg: "api failure with status code 404 and message: not found",
+		},
+		{
+			name: "Failed to perform the head request and receive 403 status code",
+			httpClientSetup: func(client *mockHttpClient) {
+				client.On("Do", mock.Anything).Return(&http.Response{StatusCode: 403, Body: http.NoBody}, nil)
+			},
+			wantErr:    true,
+			wantErrMsg: "auth failure with status code 403: the credentials are not allowed to perform the operation, check the permissions of the user on the organisation",
+		},
+		{
+			name: "Failed to perform the head request and receive 500 status code",
+			httpClientSetup: func(client *mockHttpClient) {
+				client.On("Do", mock.Anything).Return(&http.Response{StatusCode: 500, Body: http.NoBody}, nil)
+			},
+			wantErr:    true,
+			wantErrMsg: "unexpected status code 500",
+		},
+		{
+			name: "Failed to perform the head request because the server is unreachable",
+			httpClientSetup: func(client *mockHttpClient) {
+				client.On("Do", mock.Anything).Return(nil, errors.New("connection refused"))
+			},
+			wantErr:    true,
+			wantErrMsg: "connection refused",
+		},
+		{
+			name: "Failed to create the head request",
+			reqCreator: func(ctx context.Context, method, url string, body io.Reader) (*http.Request, error) {
+				return nil, errors.New("invalid request")
+			},
+			wantErr:    true,
+			wantErrMsg: "invalid request",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpClientMock := &mockHttpClient{}
+			if tt.httpClientSetup != nil {
+				tt.httpClientSetup(httpClientMock)
+			}
+
+			client := createFakeHttpClient(httpClientMock, nil, nil, tt.reqCreator)
+
+			got, err := client.Head(context.Background(), "/a-valid-path", map[string]string{"filter[country]": "GB"})
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.EqualError(t, err, "HEAD /a-valid-path: "+tt.wantErrMsg)
 			} else {
 				require.NoError(t, err)
 			}
@@ -385,7 +657,7 @@ func TestClientDelete(t *testing.T) {
 		httpClientSetup  func(*mockHttpClient)
 		bodyReader       func(io.Reader) ([]byte, error)
 		respUnmarshaller func([]byte, interface{}) error
-		reqCreator       func(method, url string, body io.Reader) (*http.Request, error)
+		reqCreator       func(ctx context.Context, method, url string, body io.Reader) (*http.Request, error)
 		wantErr          bool
 		wantErrMsg       string
 	}{
@@ -418,6 +690,22 @@ func TestClientDelete(t *testing.T) {
 			wantErr:    true,
 			wantErrMsg: "api failure with status code 400 and message: invalid version number",
 		},
+		{
+			name: "Failed to perform the delete request and receive 409 status code with a valid json data in body",
+			httpClientSetup: func(client *mockHttpClient) {
+				client.On("Do", mock.Anything).Return(
+					&http.Response{
+						StatusCode: 409,
+						Body: ioutil.NopCloser(
+							bytes.NewBufferString(`{"error_message":"invalid version"}`),
+						),
+					},
+					nil,
+				)
+			},
+			wantErr:    true,
+			wantErrMsg: "api failure with status code 409 and message: invalid version",
+		},
 		{
 			name: "Failed to perform the delete request and receive 404 status code with an empty body",
 			httpClientSetup: func(client *mockHttpClient) {
@@ -461,7 +749,7 @@ func TestClientDelete(t *testing.T) {
 		},
 		{
 			name: "Failed to create the request",
-			reqCreator: func(string, string, io.Reader) (*http.Request, error) {
+			reqCreator: func(context.Context, string, string, io.Reader) (*http.Request, error) {
 				return nil, errors.New("failed to create the request")
 			},
 			wantErr:    true,
@@ -523,10 +811,10 @@ func TestClientDelete(t *testing.T) {
 				"version": "0",
 			}
 
-			err := client.Delete("/a-valid-path", query)
+			err := client.Delete(context.Background(), "/a-valid-path", query)
 			if tt.wantErr {
 				require.Error(t, err)
-				assert.EqualError(t, err, tt.wantErrMsg)
+				assert.EqualError(t, err, "DELETE /a-valid-path: "+tt.wantErrMsg)
 			} else {
 				require.NoError(t, err)
 			}
@@ -536,11 +824,21 @@ func TestClientDelete(t *testing.T) {
 	}
 }
 
+func TestClientCloseIdleConnections(t *testing.T) {
+	httpClientMock := &mockHttpClient{}
+	httpClientMock.On("CloseIdleConnections").Return()
+
+	client := createFakeHttpClient(httpClientMock, nil, nil, nil)
+	client.CloseIdleConnections()
+
+	mock.AssertExpectationsForObjects(t, httpClientMock)
+}
+
 func createFakeHttpClient(
 	mock *mockHttpClient,
 	bodyReader func(io.Reader) ([]byte, error),
 	respUnmarshaller func([]byte, interface{}) error,
-	reqCreator func(method, url string, body io.Reader) (*http.Request, error),
+	reqCreator func(ctx context.Context, method, url string, body io.Reader) (*http.Request, error),
 ) Client {
 	if bodyReader == nil {
 		bodyReader = ioutil.ReadAll
@@ -551,15 +849,15 @@ func createFakeHttpClient(
 	}
 
 	if reqCreator == nil {
-		reqCreator = http.NewRequest
+		reqCreator = http.NewRequestWithContext
 	}
 
 	return Client{
 		httpClient: mock,
-		baseURI: url.URL{
+		baseURIs: newBaseURIs(url.URL{
 			Scheme: "https",
 			Host:   "api.form3.tech",
-		},
+		}),
 		bodyReader:       bodyReader,
 		respUnmarshaller: respUnmarshaller,
 		reqCreator:       reqCreator,
@@ -0,0 +1,235 @@
+package httputils
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingTransport counts the response bodies opened and closed through it so the leaked ones can be detected, an
+// empty body holds no connection so it is left as it is
+type countingTransport struct {
+	next   http.RoundTripper
+	opened int32
+	closed int32
+}
+
+func (t *countingTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	response, err := t.next.RoundTrip(request)
+	if err != nil || response.Body == http.NoBody {
+		return response, err
+	}
+
+	atomic.AddInt32(&t.opened, 1)
+	response.Body = &countingBody{ReadCloser: response.Body, transport: t}
+	return response, nil
+}
+
+type countingBody struct {
+	io.ReadCloser
+	transport *countingTransport
+	closed    int32
+}
+
+func (b *countingBody) Close() error {
+	if atomic.CompareAndSwapInt32(&b.closed, 0, 1) {
+		atomic.AddInt32(&b.transport.closed, 1)
+	}
+	return b.ReadCloser.Close()
+}
+
+func TestClientClosesResponseBodies(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		call       func(*Client) error
+		wantErrMsg string
+	}{
+		{
+			name:       "Successfully posts data",
+			statusCode: http.StatusCreated,
+			body:       `{"data": {}}`,
+			call: func(c *Client) error {
+				_, err := c.Post(context.Background(), "/v1/organisation/accounts", []byte(`{}`), nil)
+				return err
+			},
+		},
+		{
+			name:       "Failed to post data with a conflict",
+			statusCode: http.StatusConflict,
+			body:       `{"error_message": "duplicate"}`,
+			call: func(c *Client) error {
+				_, err := c.Post(context.Background(), "/v1/organisation/accounts", []byte(`{}`), nil)
+				return err
+			},
+			wantErrMsg: "POST /v1/organisation/accounts: api failure with status code 409 and message: duplicate",
+		},
+		{
+			name:       "Successfully patches data",
+			statusCode: http.StatusOK,
+			body:       `{"data": {}}`,
+			call: func(c *Client) error {
+				_, err := c.Patch(context.Background(), "/v1/organisation/accounts/1", []byte(`{}`), nil)
+				return err
+			},
+		},
+		{
+			name:       "Successfully gets data",
+			statusCode: http.StatusOK,
+			body:       `{"data": {}}`,
+			call: func(c *Client) error {
+				_, err := c.Get(context.Background(), "/v1/organisation/accounts/1", nil)
+				return err
+			},
+		},
+		{
+			name:       "Failed to get data with an unexpected status code",
+			statusCode: http.StatusInternalServerError,
+			body:       `internal error`,
+			call: func(c *Client) error {
+				_, err := c.Get(context.Background(), "/v1/organisation/accounts/1", nil)
+				return err
+			},
+			wantErrMsg: "GET /v1/organisation/accounts/1: unexpected status code 500",
+		},
+		{
+			name:       "Successfully deletes data",
+			statusCode: http.StatusNoContent,
+			call: func(c *Client) error {
+				return c.Delete(context.Background(), "/v1/organisation/accounts/1", nil)
+			},
+		},
+		{
+			name:       "Failed to delete data not found",
+			statusCode: http.StatusNotFound,
+			call: func(c *Client) error {
+				return c.Delete(context.Background(), "/v1/organisation/accounts/1", nil)
+			},
+			wantErrMsg: "DELETE /v1/organisation/accounts/1: api failure with status code 404 and message: not found",
+		},
+		{
+			name:       "Failed to delete data with a conflict",
+			statusCode: http.StatusConflict,
+			body:       `{"error_message": "invalid version"}`,
+			call: func(c *Client) error {
+				return c.Delete(context.Background(), "/v1/organisation/accounts/1", nil)
+			},
+			wantErrMsg: "DELETE /v1/organisation/accounts/1: api failure with status code 409 and message: invalid version",
+		},
+		{
+			name:       "Failed to delete data without permission",
+			statusCode: http.StatusForbidden,
+			body:       `{"error_message": "forbidden"}`,
+			call: func(c *Client) error {
+				return c.Delete(context.Background(), "/v1/organisation/accounts/1", nil)
+			},
+			wantErrMsg: "DELETE /v1/organisation/accounts/1: auth failure with status code 403: the credentials are not allowed to perform the operation, check the permissions of the user on the organisation (forbidden)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.statusCode)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			transport := &countingTransport{}
+			client, err := NewClient(server.URL, time.Second, WithRoundTripper(func(next http.RoundTripper) http.RoundTripper {
+				transport.next = next
+				return transport
+			}))
+			require.NoError(t, err)
+
+			err = tt.call(client)
+			if tt.wantErrMsg != "" {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.wantErrMsg)
+			} else {
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, atomic.LoadInt32(&transport.opened), atomic.LoadInt32(&transport.closed), "a response body was not closed")
+		})
+	}
+}
+
+func TestClientSkipsEmptyBodies(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		call       func(*Client) error
+		wantErrMsg string
+	}{
+		{
+			name:       "Successfully deletes data",
+			statusCode: http.StatusNoContent,
+			call: func(c *Client) error {
+				return c.Delete(context.Background(), "/v1/organisation/accounts/1", nil)
+			},
+		},
+		{
+			name:       "Failed to delete data not found",
+			statusCode: http.StatusNotFound,
+			call: func(c *Client) error {
+				return c.Delete(context.Background(), "/v1/organisation/accounts/1", nil)
+			},
+			wantErrMsg: "DELETE /v1/organisation/accounts/1: api failure with status code 404 and message: not found",
+		},
+		{
+			name:       "Failed to get data not found",
+			statusCode: http.StatusNotFound,
+			call: func(c *Client) error {
+				_, err := c.Get(context.Background(), "/v1/organisation/accounts/1", nil)
+				return err
+			},
+			wantErrMsg: "GET /v1/organisation/accounts/1: api failure with status code 404 and message: not found",
+		},
+		{
+			name:       "Failed to post data with a conflict",
+			statusCode: http.StatusConflict,
+			call: func(c *Client) error {
+				_, err := c.Post(context.Background(), "/v1/organisation/accounts", []byte(`{}`), nil)
+				return err
+			},
+			wantErrMsg: "POST /v1/organisation/accounts: api failure with status code 409 and message: conflict",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.statusCode)
+			}))
+			defer server.Close()
+
+			client, err := NewClient(server.URL, time.Second)
+			require.NoError(t, err)
+			client.bodyReader = func(io.Reader) ([]byte, error) {
+				t.Fatal("the empty body was read")
+				return nil, nil
+			}
+			client.respUnmarshaller = func([]byte, interface{}) error {
+				t.Fatal("the empty body was unmarshalled")
+				return nil
+			}
+
+			err = tt.call(client)
+			if tt.wantErrMsg != "" {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.wantErrMsg)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
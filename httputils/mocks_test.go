@@ -35,3 +35,8 @@ func (_m *mockHttpClient) Do(req *http.Request) (*http.Response, error) {
 
 	return r0, r1
 }
+
+// CloseIdleConnections provides a mock function with given fields:
+func (_m *mockHttpClient) CloseIdleConnections() {
+	_m.Called()
+}
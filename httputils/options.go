@@ -0,0 +1,259 @@
+package httputils
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Option configures the http client created by NewClient, the options validate their input and the invalid ones
+// make NewClient fail
+type Option func(*Client) error
+
+// WithMaxIdleConns sets the maximum number of idle keep-alive connections across all hosts, zero means no limit
+func WithMaxIdleConns(maxIdleConns int) Option {
+	return func(c *Client) error {
+		if maxIdleConns < 0 {
+			return fmt.Errorf("invalid max idle conns %d, it must not be negative", maxIdleConns)
+		}
+
+		c.transport.MaxIdleConns = maxIdleConns
+		return nil
+	}
+}
+
+// WithMaxIdleConnsPerHost sets the maximum number of idle keep-alive connections kept per host
+func WithMaxIdleConnsPerHost(maxIdleConnsPerHost int) Option {
+	return func(c *Client) error {
+		if maxIdleConnsPerHost < 0 {
+			return fmt.Errorf("invalid max idle conns per host %d, it must not be negative", maxIdleConnsPerHost)
+		}
+
+		c.transport.MaxIdleConnsPerHost = maxIdleConnsPerHost
+		return nil
+	}
+}
+
+// WithIdleConnTimeout sets how long an idle keep-alive connection remains open before closing itself
+func WithIdleConnTimeout(idleConnTimeout time.Duration) Option {
+	return func(c *Client) error {
+		if idleConnTimeout <= 0 {
+			return fmt.Errorf("invalid idle conn timeout %s, it must be positive", idleConnTimeout)
+		}
+
+		c.transport.IdleConnTimeout = idleConnTimeout
+		return nil
+	}
+}
+
+// WithDisableKeepAlives disables the keep-alive connections so every request uses a new connection
+func WithDisableKeepAlives(disableKeepAlives bool) Option {
+	return func(c *Client) error {
+		c.transport.DisableKeepAlives = disableKeepAlives
+		return nil
+	}
+}
+
+// WithMinTLSVersion sets the minimum TLS version accepted by the client, such as tls.VersionTLS12
+func WithMinTLSVersion(version uint16) Option {
+	return func(c *Client) error {
+		if version < tls.VersionTLS10 || version > tls.VersionTLS13 {
+			return fmt.Errorf("invalid min tls version %#x, it must be between tls 1.0 and tls 1.3", version)
+		}
+
+		if c.transport.TLSClientConfig == nil {
+			c.transport.TLSClientConfig = &tls.Config{}
+		}
+		c.transport.TLSClientConfig.MinVersion = version
+		return nil
+	}
+}
+
+// WithRoundTripper wraps the transport of the client, such as with a recording or a fault injecting round tripper,
+// the wraps are applied in order so the last one given is the outermost
+func WithRoundTripper(wrap func(http.RoundTripper) http.RoundTripper) Option {
+	return func(c *Client) error {
+		if wrap == nil {
+			return errors.New("invalid round tripper wrap, it must not be nil")
+		}
+
+		c.roundTripperWraps = append(c.roundTripperWraps, wrap)
+		return nil
+	}
+}
+
+// WithSuccessStatuses restricts the status codes treated as a success of the method given, such as only 201 for a
+// post, by default any 2xx status code is a success
+func WithSuccessStatuses(method string, statusCodes ...int) Option {
+	return func(c *Client) error {
+		if len(statusCodes) == 0 {
+			return fmt.Errorf("invalid success statuses of %s, at least one status code is required", method)
+		}
+
+		statuses := make(map[int]bool, len(statusCodes))
+		for _, statusCode := range statusCodes {
+			if statusCode < 200 || statusCode > 299 {
+				return fmt.Errorf("invalid success status %d of %s, it must be a 2xx status code", statusCode, method)
+			}
+			statuses[statusCode] = true
+		}
+
+		if c.successStatuses == nil {
+			c.successStatuses = make(map[string]map[int]bool)
+		}
+		c.successStatuses[strings.ToUpper(method)] = statuses
+		return nil
+	}
+}
+
+// isSuccess tells if the status code is a success of the method
+func (c Client) isSuccess(method string, statusCode int) bool {
+	if statuses, ok := c.successStatuses[method]; ok {
+		return statuses[statusCode]
+	}
+
+	return statusCode >= 200 && statusCode <= 299
+}
+
+// authHeaders are the headers carrying the credentials or the signature of a request
+var authHeaders = []string{"Authorization", "Signature", "Digest", "Cookie"}
+
+// RedirectPolicy is the redirect behaviour of the client, by default the redirects are followed up to 10 times as
+// with the net/http client
+type RedirectPolicy struct {
+	// MaxRedirects is the maximum number of redirects followed, zero never follows them so the redirect response is
+	// returned as an unexpected status code
+	MaxRedirects int
+	// SameHostAuthOnly keeps the auth headers only on the redirects to the host of the original request, so a
+	// redirect never carries the credentials or the signature to another host
+	SameHostAuthOnly bool
+}
+
+// WithRedirectPolicy sets the redirect behaviour of the client, see RedirectPolicy
+func WithRedirectPolicy(policy RedirectPolicy) Option {
+	return func(c *Client) error {
+		if policy.MaxRedirects < 0 {
+			return fmt.Errorf("invalid max redirects %d, it must not be negative", policy.MaxRedirects)
+		}
+
+		c.checkRedirect = func(request *http.Request, via []*http.Request) error {
+			if len(via) > policy.MaxRedirects {
+				return http.ErrUseLastResponse
+			}
+
+			if policy.SameHostAuthOnly && request.URL.Host != via[0].URL.Host {
+				for _, header := range authHeaders {
+					request.Header.Del(header)
+				}
+			}
+
+			return nil
+		}
+		return nil
+	}
+}
+
+// WithDialer sets the dialer of the connections, the connect timeout and the resolver already set are kept when the
+// dialer leaves them zero so the options can be given in any order
+func WithDialer(dialer *net.Dialer) Option {
+	return func(c *Client) error {
+		if dialer == nil {
+			return errors.New("invalid dialer, it must not be nil")
+		}
+
+		copied := *dialer
+		if copied.Timeout == 0 {
+			copied.Timeout = c.dialer.Timeout
+		}
+		if copied.Resolver == nil {
+			copied.Resolver = c.dialer.Resolver
+		}
+
+		c.dialer = &copied
+		return nil
+	}
+}
+
+// WithResolver sets the resolver of the hosts, such as for a split-horizon DNS
+func WithResolver(resolver *net.Resolver) Option {
+	return func(c *Client) error {
+		if resolver == nil {
+			return errors.New("invalid resolver, it must not be nil")
+		}
+
+		c.dialer.Resolver = resolver
+		return nil
+	}
+}
+
+// WithDialContext sets the function dialing the connections, such as the one of a service-mesh sidecar, it takes
+// precedence over the dialer so the connect timeout and the resolver are then up to it
+func WithDialContext(dial func(ctx context.Context, network, addr string) (net.Conn, error)) Option {
+	return func(c *Client) error {
+		if dial == nil {
+			return errors.New("invalid dial context, it must not be nil")
+		}
+
+		c.dialContext = dial
+		return nil
+	}
+}
+
+// WithUnixSocket dials every connection over the unix socket at the path given, such as the one of a local fake or
+// of a sidecar proxy, the host of the base uri is then only sent in the requests
+func WithUnixSocket(path string) Option {
+	return func(c *Client) error {
+		if path == "" {
+			return errors.New("invalid unix socket path, it must not be empty")
+		}
+
+		c.dialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return c.dialer.DialContext(ctx, "unix", path)
+		}
+		return nil
+	}
+}
+
+// Timeouts is the granular timeout config of the client, zero values keep the defaults of the transport
+type Timeouts struct {
+	// Connect is the maximum time to wait for the connection to be established
+	Connect time.Duration
+	// TLSHandshake is the maximum time to wait for the TLS handshake
+	TLSHandshake time.Duration
+	// ResponseHeader is the maximum time to wait for the response headers once the request is written
+	ResponseHeader time.Duration
+	// Overall is the deadline of the whole request including reading the body, it overrides the timeout given to NewClient
+	Overall time.Duration
+}
+
+// WithTimeouts sets the granular timeouts so slow handshakes and slow bodies can be treated differently
+func WithTimeouts(timeouts Timeouts) Option {
+	return func(c *Client) error {
+		if timeouts.Connect < 0 || timeouts.TLSHandshake < 0 || timeouts.ResponseHeader < 0 || timeouts.Overall < 0 {
+			return fmt.Errorf("invalid timeouts %+v, they must not be negative", timeouts)
+		}
+
+		if timeouts.Connect > 0 {
+			c.dialer.Timeout = timeouts.Connect
+		}
+
+		if timeouts.TLSHandshake > 0 {
+			c.transport.TLSHandshakeTimeout = timeouts.TLSHandshake
+		}
+
+		if timeouts.ResponseHeader > 0 {
+			c.transport.ResponseHeaderTimeout = timeouts.ResponseHeader
+		}
+
+		if timeouts.Overall > 0 {
+			c.timeout = timeouts.Overall
+		}
+
+		return nil
+	}
+}
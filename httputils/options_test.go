@@ -0,0 +1,467 @@
+package httputils
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientTransportOptions(t *testing.T) {
+	tests := []struct {
+		name   string
+		opts   []Option
+		assert func(*testing.T, *Client)
+	}{
+		{
+			name: "Successfully sets the max idle connections",
+			opts: []Option{WithMaxIdleConns(50)},
+			assert: func(t *testing.T, c *Client) {
+				assert.Equal(t, 50, c.transport.MaxIdleConns)
+			},
+		},
+		{
+			name: "Successfully sets the max idle connections per host",
+			opts: []Option{WithMaxIdleConnsPerHost(10)},
+			assert: func(t *testing.T, c *Client) {
+				assert.Equal(t, 10, c.transport.MaxIdleConnsPerHost)
+			},
+		},
+		{
+			name: "Successfully sets the idle connection timeout",
+			opts: []Option{WithIdleConnTimeout(30 * time.Second)},
+			assert: func(t *testing.T, c *Client) {
+				assert.Equal(t, 30*time.Second, c.transport.IdleConnTimeout)
+			},
+		},
+		{
+			name: "Successfully disables the keep alives",
+			opts: []Option{WithDisableKeepAlives(true)},
+			assert: func(t *testing.T, c *Client) {
+				assert.True(t, c.transport.DisableKeepAlives)
+			},
+		},
+		{
+			name: "Successfully sets the min tls version",
+			opts: []Option{WithMinTLSVersion(tls.VersionTLS12)},
+			assert: func(t *testing.T, c *Client) {
+				assert.Equal(t, uint16(tls.VersionTLS12), c.transport.TLSClientConfig.MinVersion)
+			},
+		},
+		{
+			name: "Successfully sets the dialer keeping the connect timeout and the resolver given after it",
+			opts: []Option{
+				WithDialer(&net.Dialer{KeepAlive: time.Minute}),
+				WithTimeouts(Timeouts{Connect: time.Second}),
+				WithResolver(&net.Resolver{PreferGo: true}),
+			},
+			assert: func(t *testing.T, c *Client) {
+				assert.Equal(t, time.Minute, c.dialer.KeepAlive)
+				assert.Equal(t, time.Second, c.dialer.Timeout)
+				assert.True(t, c.dialer.Resolver.PreferGo)
+			},
+		},
+		{
+			name: "Successfully sets the dialer keeping the connect timeout and the resolver given before it",
+			opts: []Option{
+				WithResolver(&net.Resolver{PreferGo: true}),
+				WithTimeouts(Timeouts{Connect: time.Second}),
+				WithDialer(&net.Dialer{KeepAlive: time.Minute}),
+			},
+			assert: func(t *testing.T, c *Client) {
+				assert.Equal(t, time.Minute, c.dialer.KeepAlive)
+				assert.Equal(t, time.Second, c.dialer.Timeout)
+				assert.True(t, c.dialer.Resolver.PreferGo)
+			},
+		},
+		{
+			name: "Successfully sets the dialer with its own connect timeout and resolver",
+			opts: []Option{
+				WithResolver(&net.Resolver{PreferGo: true}),
+				WithTimeouts(Timeouts{Connect: time.Second}),
+				WithDialer(&net.Dialer{Timeout: 5 * time.Second, Resolver: &net.Resolver{StrictErrors: true}}),
+			},
+			assert: func(t *testing.T, c *Client) {
+				assert.Equal(t, 5*time.Second, c.dialer.Timeout)
+				assert.True(t, c.dialer.Resolver.StrictErrors)
+				assert.False(t, c.dialer.Resolver.PreferGo)
+			},
+		},
+		{
+			name: "Successfully sets the granular timeouts",
+			opts: []Option{WithTimeouts(Timeouts{
+				Connect:        time.Second,
+				TLSHandshake:   2 * time.Second,
+				ResponseHeader: 3 * time.Second,
+				Overall:        4 * time.Second,
+			})},
+			assert: func(t *testing.T, c *Client) {
+				assert.NotNil(t, c.transport.DialContext)
+				assert.Equal(t, time.Second, c.dialer.Timeout)
+				assert.Equal(t, 2*time.Second, c.transport.TLSHandshakeTimeout)
+				assert.Equal(t, 3*time.Second, c.transport.ResponseHeaderTimeout)
+				assert.Equal(t, 4*time.Second, c.timeout)
+				assert.Equal(t, 4*time.Second, c.httpClient.(*http.Client).Timeout)
+			},
+		},
+		{
+			name: "Successfully keeps the defaults for the timeouts not set",
+			opts: []Option{WithTimeouts(Timeouts{ResponseHeader: 3 * time.Second})},
+			assert: func(t *testing.T, c *Client) {
+				assert.Equal(t, 10*time.Second, c.transport.TLSHandshakeTimeout)
+				assert.Equal(t, 3*time.Second, c.transport.ResponseHeaderTimeout)
+				assert.Equal(t, 15*time.Second, c.httpClient.(*http.Client).Timeout)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewClient("https://valid-url.com", 15*time.Second, tt.opts...)
+			require.NoError(t, err)
+
+			tt.assert(t, got)
+		})
+	}
+}
+
+func TestClientInvalidOptions(t *testing.T) {
+	tests := []struct {
+		name       string
+		opt        Option
+		wantErrMsg string
+	}{
+		{
+			name:       "Failed to create the client with negative max idle connections",
+			opt:        WithMaxIdleConns(-1),
+			wantErrMsg: "invalid max idle conns -1, it must not be negative; invalid option",
+		},
+		{
+			name:       "Failed to create the client with negative max idle connections per host",
+			opt:        WithMaxIdleConnsPerHost(-1),
+			wantErrMsg: "invalid max idle conns per host -1, it must not be negative; invalid option",
+		},
+		{
+			name:       "Failed to create the client with a non positive idle connection timeout",
+			opt:        WithIdleConnTimeout(0),
+			wantErrMsg: "invalid idle conn timeout 0s, it must be positive; invalid option",
+		},
+		{
+			name:       "Failed to create the client with a negative timeout",
+			opt:        WithTimeouts(Timeouts{Connect: -time.Second}),
+			wantErrMsg: "invalid timeouts {Connect:-1s TLSHandshake:0s ResponseHeader:0s Overall:0s}, they must not be negative; invalid option",
+		},
+		{
+			name:       "Failed to create the client with an unknown tls version",
+			opt:        WithMinTLSVersion(0x0200),
+			wantErrMsg: "invalid min tls version 0x200, it must be between tls 1.0 and tls 1.3; invalid option",
+		},
+		{
+			name:       "Failed to create the client without success statuses",
+			opt:        WithSuccessStatuses(http.MethodPost),
+			wantErrMsg: "invalid success statuses of POST, at least one status code is required; invalid option",
+		},
+		{
+			name:       "Failed to create the client with a success status out of the 2xx",
+			opt:        WithSuccessStatuses(http.MethodPost, http.StatusFound),
+			wantErrMsg: "invalid success status 302 of POST, it must be a 2xx status code; invalid option",
+		},
+		{
+			name:       "Failed to create the client with negative max redirects",
+			opt:        WithRedirectPolicy(RedirectPolicy{MaxRedirects: -1}),
+			wantErrMsg: "invalid max redirects -1, it must not be negative; invalid option",
+		},
+		{
+			name:       "Failed to create the client with a nil dialer",
+			opt:        WithDialer(nil),
+			wantErrMsg: "invalid dialer, it must not be nil; invalid option",
+		},
+		{
+			name:       "Failed to create the client with a nil resolver",
+			opt:        WithResolver(nil),
+			wantErrMsg: "invalid resolver, it must not be nil; invalid option",
+		},
+		{
+			name:       "Failed to create the client with a nil dial context",
+			opt:        WithDialContext(nil),
+			wantErrMsg: "invalid dial context, it must not be nil; invalid option",
+		},
+		{
+			name:       "Failed to create the client with an empty unix socket path",
+			opt:        WithUnixSocket(""),
+			wantErrMsg: "invalid unix socket path, it must not be empty; invalid option",
+		},
+		{
+			name:       "Failed to create the client with a nil round tripper wrap",
+			opt:        WithRoundTripper(nil),
+			wantErrMsg: "invalid round tripper wrap, it must not be nil; invalid option",
+		},
+		{
+			name:       "Failed to create the client with a nil clock",
+			opt:        WithClock(nil),
+			wantErrMsg: "invalid clock, it must not be nil; invalid option",
+		},
+		{
+			name:       "Failed to create the client with a nil sleeper",
+			opt:        WithSleeper(nil),
+			wantErrMsg: "invalid sleeper, it must not be nil; invalid option",
+		},
+		{
+			name:       "Failed to create the client with an empty api version",
+			opt:        WithAPIVersion(""),
+			wantErrMsg: `invalid api version "", it must not be empty nor start or end with a slash; invalid option`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewClient("https://valid-url.com", 15*time.Second, tt.opt)
+
+			require.Error(t, err)
+			assert.EqualError(t, err, tt.wantErrMsg)
+			assert.Nil(t, got)
+		})
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
+
+func TestClientWithRoundTripper(t *testing.T) {
+	var calls []string
+	wrap := func(name string) func(http.RoundTripper) http.RoundTripper {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return roundTripperFunc(func(request *http.Request) (*http.Response, error) {
+				calls = append(calls, name)
+				return next.RoundTrip(request)
+			})
+		}
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, "server")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, 5*time.Second, WithRoundTripper(wrap("inner")), WithRoundTripper(wrap("outer")))
+	require.NoError(t, err)
+
+	_, err = client.Get(context.Background(), "/v1/organisation/accounts/id", nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"outer", "inner", "server"}, calls)
+}
+
+func TestClientSuccessStatuses(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		opts       []Option
+		call       func(*Client) error
+		wantErr    bool
+		wantErrMsg string
+	}{
+		{
+			name:       "Successfully posts data answered with a 200",
+			statusCode: http.StatusOK,
+			call: func(c *Client) error {
+				_, err := c.Post(context.Background(), "/v1/organisation/accounts", []byte(`{}`), nil)
+				return err
+			},
+		},
+		{
+			name:       "Successfully posts data answered with a 202",
+			statusCode: http.StatusAccepted,
+			call: func(c *Client) error {
+				_, err := c.Post(context.Background(), "/v1/organisation/accounts", []byte(`{}`), nil)
+				return err
+			},
+		},
+		{
+			name:       "Successfully deletes data answered with a 200",
+			statusCode: http.StatusOK,
+			call: func(c *Client) error {
+				return c.Delete(context.Background(), "/v1/organisation/accounts/1", nil)
+			},
+		},
+		{
+			name:       "Successfully gets data with the success statuses of another method restricted",
+			statusCode: http.StatusAccepted,
+			opts:       []Option{WithSuccessStatuses(http.MethodPost, http.StatusCreated)},
+			call: func(c *Client) error {
+				_, err := c.Get(context.Background(), "/v1/organisation/accounts", nil)
+				return err
+			},
+		},
+		{
+			name:       "Failed to post data answered with a status out of the restricted ones",
+			statusCode: http.StatusOK,
+			opts:       []Option{WithSuccessStatuses("post", http.StatusCreated)},
+			call: func(c *Client) error {
+				_, err := c.Post(context.Background(), "/v1/organisation/accounts", []byte(`{}`), nil)
+				return err
+			},
+			wantErr:    true,
+			wantErrMsg: "POST /v1/organisation/accounts: unexpected status code 200",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.statusCode)
+				_, _ = w.Write([]byte(`{"data": {}}`))
+			}))
+			defer server.Close()
+
+			client, err := NewClient(server.URL, time.Second, tt.opts...)
+			require.NoError(t, err)
+
+			err = tt.call(client)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.wantErrMsg)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestClientWithRedirectPolicy(t *testing.T) {
+	var receivedAuth []string
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receivedAuth = append(receivedAuth, r.Header.Get("Authorization")+"|"+r.Header.Get("Signature"))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer target.Close()
+
+	origin := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/same-host":
+			http.Redirect(w, r, "/final", http.StatusFound)
+		case "/other-host":
+			http.Redirect(w, r, target.URL+"/final", http.StatusFound)
+		case "/twice":
+			http.Redirect(w, r, "/same-host", http.StatusFound)
+		default:
+			receivedAuth = append(receivedAuth, r.Header.Get("Authorization")+"|"+r.Header.Get("Signature"))
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	defer origin.Close()
+
+	header := http.Header{"Authorization": {"Bearer token"}, "Signature": {"keyId=1"}}
+
+	tests := []struct {
+		name       string
+		policy     RedirectPolicy
+		path       string
+		wantAuth   []string
+		wantErr    bool
+		wantErrMsg string
+	}{
+		{
+			name:     "Successfully keeps the auth headers on a same host redirect",
+			policy:   RedirectPolicy{MaxRedirects: 1, SameHostAuthOnly: true},
+			path:     "/same-host",
+			wantAuth: []string{"Bearer token|keyId=1"},
+		},
+		{
+			name:     "Successfully drops the auth headers on a redirect to another host",
+			policy:   RedirectPolicy{MaxRedirects: 1, SameHostAuthOnly: true},
+			path:     "/other-host",
+			wantAuth: []string{"|"},
+		},
+		{
+			name:       "Failed to follow a redirect when the redirects are never followed",
+			policy:     RedirectPolicy{},
+			path:       "/same-host",
+			wantErr:    true,
+			wantErrMsg: "GET /same-host: unexpected status code 302",
+		},
+		{
+			name:       "Failed to follow more redirects than the max",
+			policy:     RedirectPolicy{MaxRedirects: 1},
+			path:       "/twice",
+			wantErr:    true,
+			wantErrMsg: "GET /twice: unexpected status code 302",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			receivedAuth = nil
+
+			client, err := NewClient(origin.URL, time.Second, WithRedirectPolicy(tt.policy))
+			require.NoError(t, err)
+
+			_, err = client.WithHeaders(header).Get(context.Background(), tt.path, nil)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.wantErrMsg)
+				assert.Empty(t, receivedAuth)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantAuth, receivedAuth)
+			}
+		})
+	}
+}
+
+func TestClientWithDialContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "form3.mesh.internal", r.Host)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	var dialed []string
+	sidecar := func(ctx context.Context, network, addr string) (net.Conn, error) {
+		dialed = append(dialed, addr)
+		return (&net.Dialer{}).DialContext(ctx, network, server.Listener.Addr().String())
+	}
+
+	client, err := NewClient("http://form3.mesh.internal", time.Second, WithDialContext(sidecar))
+	require.NoError(t, err)
+
+	_, err = client.Get(context.Background(), "/v1/organisation/accounts", nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"form3.mesh.internal:80"}, dialed)
+}
+
+func TestClientWithUnixSocket(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "form3.sock")
+	listener, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "localhost", r.Host)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"data": {}}`))
+	}))
+	server.Listener = listener
+	server.Start()
+	defer server.Close()
+
+	client, err := NewClient("http://localhost", time.Second, WithUnixSocket(socket))
+	require.NoError(t, err)
+
+	got, err := client.Get(context.Background(), "/v1/organisation/accounts", nil)
+	require.NoError(t, err)
+	assert.Equal(t, `{"data": {}}`, string(got))
+}
@@ -0,0 +1,38 @@
+package httputils
+
+import (
+	"bytes"
+	"io"
+	"sync"
+)
+
+// maxPooledBufferSize is the capacity above which a buffer is not returned to the pool, so an unusually large
+// response doesn't stay in memory
+const maxPooledBufferSize = 1 << 20
+
+var bufferPool = sync.Pool{
+	New: func() interface{} {
+		return new(bytes.Buffer)
+	},
+}
+
+// readAllPooled reads the reader into a pooled buffer and returns a copy of exactly the size read, so reading a body
+// costs one allocation instead of the repeated growth of ioutil.ReadAll
+func readAllPooled(r io.Reader) ([]byte, error) {
+	buf := bufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	defer func() {
+		if buf.Cap() <= maxPooledBufferSize {
+			bufferPool.Put(buf)
+		}
+	}()
+
+	if _, err := buf.ReadFrom(r); err != nil {
+		return nil, err
+	}
+
+	body := make([]byte, buf.Len())
+	copy(body, buf.Bytes())
+
+	return body, nil
+}
@@ -0,0 +1,90 @@
+package httputils
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) {
+	return 0, errors.New("connection reset")
+}
+
+func TestReadAllPooled(t *testing.T) {
+	body := strings.Repeat(`{"id": "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc"}`, 100)
+
+	first, err := readAllPooled(strings.NewReader(body))
+	require.NoError(t, err)
+
+	second, err := readAllPooled(strings.NewReader("{}"))
+	require.NoError(t, err)
+
+	assert.Equal(t, body, string(first), "a body read before must not be overwritten by the reuse of the buffer")
+	assert.Equal(t, "{}", string(second))
+
+	_, err = readAllPooled(failingReader{})
+	assert.EqualError(t, err, "connection reset")
+}
+
+// benchmarkBody is an account resource as returned by form3
+var benchmarkBody = []byte(`{"data": {"attributes": {"alternative_names": ["Sam Holder"], "bank_id": "400300", "bank_id_code": "GBDSC",
+"base_currency": "GBP", "bic": "NWBKGB22", "country": "GB", "iban": "GB16NWBK40030041426819", "name": ["Samantha Holder"]},
+"created_on": "2021-06-01T10:00:00.000Z", "id": "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc", "modified_on": "2021-06-01T10:00:00.000Z",
+"organisation_id": "eb0bd6f5-c3f5-44b2-b677-acd23cdde73c", "type": "accounts", "version": 0},
+"links": {"self": "/v1/organisation/accounts/ad27e265-9605-4b4b-a0e5-3003ea9cc4dc"}}`)
+
+// stubHttpClient answers every request with the benchmark body without a network round trip
+type stubHttpClient struct{}
+
+func (stubHttpClient) Do(*http.Request) (*http.Response, error) {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{},
+		Body:       ioutil.NopCloser(bytes.NewReader(benchmarkBody)),
+	}, nil
+}
+
+func (stubHttpClient) CloseIdleConnections() {}
+
+func benchmarkRead(b *testing.B, read func(io.Reader) ([]byte, error)) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := read(bytes.NewReader(benchmarkBody)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkReadAll(b *testing.B) {
+	benchmarkRead(b, ioutil.ReadAll)
+}
+
+func BenchmarkReadAllPooled(b *testing.B) {
+	benchmarkRead(b, readAllPooled)
+}
+
+func BenchmarkClientGet(b *testing.B) {
+	client, err := NewClient("https://api.form3.tech", time.Second)
+	require.NoError(b, err)
+	client.httpClient = stubHttpClient{}
+
+	ctx := context.Background()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := client.Get(ctx, "/v1/organisation/accounts/ad27e265-9605-4b4b-a0e5-3003ea9cc4dc", nil); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package httputils
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+)
+
+// Protocol is the http protocol used by the client to talk to form3
+type Protocol int
+
+const (
+	// ProtocolAuto negotiates the protocol as the net/http client does, HTTP/2 when the server offers it over TLS
+	ProtocolAuto Protocol = iota
+	// ProtocolHTTP1 forces HTTP/1.1, for the proxies misbehaving with the protocol negotiation
+	ProtocolHTTP1
+	// ProtocolHTTP2 attempts HTTP/2 over TLS also when the TLS config of the transport is customised
+	ProtocolHTTP2
+	// ProtocolH2C uses HTTP/2 without TLS, such as with the local mock servers, it requires go 1.24 or later
+	ProtocolH2C
+)
+
+func (protocol Protocol) String() string {
+	switch protocol {
+	case ProtocolAuto:
+		return "auto"
+	case ProtocolHTTP1:
+		return "http/1.1"
+	case ProtocolHTTP2:
+		return "h2"
+	case ProtocolH2C:
+		return "h2c"
+	default:
+		return fmt.Sprintf("Protocol(%d)", int(protocol))
+	}
+}
+
+// WithProtocol sets the http protocol of the client, see Protocol
+func WithProtocol(protocol Protocol) Option {
+	return func(c *Client) error {
+		switch protocol {
+		case ProtocolAuto:
+			return nil
+		case ProtocolHTTP1:
+			c.transport.ForceAttemptHTTP2 = false
+			c.transport.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+			return nil
+		case ProtocolHTTP2:
+			c.transport.ForceAttemptHTTP2 = true
+			return nil
+		case ProtocolH2C:
+			return enableH2C(c.transport)
+		default:
+			return fmt.Errorf("invalid protocol %s", protocol)
+		}
+	}
+}
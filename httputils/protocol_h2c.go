@@ -0,0 +1,13 @@
+//go:build go1.24
+
+package httputils
+
+import "net/http"
+
+// enableH2C makes the transport use HTTP/2 without TLS
+func enableH2C(transport *http.Transport) error {
+	protocols := new(http.Protocols)
+	protocols.SetUnencryptedHTTP2(true)
+	transport.Protocols = protocols
+	return nil
+}
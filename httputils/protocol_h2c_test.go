@@ -0,0 +1,35 @@
+//go:build go1.24
+
+package httputils
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientWithH2C(t *testing.T) {
+	var gotProto string
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotProto = r.Proto
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	server.Config.Protocols = new(http.Protocols)
+	server.Config.Protocols.SetHTTP1(true)
+	server.Config.Protocols.SetUnencryptedHTTP2(true)
+	server.Start()
+	defer server.Close()
+
+	client, err := NewClient(server.URL, time.Second, WithProtocol(ProtocolH2C))
+	require.NoError(t, err)
+
+	_, err = client.Get(context.Background(), "/v1/organisation/accounts", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "HTTP/2.0", gotProto)
+}
@@ -0,0 +1,13 @@
+//go:build !go1.24
+
+package httputils
+
+import (
+	"errors"
+	"net/http"
+)
+
+// enableH2C fails as the transport supports HTTP/2 without TLS only from go 1.24
+func enableH2C(*http.Transport) error {
+	return errors.New("invalid protocol h2c, it requires go 1.24 or later")
+}
@@ -0,0 +1,68 @@
+package httputils
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientWithProtocol(t *testing.T) {
+	tests := []struct {
+		name      string
+		protocol  Protocol
+		wantProto string
+	}{
+		{
+			name:      "Successfully negotiates HTTP/2 by default",
+			protocol:  ProtocolAuto,
+			wantProto: "HTTP/2.0",
+		},
+		{
+			name:      "Successfully forces HTTP/1.1",
+			protocol:  ProtocolHTTP1,
+			wantProto: "HTTP/1.1",
+		},
+		{
+			name:      "Successfully attempts HTTP/2 with a customised TLS config",
+			protocol:  ProtocolHTTP2,
+			wantProto: "HTTP/2.0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotProto string
+			server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotProto = r.Proto
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte(`{}`))
+			}))
+			server.EnableHTTP2 = true
+			server.StartTLS()
+			defer server.Close()
+
+			trustServer := func(next http.RoundTripper) http.RoundTripper {
+				next.(*http.Transport).TLSClientConfig = server.Client().Transport.(*http.Transport).TLSClientConfig.Clone()
+				return next
+			}
+
+			client, err := NewClient(server.URL, time.Second, WithProtocol(tt.protocol), WithRoundTripper(trustServer))
+			require.NoError(t, err)
+
+			_, err = client.Get(context.Background(), "/v1/organisation/accounts", nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantProto, gotProto)
+		})
+	}
+}
+
+func TestClientWithInvalidProtocol(t *testing.T) {
+	_, err := NewClient("https://valid-url.com", time.Second, WithProtocol(Protocol(9)))
+	require.Error(t, err)
+	assert.EqualError(t, err, "invalid protocol Protocol(9); invalid option")
+}
@@ -0,0 +1,131 @@
+package httputils
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// epochThreshold tells the reset header given as an epoch in seconds apart from the one given as seconds to wait
+const epochThreshold = 1e9
+
+// RateLimit is the rate limit state form3 reported in the headers of the last response having them
+type RateLimit struct {
+	// Limit is the number of requests allowed in the window
+	Limit int
+	// Remaining is the number of requests left in the window
+	Remaining int
+	// Reset is when the window resets
+	Reset time.Time
+	// UpdatedAt is when the state was last reported, zero when form3 never reported it
+	UpdatedAt time.Time
+}
+
+// rateLimiter keeps the rate limit state and, when throttling, paces the requests to spread the remaining ones until
+// the reset
+type rateLimiter struct {
+	mu       sync.Mutex
+	state    RateLimit
+	throttle bool
+	lastSent time.Time
+	clock    Clock
+	sleeper  Sleeper
+}
+
+func newRateLimiter() *rateLimiter {
+	return &rateLimiter{clock: SystemClock{}, sleeper: SystemClock{}}
+}
+
+// WithAdaptiveThrottling paces the requests from the rate limit headers of the responses, the remaining requests are
+// spread until the reset and, once there are none left, the requests wait for the reset
+func WithAdaptiveThrottling() Option {
+	return func(c *Client) error {
+		c.rateLimiter.throttle = true
+		return nil
+	}
+}
+
+// RateLimit returns the rate limit state reported by form3, such as for a dashboard
+func (c Client) RateLimit() RateLimit {
+	if c.rateLimiter == nil {
+		return RateLimit{}
+	}
+
+	c.rateLimiter.mu.Lock()
+	defer c.rateLimiter.mu.Unlock()
+
+	return c.rateLimiter.state
+}
+
+// observe updates the state from the rate limit headers of the response, the responses without them are ignored
+func (l *rateLimiter) observe(header http.Header) {
+	if l == nil {
+		return
+	}
+
+	remaining, err := strconv.Atoi(header.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.clock.Now()
+	l.state.Remaining = remaining
+	l.state.UpdatedAt = now
+
+	if limit, err := strconv.Atoi(header.Get("X-RateLimit-Limit")); err == nil {
+		l.state.Limit = limit
+	}
+
+	if reset, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		if reset > epochThreshold {
+			l.state.Reset = time.Unix(reset, 0)
+		} else {
+			l.state.Reset = now.Add(time.Duration(reset) * time.Second)
+		}
+	}
+}
+
+// delay returns how long the next request must wait, reserving its slot
+func (l *rateLimiter) delay() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.clock.Now()
+	untilReset := l.state.Reset.Sub(now)
+	if l.state.UpdatedAt.IsZero() || untilReset <= 0 {
+		l.lastSent = now
+		return 0
+	}
+
+	if l.state.Remaining <= 0 {
+		l.lastSent = l.state.Reset
+		return untilReset
+	}
+
+	next := l.lastSent.Add(untilReset / time.Duration(l.state.Remaining))
+	if next.Before(now) {
+		next = now
+	}
+	l.lastSent = next
+
+	return next.Sub(now)
+}
+
+// wait blocks until the request is allowed by the throttling or the context is done
+func (l *rateLimiter) wait(ctx context.Context) error {
+	if l == nil || !l.throttle {
+		return nil
+	}
+
+	delay := l.delay()
+	if delay <= 0 {
+		return nil
+	}
+
+	return l.sleeper.Sleep(ctx, delay)
+}
@@ -0,0 +1,100 @@
+package httputils
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientRateLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "100")
+		w.Header().Set("X-RateLimit-Remaining", "42")
+		w.Header().Set("X-RateLimit-Reset", "1622541600")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, time.Second)
+	require.NoError(t, err)
+	assert.True(t, client.RateLimit().UpdatedAt.IsZero())
+
+	_, err = client.Get(context.Background(), "/v1/organisation/accounts", nil)
+	require.NoError(t, err)
+
+	rateLimit := client.RateLimit()
+	assert.Equal(t, 100, rateLimit.Limit)
+	assert.Equal(t, 42, rateLimit.Remaining)
+	assert.Equal(t, time.Unix(1622541600, 0), rateLimit.Reset)
+	assert.False(t, rateLimit.UpdatedAt.IsZero())
+
+	assert.Equal(t, RateLimit{}, Client{}.RateLimit())
+}
+
+func TestRateLimiterObserve(t *testing.T) {
+	now := time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC)
+	limiter := &rateLimiter{clock: NewManualClock(now)}
+
+	limiter.observe(http.Header{"X-Ratelimit-Remaining": {"not a number"}})
+	assert.Equal(t, RateLimit{}, limiter.state, "the responses without a valid remaining header must be ignored")
+
+	limiter.observe(http.Header{"X-Ratelimit-Remaining": {"5"}, "X-Ratelimit-Reset": {"30"}})
+	assert.Equal(t, RateLimit{Remaining: 5, Reset: now.Add(30 * time.Second), UpdatedAt: now}, limiter.state)
+}
+
+func TestRateLimiterDelay(t *testing.T) {
+	now := time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC)
+	clock := NewManualClock(now)
+	limiter := &rateLimiter{clock: clock, throttle: true}
+
+	assert.Equal(t, time.Duration(0), limiter.delay(), "the requests must not wait before form3 reports the limit")
+
+	limiter.state = RateLimit{Remaining: 4, Reset: now.Add(8 * time.Second), UpdatedAt: now}
+	limiter.lastSent = now
+	assert.Equal(t, 2*time.Second, limiter.delay(), "the remaining requests must be spread until the reset")
+	assert.Equal(t, 4*time.Second, limiter.delay())
+
+	limiter.state = RateLimit{Remaining: 0, Reset: now.Add(8 * time.Second), UpdatedAt: now}
+	assert.Equal(t, 8*time.Second, limiter.delay(), "the requests must wait for the reset once there are none left")
+
+	clock.Advance(9 * time.Second)
+	assert.Equal(t, time.Duration(0), limiter.delay(), "the requests must not wait once the window reset")
+}
+
+func TestRateLimiterWaitStopsWhenTheContextIsDone(t *testing.T) {
+	now := time.Now()
+	limiter := &rateLimiter{
+		clock:    SystemClock{},
+		sleeper:  SystemClock{},
+		throttle: true,
+		state:    RateLimit{Remaining: 0, Reset: now.Add(time.Minute), UpdatedAt: now},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err := limiter.wait(ctx)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestRateLimiterWaitSleepsOnTheSleeper(t *testing.T) {
+	now := time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC)
+	clock := NewManualClock(now)
+	limiter := &rateLimiter{
+		clock:    clock,
+		sleeper:  clock,
+		throttle: true,
+		state:    RateLimit{Remaining: 0, Reset: now.Add(time.Hour), UpdatedAt: now},
+	}
+
+	require.NoError(t, limiter.wait(context.Background()))
+	assert.Equal(t, time.Hour, clock.Slept())
+	assert.Equal(t, now.Add(time.Hour), clock.Now())
+}
@@ -0,0 +1,39 @@
+package httputils
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// PanicError is the failure of a request whose hook or middleware panicked, such as a round tripper, the credentials
+// or the client trace, the panic is recovered so a bad hook fails the request rather than crashing the service
+type PanicError struct {
+	Value interface{}
+	Stack []byte
+}
+
+func (err *PanicError) Error() string {
+	return fmt.Sprintf("recovered from a panic: %v", err.Value)
+}
+
+// Unwrap returns the value of the panic when it is an error
+func (err *PanicError) Unwrap() error {
+	if valueErr, ok := err.Value.(error); ok {
+		return valueErr
+	}
+
+	return nil
+}
+
+// recoverPanic sets the panic, if any, as a PanicError on the error given, it must be deferred
+func recoverPanic(err *error) {
+	if value := recover(); value != nil {
+		*err = &PanicError{Value: value, Stack: debug.Stack()}
+	}
+}
+
+// ignorePanic recovers the panic of a hook observing a request, such as the timing or the abort hooks, so it neither
+// fails nor crashes the request it observes, it must be deferred
+func ignorePanic() {
+	_ = recover()
+}
@@ -0,0 +1,106 @@
+package httputils
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httptrace"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientRecoversThePanicsOfTheHooks(t *testing.T) {
+	errBadHook := errors.New("bad hook")
+
+	tests := []struct {
+		name       string
+		opts       []Option
+		wantErr    bool
+		wantErrMsg string
+		wantIs     error
+	}{
+		{
+			name: "Failed to get when a round tripper panics",
+			opts: []Option{WithRoundTripper(func(http.RoundTripper) http.RoundTripper {
+				return roundTripperFunc(func(*http.Request) (*http.Response, error) {
+					panic("bad round tripper")
+				})
+			})},
+			wantErr:    true,
+			wantErrMsg: "GET /v1/organisation/accounts: recovered from a panic: bad round tripper",
+		},
+		{
+			name: "Failed to get when the credentials panic",
+			opts: []Option{WithCredentials(CredentialsFunc(func(*http.Request) error {
+				panic(errBadHook)
+			}))},
+			wantErr:    true,
+			wantErrMsg: "GET /v1/organisation/accounts: recovered from a panic: bad hook",
+			wantIs:     errBadHook,
+		},
+		{
+			name: "Failed to get when the client trace panics",
+			opts: []Option{WithClientTrace(func(*http.Request) *httptrace.ClientTrace {
+				panic("bad trace")
+			})},
+			wantErr:    true,
+			wantErrMsg: "GET /v1/organisation/accounts: recovered from a panic: bad trace",
+		},
+		{
+			name: "Successfully gets when the telemetry hook panics",
+			opts: []Option{WithTelemetryHook(func(RequestTiming) {
+				panic("bad telemetry hook")
+			})},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(`{}`))
+			}))
+			defer server.Close()
+
+			client, err := NewClient(server.URL, time.Second, tt.opts...)
+			require.NoError(t, err)
+
+			_, err = client.Get(context.Background(), "/v1/organisation/accounts", nil)
+			if !tt.wantErr {
+				require.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			assert.EqualError(t, err, tt.wantErrMsg)
+
+			var panicErr *PanicError
+			require.True(t, errors.As(err, &panicErr))
+			assert.NotEmpty(t, panicErr.Stack)
+			if tt.wantIs != nil {
+				assert.ErrorIs(t, err, tt.wantIs)
+			}
+		})
+	}
+}
+
+func TestClientRecoversThePanicOfTheAuthRefresh(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, time.Second, WithAuthRefresh(func(context.Context) error {
+		panic("bad refresh")
+	}))
+	require.NoError(t, err)
+
+	_, err = client.Get(context.Background(), "/v1/organisation/accounts", nil)
+	require.Error(t, err)
+
+	var panicErr *PanicError
+	assert.False(t, errors.As(err, &panicErr))
+}
@@ -0,0 +1,91 @@
+package httputils
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// maxDrainSize is the most of an unread body drained before closing it, so the connection can be reused without
+// reading an unexpectedly large body
+const maxDrainSize = 64 << 10
+
+// closeBody drains what is left of the body of the response and closes it, so its connection goes back to the pool
+func closeBody(response *http.Response) {
+	if response.Body == nil {
+		return
+	}
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(response.Body, maxDrainSize))
+	response.Body.Close()
+}
+
+// readResponse reads the body of the response, an empty body is not read at all
+func (c Client) readResponse(response *http.Response) ([]byte, error) {
+	if isEmptyBody(response) {
+		return nil, nil
+	}
+
+	return c.readBody(response)
+}
+
+// isEmptyBody tells if the response has no body, the body given by the http client is never http.NoBody once it has a
+// timeout so the status code and the content length header are checked as well
+func isEmptyBody(response *http.Response) bool {
+	switch {
+	case response.Body == nil, response.Body == http.NoBody:
+		return true
+	case response.StatusCode == http.StatusNoContent, response.StatusCode == http.StatusNotModified:
+		return true
+	default:
+		return response.Header.Get("Content-Length") == "0"
+	}
+}
+
+// responseError decodes the error of a failed response, an empty body is described by the status text
+func (c Client) responseError(statusCode int, respBody []byte) error {
+	if len(respBody) == 0 {
+		return &ResponseError{
+			ErrorMessage: strings.ToLower(http.StatusText(statusCode)),
+			StatusCode:   statusCode,
+		}
+	}
+
+	var errRes ResponseError
+	if err := c.respUnmarshaller(respBody, &errRes); err != nil {
+		return err
+	}
+
+	errRes.StatusCode = statusCode
+	return &errRes
+}
+
+// headerRequestID is the header carrying the id of the request, sent by the callers and echoed by form3
+const headerRequestID = "X-Request-Id"
+
+// Response is the envelope of a response of the API, the body along with the metadata of the exchange
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+	// RequestID is the id of the request, the one echoed by the API or otherwise the one sent, empty when neither
+	RequestID string
+	// Duration is how long the exchange took, from sending the request to reading the body
+	Duration time.Duration
+}
+
+func newResponse(request *http.Request, response *http.Response, body []byte, started time.Time) *Response {
+	requestID := response.Header.Get(headerRequestID)
+	if requestID == "" {
+		requestID = request.Header.Get(headerRequestID)
+	}
+
+	return &Response{
+		StatusCode: response.StatusCode,
+		Header:     response.Header,
+		Body:       body,
+		RequestID:  requestID,
+		Duration:   time.Since(started),
+	}
+}
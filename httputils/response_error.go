@@ -1,13 +1,109 @@
 package httputils
 
-import "fmt"
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// validationFailurePrefix is the line form3 nests the field violations of a bad request under
+const validationFailurePrefix = "validation failure list:"
 
 // ResponseError is the representation of an error coming from the form3 api with the status code
 type ResponseError struct {
-	ErrorMessage string `json:"error_message,omitempty"`
+	ErrorMessage string       `json:"error_message,omitempty"`
+	ErrorCode    string       `json:"error_code,omitempty"`
+	FieldErrors  []FieldError `json:"field_errors,omitempty"`
 	StatusCode   int
 }
 
+// FieldError is a violation of a field of the request payload, so it can be mapped back to a form field
+type FieldError struct {
+	// Pointer is the JSON pointer of the field in the request payload, such as /data/attributes/country
+	Pointer string `json:"pointer"`
+	// Message is the violation, such as "is required"
+	Message string `json:"message"`
+}
+
+// Path returns the dotted path of the field, such as data.attributes.country
+func (fieldErr FieldError) Path() string {
+	return strings.ReplaceAll(strings.TrimPrefix(fieldErr.Pointer, "/"), "/", ".")
+}
+
 func (err *ResponseError) Error() string {
 	return fmt.Sprintf("api failure with status code %d and message: %s", err.StatusCode, err.ErrorMessage)
 }
+
+// UnmarshalJSON decodes the error response, when it has no structured field errors they are parsed from the
+// validation failure list of the error message
+func (err *ResponseError) UnmarshalJSON(data []byte) error {
+	type responseError ResponseError
+	if unmarshalErr := json.Unmarshal(data, (*responseError)(err)); unmarshalErr != nil {
+		return unmarshalErr
+	}
+
+	if len(err.FieldErrors) == 0 {
+		err.FieldErrors = parseFieldErrors(err.ErrorMessage)
+	}
+
+	return nil
+}
+
+// parseFieldErrors parses the lines of a validation failure list, such as "country in body is required", the lines
+// not naming a field of the body are ignored
+func parseFieldErrors(message string) []FieldError {
+	if !strings.HasPrefix(message, validationFailurePrefix) {
+		return nil
+	}
+
+	var fieldErrs []FieldError
+	for _, line := range strings.Split(message, "\n") {
+		field, violation, ok := strings.Cut(strings.TrimSpace(line), " in body ")
+		if !ok || field == "" {
+			continue
+		}
+
+		fieldErrs = append(fieldErrs, FieldError{
+			Pointer: "/" + strings.ReplaceAll(field, ".", "/"),
+			Message: violation,
+		})
+	}
+
+	return fieldErrs
+}
+
+// StatusError is a response of form3 with a status code the client has no error for, such as a 5xx or a 429, so the
+// callers can tell a server failure worth retrying by its status code
+type StatusError struct {
+	StatusCode int
+}
+
+func (err *StatusError) Error() string {
+	return fmt.Sprintf("unexpected status code %d", err.StatusCode)
+}
+
+// RequestError is a failure of a request carrying the method and the resource path it was sent to, along with the
+// operation of the resource client which sent it, such as create, when the resource client sets it
+type RequestError struct {
+	Op     string
+	Method string
+	Path   string
+	Err    error
+}
+
+func (err *RequestError) Error() string {
+	return fmt.Sprintf("%s %s: %s", err.Method, err.Path, err.Err)
+}
+
+func (err *RequestError) Unwrap() error {
+	return err.Err
+}
+
+// newRequestError wraps the failure of the request with its method and resource path, nil stays nil
+func newRequestError(method, resourcePath string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &RequestError{Method: method, Path: resourcePath, Err: err}
+}
@@ -0,0 +1,55 @@
+package httputils
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResponseErrorFieldErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want *ResponseError
+	}{
+		{
+			name: "Successfully decodes the structured field errors",
+			body: `{"error_message": "validation failure", "error_code": "VALIDATION_FAILURE", "field_errors": [{"pointer": "/data/attributes/country", "message": "is required"}]}`,
+			want: &ResponseError{
+				ErrorMessage: "validation failure",
+				ErrorCode:    "VALIDATION_FAILURE",
+				FieldErrors:  []FieldError{{Pointer: "/data/attributes/country", Message: "is required"}},
+			},
+		},
+		{
+			name: "Successfully parses the field errors of a validation failure list",
+			body: `{"error_message": "validation failure list:\nvalidation failure list:\ncountry in body is required\nattributes.bic in body should match '^([A-Z]{6}[A-Z0-9]{2}|[A-Z]{6}[A-Z0-9]{5})$'"}`,
+			want: &ResponseError{
+				ErrorMessage: "validation failure list:\nvalidation failure list:\ncountry in body is required\nattributes.bic in body should match '^([A-Z]{6}[A-Z0-9]{2}|[A-Z]{6}[A-Z0-9]{5})$'",
+				FieldErrors: []FieldError{
+					{Pointer: "/country", Message: "is required"},
+					{Pointer: "/attributes/bic", Message: "should match '^([A-Z]{6}[A-Z0-9]{2}|[A-Z]{6}[A-Z0-9]{5})$'"},
+				},
+			},
+		},
+		{
+			name: "Successfully decodes an error without field errors",
+			body: `{"error_message": "Account cannot be created as it violates a duplicate constraint"}`,
+			want: &ResponseError{ErrorMessage: "Account cannot be created as it violates a duplicate constraint"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got ResponseError
+			require.NoError(t, json.Unmarshal([]byte(tt.body), &got))
+			assert.Equal(t, tt.want, &got)
+		})
+	}
+}
+
+func TestFieldErrorPath(t *testing.T) {
+	assert.Equal(t, "data.attributes.country", FieldError{Pointer: "/data/attributes/country"}.Path())
+}
@@ -0,0 +1,135 @@
+package httputils
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientResponseEnvelope(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if echo := r.URL.Query().Get("echo"); echo != "" {
+			w.Header().Set("X-Request-Id", echo)
+		}
+
+		switch r.Method {
+		case http.MethodPost:
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"data":{}}`))
+		case http.MethodPatch:
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte(`{"error_message":"invalid version"}`))
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			_, _ = w.Write([]byte(`{"data":[]}`))
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, time.Second)
+	require.NoError(t, err)
+	ctx := context.Background()
+	requestHeader := http.Header{"X-Request-Id": []string{"sent-id"}}
+
+	tests := []struct {
+		name           string
+		call           func() (*Response, error)
+		wantStatusCode int
+		wantBody       string
+		wantRequestID  string
+		wantErrMsg     string
+	}{
+		{
+			name: "Successfully returns the envelope of a post with the request id sent",
+			call: func() (*Response, error) {
+				return client.PostResponse(ctx, "/v1/organisation/accounts", []byte(`{}`), requestHeader)
+			},
+			wantStatusCode: http.StatusCreated,
+			wantBody:       `{"data":{}}`,
+			wantRequestID:  "sent-id",
+		},
+		{
+			name: "Successfully returns the envelope of a get with the request id echoed",
+			call: func() (*Response, error) {
+				return client.GetResponse(ctx, "/v1/organisation/accounts", map[string]string{"echo": "echoed-id"})
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody:       `{"data":[]}`,
+			wantRequestID:  "echoed-id",
+		},
+		{
+			name: "Successfully returns the envelope of a head without a body",
+			call: func() (*Response, error) {
+				return client.HeadResponse(ctx, "/v1/organisation/accounts/1", nil)
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name: "Successfully returns the envelope of a delete",
+			call: func() (*Response, error) {
+				return client.DeleteResponse(ctx, "/v1/organisation/accounts/1", map[string]string{"version": "0"})
+			},
+			wantStatusCode: http.StatusNoContent,
+		},
+		{
+			name: "Failed to patch returning the envelope along with the error",
+			call: func() (*Response, error) {
+				return client.PatchResponse(ctx, "/v1/organisation/accounts/1", []byte(`{}`), requestHeader)
+			},
+			wantStatusCode: http.StatusConflict,
+			wantBody:       `{"error_message":"invalid version"}`,
+			wantRequestID:  "sent-id",
+			wantErrMsg:     "PATCH /v1/organisation/accounts/1: api failure with status code 409 and message: invalid version",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := tt.call()
+			if tt.wantErrMsg != "" {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.wantErrMsg)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.NotNil(t, response)
+			assert.Equal(t, tt.wantStatusCode, response.StatusCode)
+			assert.Equal(t, tt.wantBody, string(response.Body))
+			assert.Equal(t, tt.wantRequestID, response.RequestID)
+			assert.NotNil(t, response.Header)
+			assert.Positive(t, response.Duration)
+		})
+	}
+}
+
+func TestClientRequestError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, time.Second)
+	require.NoError(t, err)
+
+	_, err = client.Get(context.Background(), "/v1/organisation/accounts/1", map[string]string{"version": "0"})
+	require.Error(t, err)
+	assert.EqualError(t, err, "GET /v1/organisation/accounts/1: api failure with status code 404 and message: not found")
+
+	var requestErr *RequestError
+	require.True(t, errors.As(err, &requestErr))
+	assert.Equal(t, http.MethodGet, requestErr.Method)
+	assert.Equal(t, "/v1/organisation/accounts/1", requestErr.Path)
+	assert.Empty(t, requestErr.Op)
+
+	var responseErr *ResponseError
+	require.True(t, errors.As(err, &responseErr))
+	assert.Equal(t, http.StatusNotFound, responseErr.StatusCode)
+}
@@ -0,0 +1,148 @@
+package httputils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Secret is the key material or the token fetched from a secret store
+type Secret struct {
+	Value []byte
+	// ExpiresAt is when the secret must be fetched again, such as the end of the lease of vault, zero when it does
+	// not expire
+	ExpiresAt time.Time
+}
+
+// SecretProvider fetches the secrets by name, such as from vault or a kms, so the credentials don't need the raw key
+// material at construction time
+type SecretProvider interface {
+	Secret(ctx context.Context, name string) (Secret, error)
+}
+
+// SecretProviderFunc is a function fetching the secrets
+type SecretProviderFunc func(ctx context.Context, name string) (Secret, error)
+
+// Secret calls the function with the name
+func (f SecretProviderFunc) Secret(ctx context.Context, name string) (Secret, error) {
+	return f(ctx, name)
+}
+
+type cachedSecret struct {
+	secret    Secret
+	expiresAt time.Time
+}
+
+// SecretCache caches the secrets of a provider until they expire or for the ttl, the first of both, Rotate drops a
+// secret so the next use fetches it from the provider again
+type SecretCache struct {
+	provider SecretProvider
+	ttl      time.Duration
+	now      func() time.Time
+
+	mu      sync.Mutex
+	secrets map[string]cachedSecret
+}
+
+// SecretCacheOption configures the secret cache
+type SecretCacheOption func(*SecretCache) error
+
+// WithSecretClock sets the clock telling when the cached secrets expire, the system clock is used by default, a
+// manual clock makes the tests fast-forward the expiry
+func WithSecretClock(clock Clock) SecretCacheOption {
+	return func(cache *SecretCache) error {
+		if clock == nil {
+			return errors.New("invalid clock, it must not be nil")
+		}
+
+		cache.now = clock.Now
+		return nil
+	}
+}
+
+// NewSecretCache creates the cache of the secrets of the provider, a zero ttl keeps the secrets until they expire
+func NewSecretCache(provider SecretProvider, ttl time.Duration, opts ...SecretCacheOption) (*SecretCache, error) {
+	if provider == nil {
+		return nil, errors.New("invalid secret provider, it must not be nil")
+	}
+
+	if ttl < 0 {
+		return nil, fmt.Errorf("invalid secret ttl %s, it must not be negative", ttl)
+	}
+
+	cache := &SecretCache{
+		provider: provider,
+		ttl:      ttl,
+		now:      time.Now,
+		secrets:  map[string]cachedSecret{},
+	}
+
+	for _, opt := range opts {
+		if err := opt(cache); err != nil {
+			return nil, fmt.Errorf("%w; invalid option", err)
+		}
+	}
+
+	return cache, nil
+}
+
+// Secret returns the cached secret, fetching it from the provider when it is not cached or it expired
+func (cache *SecretCache) Secret(ctx context.Context, name string) (Secret, error) {
+	now := cache.now()
+
+	cache.mu.Lock()
+	cached, ok := cache.secrets[name]
+	cache.mu.Unlock()
+	if ok && (cached.expiresAt.IsZero() || now.Before(cached.expiresAt)) {
+		return cached.secret, nil
+	}
+
+	secret, err := cache.provider.Secret(ctx, name)
+	if err != nil {
+		return Secret{}, fmt.Errorf("%w; unable to fetch the secret %q", err, name)
+	}
+
+	expiresAt := secret.ExpiresAt
+	if cache.ttl > 0 && (expiresAt.IsZero() || now.Add(cache.ttl).Before(expiresAt)) {
+		expiresAt = now.Add(cache.ttl)
+	}
+
+	cache.mu.Lock()
+	cache.secrets[name] = cachedSecret{secret: secret, expiresAt: expiresAt}
+	cache.mu.Unlock()
+
+	return secret, nil
+}
+
+// Rotate drops the cached secret so the next use fetches the rotated one from the provider
+func (cache *SecretCache) Rotate(name string) {
+	cache.mu.Lock()
+	delete(cache.secrets, name)
+	cache.mu.Unlock()
+}
+
+// Refresh returns the auth refresh rotating the secret and fetching it again, see WithAuthRefresh, so a 401 caused
+// by a revoked token is retried with the one the provider holds now
+func (cache *SecretCache) Refresh(name string) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		cache.Rotate(name)
+		_, err := cache.Secret(ctx, name)
+		return err
+	}
+}
+
+// BearerTokenSecret returns the credentials authenticating the requests with the bearer token of the secret named,
+// fetched from the provider on every request so it is meant to be a SecretCache
+func BearerTokenSecret(provider SecretProvider, name string) Credentials {
+	return CredentialsFunc(func(request *http.Request) error {
+		secret, err := provider.Secret(request.Context(), name)
+		if err != nil {
+			return err
+		}
+
+		return BearerToken(secret.Value).Authenticate(request)
+	})
+}
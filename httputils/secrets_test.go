@@ -0,0 +1,157 @@
+package httputils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// versionedProvider returns a new version of the secret on every fetch
+type versionedProvider struct {
+	fetches   int
+	expiresAt time.Time
+	err       error
+}
+
+func (provider *versionedProvider) Secret(_ context.Context, name string) (Secret, error) {
+	if provider.err != nil {
+		return Secret{}, provider.err
+	}
+
+	provider.fetches++
+	return Secret{Value: []byte(fmt.Sprintf("%s-%d", name, provider.fetches)), ExpiresAt: provider.expiresAt}, nil
+}
+
+func TestSecretCache(t *testing.T) {
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		ttl       time.Duration
+		expiresAt time.Time
+		elapsed   time.Duration
+		rotate    bool
+		want      string
+	}{
+		{
+			name:    "Successfully serves the cached secret without expiry",
+			elapsed: time.Hour,
+			want:    "token-1",
+		},
+		{
+			name:    "Successfully serves the cached secret within the ttl",
+			ttl:     time.Minute,
+			elapsed: 30 * time.Second,
+			want:    "token-1",
+		},
+		{
+			name:    "Successfully fetches the secret again after the ttl",
+			ttl:     time.Minute,
+			elapsed: time.Minute,
+			want:    "token-2",
+		},
+		{
+			name:      "Successfully fetches the secret again when it expires before the ttl",
+			ttl:       time.Hour,
+			expiresAt: start.Add(time.Minute),
+			elapsed:   2 * time.Minute,
+			want:      "token-2",
+		},
+		{
+			name:      "Successfully fetches the secret again when it expires without a ttl",
+			expiresAt: start.Add(time.Minute),
+			elapsed:   2 * time.Minute,
+			want:      "token-2",
+		},
+		{
+			name:   "Successfully fetches the secret again once rotated",
+			rotate: true,
+			want:   "token-2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := NewManualClock(start)
+			cache, err := NewSecretCache(&versionedProvider{expiresAt: tt.expiresAt}, tt.ttl, WithSecretClock(clock))
+			require.NoError(t, err)
+
+			secret, err := cache.Secret(context.Background(), "token")
+			require.NoError(t, err)
+			assert.Equal(t, "token-1", string(secret.Value))
+
+			clock.Advance(tt.elapsed)
+			if tt.rotate {
+				cache.Rotate("token")
+			}
+
+			secret, err = cache.Secret(context.Background(), "token")
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(secret.Value))
+		})
+	}
+}
+
+func TestSecretCacheWithFailures(t *testing.T) {
+	_, err := NewSecretCache(nil, 0)
+	require.Error(t, err)
+	assert.EqualError(t, err, "invalid secret provider, it must not be nil")
+
+	_, err = NewSecretCache(&versionedProvider{}, -time.Second)
+	require.Error(t, err)
+	assert.EqualError(t, err, "invalid secret ttl -1s, it must not be negative")
+
+	_, err = NewSecretCache(&versionedProvider{}, 0, WithSecretClock(nil))
+	require.Error(t, err)
+	assert.EqualError(t, err, "invalid clock, it must not be nil; invalid option")
+
+	cache, err := NewSecretCache(SecretProviderFunc(func(context.Context, string) (Secret, error) {
+		return Secret{}, errors.New("vault is sealed")
+	}), 0)
+	require.NoError(t, err)
+
+	_, err = cache.Secret(context.Background(), "token")
+	require.Error(t, err)
+	assert.EqualError(t, err, `vault is sealed; unable to fetch the secret "token"`)
+}
+
+func TestClientWithBearerTokenSecret(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token-2" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	provider := &versionedProvider{}
+	cache, err := NewSecretCache(provider, 0)
+	require.NoError(t, err)
+
+	client, err := NewClient(server.URL, time.Second,
+		WithCredentials(BearerTokenSecret(cache, "token")),
+		WithAuthRefresh(cache.Refresh("token")),
+	)
+	require.NoError(t, err)
+
+	_, err = client.Get(context.Background(), "/v1/organisation/accounts", nil)
+	require.NoError(t, err, "the revoked token is rotated on the 401")
+
+	_, err = client.Get(context.Background(), "/v1/organisation/accounts", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2, provider.fetches, "the rotated token is cached")
+
+	provider.err = errors.New("vault is sealed")
+	cache.Rotate("token")
+	_, err = client.Get(context.Background(), "/v1/organisation/accounts", nil)
+	require.Error(t, err)
+	assert.EqualError(t, err, `GET /v1/organisation/accounts: vault is sealed; unable to fetch the secret "token"; unable to authenticate the request`)
+}
@@ -0,0 +1,156 @@
+package httputils
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net/http"
+	"net/http/httptrace"
+	"sync"
+	"time"
+)
+
+// RequestTiming is the latency breakdown of a request, so the slowness can be attributed to the network or to form3
+type RequestTiming struct {
+	Method     string
+	Path       string
+	StatusCode int
+	// DNS is the time resolving the host, zero when the connection was reused
+	DNS time.Duration
+	// Connect is the time dialing the host, zero when the connection was reused
+	Connect time.Duration
+	// TLSHandshake is the time of the TLS handshake, zero when the connection was reused
+	TLSHandshake time.Duration
+	// TimeToFirstByte is the time from sending the request to the first byte of the response
+	TimeToFirstByte time.Duration
+	// Total is the time from sending the request to receiving the response headers
+	Total time.Duration
+	// ReusedConn tells if a keep-alive connection was reused
+	ReusedConn bool
+	Err        error
+}
+
+type timingObserverKey struct{}
+
+// ContextWithTimingObserver returns a context reporting the timing of the requests made with it to the observer, on
+// top of the telemetry hook of the client
+func ContextWithTimingObserver(ctx context.Context, observe func(RequestTiming)) context.Context {
+	return context.WithValue(ctx, timingObserverKey{}, observe)
+}
+
+// WithTelemetryHook registers the hook called with the timing of every request of the client
+func WithTelemetryHook(hook func(RequestTiming)) Option {
+	return func(c *Client) error {
+		c.hooks.onTiming = hook
+		return nil
+	}
+}
+
+// WithClientTrace attaches the trace returned by newTrace to every request sent by the client, such as the one of a
+// tracing library or one logging the connection events, its callbacks are called along the ones of the telemetry and
+// the abort hooks, a nil trace attaches nothing
+func WithClientTrace(newTrace func(*http.Request) *httptrace.ClientTrace) Option {
+	return func(c *Client) error {
+		if newTrace == nil {
+			return errors.New("invalid client trace, it must not be nil")
+		}
+
+		c.hooks.onTrace = newTrace
+		return nil
+	}
+}
+
+// attachTrace attaches the client trace of the hooks to the request
+func (c Client) attachTrace(request *http.Request) *http.Request {
+	if c.hooks.onTrace == nil {
+		return request
+	}
+
+	trace := c.hooks.onTrace(request)
+	if trace == nil {
+		return request
+	}
+
+	return request.WithContext(httptrace.WithClientTrace(request.Context(), trace))
+}
+
+// timingTracker records the httptrace events of a request
+type timingTracker struct {
+	mu       sync.Mutex
+	timing   RequestTiming
+	started  time.Time
+	dnsStart time.Time
+	dialed   time.Time
+	tlsStart time.Time
+	observe  func(RequestTiming)
+	hook     func(RequestTiming)
+}
+
+// traceTiming attaches a httptrace recording the timing of the request, only when a hook or an observer is set
+func (c Client) traceTiming(request *http.Request) (*http.Request, *timingTracker) {
+	observe, _ := request.Context().Value(timingObserverKey{}).(func(RequestTiming))
+	if observe == nil && c.hooks.onTiming == nil {
+		return request, nil
+	}
+
+	tracker := &timingTracker{
+		timing:  RequestTiming{Method: request.Method, Path: request.URL.Path},
+		started: time.Now(),
+		observe: observe,
+		hook:    c.hooks.onTiming,
+	}
+	trace := &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) { tracker.record(func(now time.Time) { tracker.dnsStart = now }) },
+		DNSDone: func(httptrace.DNSDoneInfo) {
+			tracker.record(func(now time.Time) { tracker.timing.DNS = now.Sub(tracker.dnsStart) })
+		},
+		ConnectStart: func(string, string) { tracker.record(func(now time.Time) { tracker.dialed = now }) },
+		ConnectDone: func(string, string, error) {
+			tracker.record(func(now time.Time) { tracker.timing.Connect = now.Sub(tracker.dialed) })
+		},
+		TLSHandshakeStart: func() { tracker.record(func(now time.Time) { tracker.tlsStart = now }) },
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			tracker.record(func(now time.Time) { tracker.timing.TLSHandshake = now.Sub(tracker.tlsStart) })
+		},
+		GotConn: func(info httptrace.GotConnInfo) {
+			tracker.record(func(time.Time) { tracker.timing.ReusedConn = info.Reused })
+		},
+		GotFirstResponseByte: func() {
+			tracker.record(func(now time.Time) { tracker.timing.TimeToFirstByte = now.Sub(tracker.started) })
+		},
+	}
+
+	return request.WithContext(httptrace.WithClientTrace(request.Context(), trace)), tracker
+}
+
+func (t *timingTracker) record(set func(now time.Time)) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	set(time.Now())
+}
+
+// finish reports the timing of the request once its response is received or it failed
+func (t *timingTracker) finish(response *http.Response, err error) {
+	if t == nil {
+		return
+	}
+	defer ignorePanic()
+
+	t.mu.Lock()
+	timing := t.timing
+	t.mu.Unlock()
+
+	timing.Total = time.Since(t.started)
+	timing.Err = err
+	if response != nil {
+		timing.StatusCode = response.StatusCode
+	}
+
+	if t.hook != nil {
+		t.hook(timing)
+	}
+	if t.observe != nil {
+		t.observe(timing)
+	}
+}
@@ -0,0 +1,114 @@
+package httputils
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httptrace"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientTelemetry(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(5 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	var hooked, observed []RequestTiming
+	client, err := NewClient(server.URL, time.Second, WithTelemetryHook(func(timing RequestTiming) {
+		hooked = append(hooked, timing)
+	}))
+	require.NoError(t, err)
+
+	ctx := ContextWithTimingObserver(context.Background(), func(timing RequestTiming) {
+		observed = append(observed, timing)
+	})
+
+	for i := 0; i < 2; i++ {
+		_, err = client.Get(ctx, "/v1/organisation/accounts", nil)
+		require.NoError(t, err)
+	}
+
+	require.Len(t, hooked, 2)
+	assert.Equal(t, hooked, observed)
+
+	first, second := hooked[0], hooked[1]
+	assert.Equal(t, http.MethodGet, first.Method)
+	assert.Equal(t, "/v1/organisation/accounts", first.Path)
+	assert.Equal(t, http.StatusOK, first.StatusCode)
+	assert.False(t, first.ReusedConn)
+	assert.Greater(t, int64(first.Connect), int64(0))
+	assert.GreaterOrEqual(t, int64(first.TimeToFirstByte), int64(5*time.Millisecond))
+	assert.GreaterOrEqual(t, int64(first.Total), int64(first.TimeToFirstByte))
+	assert.True(t, second.ReusedConn)
+	assert.Equal(t, time.Duration(0), second.Connect)
+}
+
+func TestClientTelemetryOfAFailedRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close()
+
+	var hooked []RequestTiming
+	client, err := NewClient(server.URL, time.Second, WithTelemetryHook(func(timing RequestTiming) {
+		hooked = append(hooked, timing)
+	}))
+	require.NoError(t, err)
+
+	_, err = client.Get(context.Background(), "/v1/organisation/accounts", nil)
+	require.Error(t, err)
+	require.Len(t, hooked, 1)
+	assert.Error(t, hooked[0].Err)
+	assert.Equal(t, 0, hooked[0].StatusCode)
+}
+
+func TestClientTrace(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	var events []string
+	var hooked []RequestTiming
+	client, err := NewClient(
+		server.URL,
+		time.Second,
+		WithTelemetryHook(func(timing RequestTiming) {
+			hooked = append(hooked, timing)
+		}),
+		WithClientTrace(func(request *http.Request) *httptrace.ClientTrace {
+			if request.Method != http.MethodGet {
+				return nil
+			}
+
+			return &httptrace.ClientTrace{
+				ConnectDone: func(string, string, error) { events = append(events, "connected "+request.URL.Path) },
+				GotConn: func(info httptrace.GotConnInfo) {
+					events = append(events, fmt.Sprintf("got conn reused=%t", info.Reused))
+				},
+			}
+		}),
+	)
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		_, err = client.Get(context.Background(), "/v1/organisation/accounts", nil)
+		require.NoError(t, err)
+	}
+
+	err = client.Delete(context.Background(), "/v1/organisation/accounts", nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"connected /v1/organisation/accounts", "got conn reused=false", "got conn reused=true"}, events)
+	assert.Len(t, hooked, 3, "the telemetry hook must still be called along the client trace")
+
+	_, err = NewClient(server.URL, time.Second, WithClientTrace(nil))
+	require.Error(t, err)
+	assert.EqualError(t, err, "invalid client trace, it must not be nil; invalid option")
+}
@@ -0,0 +1,49 @@
+package httputils
+
+import (
+	"fmt"
+	"strings"
+)
+
+// APIVersion is the version of the form3 api prefixing the paths of the resources, such as v1 in /v1/health, it may
+// hold more segments when a gateway rewrites the paths, such as form3/v1
+type APIVersion string
+
+const (
+	// APIVersionV1 is the version 1 of the form3 api
+	APIVersionV1 APIVersion = "v1"
+	// DefaultAPIVersion is the version of the form3 api used by the clients unless configured otherwise
+	DefaultAPIVersion = APIVersionV1
+)
+
+// Validate checks the version is not empty and is not surrounded by slashes
+func (version APIVersion) Validate() error {
+	if version == "" || strings.HasPrefix(string(version), "/") || strings.HasSuffix(string(version), "/") {
+		return fmt.Errorf("invalid api version %q, it must not be empty nor start or end with a slash", version)
+	}
+
+	return nil
+}
+
+// Path prefixes the path of a resource with the version
+func (version APIVersion) Path(path string) string {
+	return "/" + string(version) + path
+}
+
+// WithAPIVersion sets the version of the form3 api of the paths built by the client, such as the health check one,
+// it is the one place the version is set, the account clients built on the client follow it
+func WithAPIVersion(version APIVersion) Option {
+	return func(c *Client) error {
+		if err := version.Validate(); err != nil {
+			return err
+		}
+
+		c.apiVersion = version
+		return nil
+	}
+}
+
+// APIVersion returns the version of the form3 api of the client
+func (c Client) APIVersion() APIVersion {
+	return c.apiVersion
+}
@@ -1,17 +1,15 @@
 package integration_tests
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"io/ioutil"
 	"log"
-	"net"
-	"net/url"
 	"os"
 	"testing"
 	"time"
 
 	"renatoaraujo/form3-account-api-client/accounts"
+	"renatoaraujo/form3-account-api-client/accounts/accountstest"
 	"renatoaraujo/form3-account-api-client/httputils"
 
 	"github.com/google/uuid"
@@ -27,63 +25,54 @@ func getEnv(key, fallback string) string {
 }
 
 func TestMain(m *testing.M) {
-	parsedUri, err := url.ParseRequestURI(getEnv("API_BASE_URI", "https://api.form3.tech"))
+	httpClient, err := httputils.NewClient(getEnv("API_BASE_URI", "https://api.form3.tech"), time.Second)
 	if err != nil {
 		panic("failed to parse the base uri, please check your environment variables")
 	}
 
-	log.Println("checking if the host is available, this is to prevent running the tests without running the docker")
-	timeout := time.Duration(1) * time.Second
-	conn, err := net.DialTimeout("tcp", parsedUri.Host, timeout)
-	if err != nil {
+	log.Println("checking if the api is healthy, this is to prevent running the tests without running the docker")
+	if err := httpClient.Ping(context.Background()); err != nil {
 		log.Println(err)
-		log.Println("host unreachable, skipping functional tests")
+		log.Println("api unavailable, skipping functional tests")
 		os.Exit(0)
 	}
-	defer conn.Close()
 
 	exitVal := m.Run()
 	os.Exit(exitVal)
 }
 
 func clientSetup() accounts.Client {
-	httpClient, _ := httputils.NewClient(getEnv("API_BASE_URI", "https://api.form3.tech"), 15)
+	httpClient, err := httputils.NewClient(getEnv("API_BASE_URI", "https://api.form3.tech"), 15*time.Second)
+	if err != nil {
+		panic("failed to create the http client")
+	}
 
-	return accounts.NewClient(httpClient)
+	client, err := accounts.NewClient(httpClient)
+	if err != nil {
+		panic("failed to create the account client")
+	}
+
+	return client
 }
 
 func createAccountResource(accountData *accounts.AccountData) (*accounts.AccountData, error) {
 	client := clientSetup()
 
-	return client.CreateResource(accountData)
+	return client.CreateResource(context.Background(), accountData)
 }
 
-func getCreateAccountData(accountID uuid.UUID) *accounts.AccountData {
-	accountData := loadAccountDataFromFileWithCustomID("./testdata/account_create_data.json", accountID)
-
-	return accountData
+func getCreateAccountData(accountID accounts.AccountID) *accounts.AccountData {
+	return accountstest.ValidGBAccount(accountstest.WithID(accountID))
 }
 
-func getFetchAccountData(accountID uuid.UUID) *accounts.AccountData {
-	accountData := loadAccountDataFromFileWithCustomID("./testdata/account_fetch_data.json", accountID)
-
-	return accountData
-}
-
-func loadAccountDataFromFileWithCustomID(file string, accountID uuid.UUID) *accounts.AccountData {
-	raw, err := ioutil.ReadFile(file)
-	if err != nil {
-		panic("failed to load the test data file")
-	}
-
-	var payload accounts.Payload
-	if err = json.Unmarshal(raw, &payload); err != nil {
-		panic("failed to unmarshal the test data file")
-	}
-
-	payload.Data.ID = accountID.String()
-
-	return payload.Data
+func getFetchAccountData(accountID accounts.AccountID) *accounts.AccountData {
+	return accountstest.ValidGBAccount(
+		accountstest.WithID(accountID),
+		accountstest.WithAttributes(func(attributes *accounts.AccountAttributes) {
+			status := accounts.StatusConfirmed
+			attributes.Status = &status
+		}),
+	)
 }
 
 func TestCreateAccount(t *testing.T) {
@@ -94,20 +83,22 @@ func TestCreateAccount(t *testing.T) {
 		{
 			name: "Successfully creates an account",
 			f: func(t *testing.T) {
-				accountID, err := uuid.NewUUID()
+				accountID, err := accounts.ParseAccountID(uuid.NewString())
 				require.NoError(t, err)
 
 				expectedAccountData := getCreateAccountData(accountID)
 				accountData, err := createAccountResource(expectedAccountData)
 				require.NoError(t, err)
 
+				assert.NotNil(t, accountData.CreatedOn)
+				accountData.CreatedOn, accountData.ModifiedOn = nil, nil
 				assert.Equal(t, expectedAccountData, accountData)
 			},
 		},
 		{
 			name: "Failed to create duplicated account",
 			f: func(t *testing.T) {
-				accountID, err := uuid.NewUUID()
+				accountID, err := accounts.ParseAccountID(uuid.NewString())
 				require.NoError(t, err)
 
 				_, err = createAccountResource(getCreateAccountData(accountID))
@@ -120,6 +111,23 @@ func TestCreateAccount(t *testing.T) {
 				)
 			},
 		},
+		{
+			name: "Successfully fetches the existing account instead of creating a duplicate",
+			f: func(t *testing.T) {
+				client := clientSetup()
+				accountID, err := accounts.ParseAccountID(uuid.NewString())
+				require.NoError(t, err)
+
+				accountData, created, err := client.FetchOrCreateResource(context.Background(), getCreateAccountData(accountID))
+				require.NoError(t, err)
+				assert.True(t, created)
+
+				existing, created, err := client.FetchOrCreateResource(context.Background(), getCreateAccountData(accountID))
+				require.NoError(t, err)
+				assert.False(t, created)
+				assert.Equal(t, accountData.ID, existing.ID)
+			},
+		},
 		{
 			name: "Failed to create with invalid account data",
 			f: func(t *testing.T) {
@@ -128,7 +136,7 @@ func TestCreateAccount(t *testing.T) {
 					ID: "invalid account id",
 				}
 
-				_, err := client.CreateResource(accountData)
+				_, err := client.CreateResource(context.Background(), accountData)
 				require.Error(t, err)
 			},
 		},
@@ -147,13 +155,13 @@ func TestFetchAccount(t *testing.T) {
 			name: "Successfully fetches an account",
 			f: func(t *testing.T) {
 				client := clientSetup()
-				accountID, err := uuid.NewUUID()
+				accountID, err := accounts.ParseAccountID(uuid.NewString())
 				require.NoError(t, err)
 
 				_, err = createAccountResource(getCreateAccountData(accountID))
 				require.NoError(t, err)
 
-				actual, err := client.FetchResource(accountID)
+				actual, err := client.FetchResource(context.Background(), accountID)
 				expected := getFetchAccountData(accountID)
 
 				assert.Equal(t, expected.ID, actual.ID)
@@ -186,10 +194,10 @@ func TestFetchAccount(t *testing.T) {
 			name: "Failed to fetch an account with an non existent id",
 			f: func(t *testing.T) {
 				client := clientSetup()
-				accountID, err := uuid.NewUUID()
+				accountID, err := accounts.ParseAccountID(uuid.NewString())
 				require.NoError(t, err)
 
-				_, err = client.FetchResource(accountID)
+				_, err = client.FetchResource(context.Background(), accountID)
 				require.Error(t, err)
 				require.EqualError(t, err,
 					fmt.Sprintf("api failure with status code 404 and message: record %s does not exist; unable to fetch resource", accountID.String()),
@@ -211,31 +219,62 @@ func TestDeleteAccount(t *testing.T) {
 			name: "Successfully deletes an account",
 			f: func(t *testing.T) {
 				client := clientSetup()
-				accountID, err := uuid.NewUUID()
+				accountID, err := accounts.ParseAccountID(uuid.NewString())
 				require.NoError(t, err)
 
 				accountData := getCreateAccountData(accountID)
 				createdAccountData, err := createAccountResource(accountData)
 				require.NoError(t, err)
 
-				err = client.DeleteResource(accountID, createdAccountData.Version)
+				err = client.DeleteResource(context.Background(), accountID, createdAccountData.Version)
 				require.NoError(t, err)
 
-				_, err = client.FetchResource(accountID)
+				_, err = client.FetchResource(context.Background(), accountID)
 				require.Error(t, err)
 				require.EqualError(t, err,
 					fmt.Sprintf("api failure with status code 404 and message: record %s does not exist; unable to fetch resource", accountID.String()),
 				)
 			},
 		},
+		{
+			name: "Failed to delete an account with a stale version",
+			f: func(t *testing.T) {
+				client := clientSetup()
+				accountID, err := accounts.ParseAccountID(uuid.NewString())
+				require.NoError(t, err)
+
+				createdAccountData, err := createAccountResource(getCreateAccountData(accountID))
+				require.NoError(t, err)
+
+				err = client.DeleteResource(context.Background(), accountID, createdAccountData.Version+1)
+				require.Error(t, err)
+
+				var conflictErr *accounts.VersionConflictError
+				require.ErrorAs(t, err, &conflictErr)
+			},
+		},
+		{
+			name: "Successfully deletes the current version of an account",
+			f: func(t *testing.T) {
+				client := clientSetup()
+				accountID, err := accounts.ParseAccountID(uuid.NewString())
+				require.NoError(t, err)
+
+				_, err = createAccountResource(getCreateAccountData(accountID))
+				require.NoError(t, err)
+
+				err = client.DeleteResourceLatest(context.Background(), accountID)
+				require.NoError(t, err)
+			},
+		},
 		{
 			name: "Failed to delete an non existent account",
 			f: func(t *testing.T) {
 				client := clientSetup()
-				accountID, err := uuid.NewUUID()
+				accountID, err := accounts.ParseAccountID(uuid.NewString())
 				require.NoError(t, err)
 
-				err = client.DeleteResource(accountID, 0)
+				err = client.DeleteResource(context.Background(), accountID, 0)
 				require.Error(t, err)
 				require.EqualError(t, err, "api failure with status code 404 and message: not found; unable to delete resource")
 			},
@@ -245,3 +284,81 @@ func TestDeleteAccount(t *testing.T) {
 		t.Run(tt.name, tt.f)
 	}
 }
+
+func TestExistsAccount(t *testing.T) {
+	tests := []struct {
+		name string
+		f    func(t *testing.T)
+	}{
+		{
+			name: "Successfully tells that an account exists",
+			f: func(t *testing.T) {
+				client := clientSetup()
+				accountID, err := accounts.ParseAccountID(uuid.NewString())
+				require.NoError(t, err)
+
+				_, err = createAccountResource(getCreateAccountData(accountID))
+				require.NoError(t, err)
+
+				exists, err := client.ExistsResource(context.Background(), accountID)
+				require.NoError(t, err)
+				assert.True(t, exists)
+			},
+		},
+		{
+			name: "Successfully tells that an non existent account does not exist",
+			f: func(t *testing.T) {
+				client := clientSetup()
+				accountID, err := accounts.ParseAccountID(uuid.NewString())
+				require.NoError(t, err)
+
+				exists, err := client.ExistsResource(context.Background(), accountID)
+				require.NoError(t, err)
+				assert.False(t, exists)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, tt.f)
+	}
+}
+
+func TestListAccounts(t *testing.T) {
+	tests := []struct {
+		name string
+		f    func(t *testing.T)
+	}{
+		{
+			name: "Successfully lists the accounts matching the filters",
+			f: func(t *testing.T) {
+				client := clientSetup()
+				accountID, err := accounts.ParseAccountID(uuid.NewString())
+				require.NoError(t, err)
+
+				_, err = createAccountResource(getCreateAccountData(accountID))
+				require.NoError(t, err)
+
+				listed, err := client.ListResources(context.Background(), accounts.ListOptions{
+					Filters:  map[accounts.FilterField]string{accounts.FilterCountry: "GB"},
+					PageSize: 100,
+				})
+				require.NoError(t, err)
+				assert.NotEmpty(t, listed)
+			},
+		},
+		{
+			name: "Failed to list the accounts with an unknown filter",
+			f: func(t *testing.T) {
+				client := clientSetup()
+
+				_, err := client.ListResources(context.Background(), accounts.ListOptions{
+					Filters: map[accounts.FilterField]string{"name": "Samantha Holder"},
+				})
+				require.Error(t, err)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, tt.f)
+	}
+}
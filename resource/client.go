@@ -0,0 +1,193 @@
+// Package resource is the generic client of the form3 organisation resources, it encodes the paths and decodes the
+// payloads of a single request while the resource clients, such as accounts, add their policies on top of it
+package resource
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+)
+
+// HTTP is the http client used to send the requests, see httputils.Client
+type HTTP interface {
+	Delete(ctx context.Context, resourcePath string, query map[string]string) error
+	Get(ctx context.Context, resourcePath string, query map[string]string) ([]byte, error)
+	Patch(ctx context.Context, resourcePath string, body []byte, header http.Header) ([]byte, error)
+	Post(ctx context.Context, resourcePath string, body []byte, header http.Header) ([]byte, error)
+}
+
+// Payload represents the payload structure of a resource in the api requests and responses
+type Payload[T any] struct {
+	Data  *T     `json:"data"`
+	Links *Links `json:"links,omitempty"`
+}
+
+// ListPayload represents the payload structure of a list of resources in the api responses
+type ListPayload[T any] struct {
+	Data  []*T   `json:"data"`
+	Links *Links `json:"links,omitempty"`
+}
+
+// Links are the links to the resource and to the pages of a list
+type Links struct {
+	First string `json:"first,omitempty"`
+	Last  string `json:"last,omitempty"`
+	Next  string `json:"next,omitempty"`
+	Prev  string `json:"prev,omitempty"`
+	Self  string `json:"self,omitempty"`
+}
+
+// rawPayload is the payload of a resource with the data left encoded
+type rawPayload struct {
+	Data  json.RawMessage `json:"data"`
+	Links *Links          `json:"links,omitempty"`
+}
+
+// Client sends the requests of the resources of type T found under a base path
+type Client[T any] struct {
+	http      HTTP
+	basePath  string
+	unmarshal func([]byte, interface{}) error
+}
+
+// NewClient creates a client of the resources of type T found under the base path, the responses are decoded with
+// the unmarshal function
+func NewClient[T any](http HTTP, basePath string, unmarshal func([]byte, interface{}) error) *Client[T] {
+	return &Client[T]{
+		http:      http,
+		basePath:  basePath,
+		unmarshal: unmarshal,
+	}
+}
+
+// Path returns the path of the resource with the id
+func (c *Client[T]) Path(id uuid.UUID) string {
+	return fmt.Sprintf("%s/%s", c.basePath, id.String())
+}
+
+// Create sends the encoded payload creating a resource and decodes the created resource
+func (c *Client[T]) Create(ctx context.Context, body []byte, header http.Header) (*T, error) {
+	payload, err := c.CreatePayload(ctx, body, header)
+	if err != nil {
+		return nil, err
+	}
+
+	return payload.Data, nil
+}
+
+// CreatePayload sends the encoded payload creating a resource and decodes the payload of the created resource, along
+// with its links
+func (c *Client[T]) CreatePayload(ctx context.Context, body []byte, header http.Header) (*Payload[T], error) {
+	response, err := c.http.Post(ctx, c.basePath, body, header)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.decode(response)
+}
+
+// Fetch fetches the resource with the id
+func (c *Client[T]) Fetch(ctx context.Context, id uuid.UUID) (*T, error) {
+	payload, err := c.FetchPayload(ctx, id, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return payload.Data, nil
+}
+
+// FetchPayload fetches the payload of the resource with the id, along with its links, sending the query, such as the
+// sparse fieldsets
+func (c *Client[T]) FetchPayload(ctx context.Context, id uuid.UUID, query *Query) (*Payload[T], error) {
+	response, err := c.http.Get(ctx, c.Path(id), query.Params())
+	if err != nil {
+		return nil, err
+	}
+
+	return c.decode(response)
+}
+
+// FetchInto fetches the resource with the id decoding its data into v
+func (c *Client[T]) FetchInto(ctx context.Context, id uuid.UUID, v interface{}) error {
+	response, err := c.http.Get(ctx, c.Path(id), nil)
+	if err != nil {
+		return err
+	}
+
+	payload := &rawPayload{}
+	if err := c.unmarshal(response, payload); err != nil {
+		return fmt.Errorf("%w; failed to unmarshal response data", err)
+	}
+
+	if err := c.unmarshal(payload.Data, v); err != nil {
+		return fmt.Errorf("%w; failed to unmarshal response data", err)
+	}
+
+	return nil
+}
+
+// Update sends the encoded payload updating the resource with the id and decodes the updated resource
+func (c *Client[T]) Update(ctx context.Context, id uuid.UUID, body []byte, header http.Header) (*T, error) {
+	payload, err := c.UpdatePayload(ctx, id, body, header)
+	if err != nil {
+		return nil, err
+	}
+
+	return payload.Data, nil
+}
+
+// UpdatePayload sends the encoded payload updating the resource with the id and decodes the payload of the updated
+// resource, along with its links
+func (c *Client[T]) UpdatePayload(ctx context.Context, id uuid.UUID, body []byte, header http.Header) (*Payload[T], error) {
+	response, err := c.http.Patch(ctx, c.Path(id), body, header)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.decode(response)
+}
+
+// Delete deletes the resource with the id and version
+func (c *Client[T]) Delete(ctx context.Context, id uuid.UUID, version int) error {
+	return c.http.Delete(ctx, c.Path(id), map[string]string{
+		"version": strconv.Itoa(version),
+	})
+}
+
+// List lists the resources matching the query, a nil query lists the first page of all of them
+func (c *Client[T]) List(ctx context.Context, query *Query) ([]*T, error) {
+	page, err := c.ListPage(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return page.Data, nil
+}
+
+// ListPage lists the page of the resources matching the query along with the links to the other pages
+func (c *Client[T]) ListPage(ctx context.Context, query *Query) (*ListPayload[T], error) {
+	response, err := c.http.Get(ctx, c.basePath, query.Params())
+	if err != nil {
+		return nil, err
+	}
+
+	payload := &ListPayload[T]{}
+	if err := c.unmarshal(response, payload); err != nil {
+		return nil, fmt.Errorf("%w; failed to unmarshal response data", err)
+	}
+
+	return payload, nil
+}
+
+func (c *Client[T]) decode(response []byte) (*Payload[T], error) {
+	payload := &Payload[T]{}
+	if err := c.unmarshal(response, payload); err != nil {
+		return nil, fmt.Errorf("%w; failed to unmarshal response data", err)
+	}
+
+	return payload, nil
+}
@@ -0,0 +1,174 @@
+package resource
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const basePath = "/v1/organisation/things"
+
+type thing struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestClient(t *testing.T) {
+	id := uuid.MustParse("ad27e265-9605-4b4b-a0e5-3003ea9cc4dc")
+	path := basePath + "/" + id.String()
+	response := []byte(`{"data": {"id": "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc", "name": "first"}}`)
+	header := http.Header{"X-Source-System": []string{"core"}}
+
+	tests := []struct {
+		name       string
+		httpSetup  func(*mockHTTP)
+		call       func(*Client[thing]) (interface{}, error)
+		want       interface{}
+		wantErrMsg string
+	}{
+		{
+			name: "Successfully creates a resource",
+			httpSetup: func(client *mockHTTP) {
+				client.On("Post", mock.Anything, basePath, []byte(`{}`), header).Return(response, nil)
+			},
+			call: func(c *Client[thing]) (interface{}, error) {
+				return c.Create(context.Background(), []byte(`{}`), header)
+			},
+			want: &thing{ID: id.String(), Name: "first"},
+		},
+		{
+			name: "Failed to create a resource",
+			httpSetup: func(client *mockHTTP) {
+				client.On("Post", mock.Anything, basePath, mock.Anything, mock.Anything).Return(nil, errors.New("conflict"))
+			},
+			call: func(c *Client[thing]) (interface{}, error) {
+				return c.Create(context.Background(), []byte(`{}`), nil)
+			},
+			wantErrMsg: "conflict",
+		},
+		{
+			name: "Successfully fetches a resource",
+			httpSetup: func(client *mockHTTP) {
+				client.On("Get", mock.Anything, path, map[string]string(nil)).Return(response, nil)
+			},
+			call: func(c *Client[thing]) (interface{}, error) {
+				return c.Fetch(context.Background(), id)
+			},
+			want: &thing{ID: id.String(), Name: "first"},
+		},
+		{
+			name: "Successfully fetches a resource with a query",
+			httpSetup: func(client *mockHTTP) {
+				client.On("Get", mock.Anything, path, map[string]string{"fields[things]": "name"}).Return(response, nil)
+			},
+			call: func(c *Client[thing]) (interface{}, error) {
+				payload, err := c.FetchPayload(context.Background(), id, NewQuery().Fields("things", "name"))
+				return payload.Data, err
+			},
+			want: &thing{ID: id.String(), Name: "first"},
+		},
+		{
+			name: "Failed to decode a fetched resource",
+			httpSetup: func(client *mockHTTP) {
+				client.On("Get", mock.Anything, path, map[string]string(nil)).Return([]byte(`{`), nil)
+			},
+			call: func(c *Client[thing]) (interface{}, error) {
+				return c.Fetch(context.Background(), id)
+			},
+			wantErrMsg: "unexpected end of JSON input; failed to unmarshal response data",
+		},
+		{
+			name: "Successfully fetches a resource into a value",
+			httpSetup: func(client *mockHTTP) {
+				client.On("Get", mock.Anything, path, map[string]string(nil)).Return(response, nil)
+			},
+			call: func(c *Client[thing]) (interface{}, error) {
+				v := map[string]string{}
+				return v, c.FetchInto(context.Background(), id, &v)
+			},
+			want: map[string]string{"id": id.String(), "name": "first"},
+		},
+		{
+			name: "Successfully updates a resource",
+			httpSetup: func(client *mockHTTP) {
+				client.On("Patch", mock.Anything, path, []byte(`{}`), header).Return(response, nil)
+			},
+			call: func(c *Client[thing]) (interface{}, error) {
+				return c.Update(context.Background(), id, []byte(`{}`), header)
+			},
+			want: &thing{ID: id.String(), Name: "first"},
+		},
+		{
+			name: "Successfully deletes a resource",
+			httpSetup: func(client *mockHTTP) {
+				client.On("Delete", mock.Anything, path, map[string]string{"version": "3"}).Return(nil)
+			},
+			call: func(c *Client[thing]) (interface{}, error) {
+				return nil, c.Delete(context.Background(), id, 3)
+			},
+		},
+		{
+			name: "Successfully lists the resources",
+			httpSetup: func(client *mockHTTP) {
+				client.On("Get", mock.Anything, basePath, map[string]string{"filter[name]": "first"}).Return(
+					[]byte(`{"data": [{"id": "1", "name": "first"}, {"id": "2", "name": "first"}]}`),
+					nil,
+				)
+			},
+			call: func(c *Client[thing]) (interface{}, error) {
+				return c.List(context.Background(), NewQuery().Filter("name", "first"))
+			},
+			want: []*thing{{ID: "1", Name: "first"}, {ID: "2", Name: "first"}},
+		},
+		{
+			name: "Successfully lists a page of the resources with its links",
+			httpSetup: func(client *mockHTTP) {
+				client.On("Get", mock.Anything, basePath, map[string]string{"page[number]": "1"}).Return(
+					[]byte(`{"data": [{"id": "1", "name": "first"}], "links": {"first": "/things?page[number]=first", "next": "/things?page[number]=2", "self": "/things?page[number]=1"}}`),
+					nil,
+				)
+			},
+			call: func(c *Client[thing]) (interface{}, error) {
+				page, err := c.ListPage(context.Background(), NewQuery().PageNumber(1))
+				return page.Links, err
+			},
+			want: &Links{First: "/things?page[number]=first", Next: "/things?page[number]=2", Self: "/things?page[number]=1"},
+		},
+		{
+			name: "Failed to list the resources",
+			httpSetup: func(client *mockHTTP) {
+				client.On("Get", mock.Anything, basePath, mock.Anything).Return(nil, errors.New("unreachable"))
+			},
+			call: func(c *Client[thing]) (interface{}, error) {
+				return c.List(context.Background(), nil)
+			},
+			wantErrMsg: "unreachable",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpMock := &mockHTTP{}
+			tt.httpSetup(httpMock)
+
+			got, err := tt.call(NewClient[thing](httpMock, basePath, json.Unmarshal))
+			if tt.wantErrMsg != "" {
+				assert.EqualError(t, err, tt.wantErrMsg)
+			} else {
+				require.NoError(t, err)
+				if tt.want != nil {
+					assert.Equal(t, tt.want, got)
+				}
+			}
+
+			mock.AssertExpectationsForObjects(t, httpMock)
+		})
+	}
+}
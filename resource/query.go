@@ -0,0 +1,98 @@
+package resource
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Query builds the json:api query parameters of a request, the filter[...], page[...], sort and fields[...]
+// parameters, so the resource clients don't assemble the parameter names by hand, the values are url-encoded when the
+// query is sent
+type Query struct {
+	params map[string]string
+}
+
+// NewQuery creates an empty query
+func NewQuery() *Query {
+	return &Query{params: map[string]string{}}
+}
+
+// Filter filters the resources by the value of the field, as filter[field]
+func (q *Query) Filter(field, value string) *Query {
+	return q.Set(fmt.Sprintf("filter[%s]", field), value)
+}
+
+// HasFilter tells if the query filters the resources by the field
+func (q *Query) HasFilter(field string) bool {
+	_, ok := q.params[fmt.Sprintf("filter[%s]", field)]
+	return ok
+}
+
+// PageNumber sets the page[number] of the list, zero is not sent so form3 returns the first page
+func (q *Query) PageNumber(number int) *Query {
+	if number <= 0 {
+		return q
+	}
+
+	return q.Set("page[number]", strconv.Itoa(number))
+}
+
+// PageSize sets the page[size] of the list, zero is not sent so form3 uses its default size
+func (q *Query) PageSize(size int) *Query {
+	if size <= 0 {
+		return q
+	}
+
+	return q.Set("page[size]", strconv.Itoa(size))
+}
+
+// Sort sorts the resources by the fields in order, a field prefixed with a dash is sorted in descending order
+func (q *Query) Sort(fields ...string) *Query {
+	if len(fields) == 0 {
+		return q
+	}
+
+	return q.Set("sort", strings.Join(fields, ","))
+}
+
+// Fields restricts the attributes of the resources of the type sent back to the fields, as fields[type], the
+// json:api sparse fieldsets
+func (q *Query) Fields(resourceType string, fields ...string) *Query {
+	if len(fields) == 0 {
+		return q
+	}
+
+	return q.Set(fmt.Sprintf("fields[%s]", resourceType), strings.Join(fields, ","))
+}
+
+// Set sets a query parameter, such as one without a json:api helper
+func (q *Query) Set(key, value string) *Query {
+	q.params[key] = value
+	return q
+}
+
+// Params returns a copy of the query parameters, nil for a nil query
+func (q *Query) Params() map[string]string {
+	if q == nil {
+		return nil
+	}
+
+	params := make(map[string]string, len(q.params))
+	for key, value := range q.params {
+		params[key] = value
+	}
+
+	return params
+}
+
+// Encode returns the url-encoded query string sorted by key, such as for the links of the pages
+func (q *Query) Encode() string {
+	values := url.Values{}
+	for key, value := range q.Params() {
+		values.Set(key, value)
+	}
+
+	return values.Encode()
+}
@@ -0,0 +1,72 @@
+package resource
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuery(t *testing.T) {
+	tests := []struct {
+		name        string
+		query       *Query
+		wantParams  map[string]string
+		wantEncoded string
+	}{
+		{
+			name:        "Successfully builds an empty query",
+			query:       NewQuery(),
+			wantParams:  map[string]string{},
+			wantEncoded: "",
+		},
+		{
+			name:        "Successfully builds a nil query",
+			wantParams:  nil,
+			wantEncoded: "",
+		},
+		{
+			name:  "Successfully builds the filters, the page and the sort",
+			query: NewQuery().Filter("country", "GB").Filter("bank_id", "400300").PageNumber(2).PageSize(50).Sort("-created_on", "id"),
+			wantParams: map[string]string{
+				"filter[country]": "GB",
+				"filter[bank_id]": "400300",
+				"page[number]":    "2",
+				"page[size]":      "50",
+				"sort":            "-created_on,id",
+			},
+			wantEncoded: "filter%5Bbank_id%5D=400300&filter%5Bcountry%5D=GB&page%5Bnumber%5D=2&page%5Bsize%5D=50&sort=-created_on%2Cid",
+		},
+		{
+			name:        "Successfully builds the sparse fieldsets",
+			query:       NewQuery().Fields("accounts", "name", "iban"),
+			wantParams:  map[string]string{"fields[accounts]": "name,iban"},
+			wantEncoded: "fields%5Baccounts%5D=name%2Ciban",
+		},
+		{
+			name:        "Successfully leaves the zero page, the empty sort and the empty fields out",
+			query:       NewQuery().PageNumber(0).PageSize(0).Sort().Fields("accounts"),
+			wantParams:  map[string]string{},
+			wantEncoded: "",
+		},
+		{
+			name:        "Successfully encodes the reserved characters of the values",
+			query:       NewQuery().Filter("customer_id", "a&b=c d").Set("version", "1"),
+			wantParams:  map[string]string{"filter[customer_id]": "a&b=c d", "version": "1"},
+			wantEncoded: "filter%5Bcustomer_id%5D=a%26b%3Dc+d&version=1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantParams, tt.query.Params())
+			assert.Equal(t, tt.wantEncoded, tt.query.Encode())
+		})
+	}
+}
+
+func TestQueryHasFilter(t *testing.T) {
+	query := NewQuery().Filter("country", "GB")
+
+	assert.True(t, query.HasFilter("country"))
+	assert.False(t, query.HasFilter("bank_id"))
+}
@@ -0,0 +1,99 @@
+// Package validation checks the format of the bank identifiers, it can be used standalone or through
+// accounts.AccountData.Validate
+package validation
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// ibanLengths are the lengths of the iban of the countries form3 supports and their neighbours
+var ibanLengths = map[string]int{
+	"AT": 20, "BE": 16, "CH": 21, "DE": 22, "DK": 18, "ES": 24, "FI": 18, "FR": 27, "GB": 22, "GR": 27,
+	"IE": 22, "IT": 27, "LU": 20, "NL": 18, "NO": 15, "PL": 28, "PT": 25, "SE": 24,
+}
+
+// IBAN checks the country code, the length and the mod-97 check digits of an iban without spaces
+func IBAN(iban string) error {
+	if len(iban) < 15 || len(iban) > 34 {
+		return fmt.Errorf("invalid iban %q, it must have between 15 and 34 characters", iban)
+	}
+
+	if !isUpperLetters(iban[:2]) || !isDigits(iban[2:4]) || !isUpperAlphanumeric(iban[4:]) {
+		return fmt.Errorf("invalid iban %q, it must be a country code, 2 check digits and uppercase letters or digits", iban)
+	}
+
+	if length, ok := ibanLengths[iban[:2]]; ok && len(iban) != length {
+		return fmt.Errorf("invalid iban %q, it must have %d characters for %s", iban, length, iban[:2])
+	}
+
+	// the first 4 characters are moved to the end and the letters are replaced by numbers, A is 10 and Z is 35
+	var numeric []byte
+	for _, r := range iban[4:] + iban[:4] {
+		if r >= 'A' && r <= 'Z' {
+			numeric = append(numeric, []byte(fmt.Sprint(r-'A'+10))...)
+		} else {
+			numeric = append(numeric, byte(r))
+		}
+	}
+
+	value, _ := new(big.Int).SetString(string(numeric), 10)
+	if new(big.Int).Mod(value, big.NewInt(97)).Int64() != 1 {
+		return fmt.Errorf("invalid iban %q, the check digits do not match", iban)
+	}
+
+	return nil
+}
+
+// BIC checks the format of a bic, 4 letters of bank code, 2 letters of country code, 2 letters or digits of location
+// and optionally 3 letters or digits of branch code
+func BIC(bic string) error {
+	if len(bic) != 8 && len(bic) != 11 {
+		return fmt.Errorf("invalid bic %q, it must have 8 or 11 characters", bic)
+	}
+
+	if !isUpperLetters(bic[:6]) || !isUpperAlphanumeric(bic[6:]) {
+		return fmt.Errorf("invalid bic %q, it must be a bank code, a country code, a location and an optional branch code", bic)
+	}
+
+	return nil
+}
+
+// SortCode checks an uk sort code is 6 digits without separators, as form3 expects it in the bank id
+func SortCode(sortCode string) error {
+	if len(sortCode) != 6 || !isDigits(sortCode) {
+		return fmt.Errorf("invalid sort code %q, it must have 6 digits without separators", sortCode)
+	}
+
+	return nil
+}
+
+func isDigits(value string) bool {
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isUpperLetters(value string) bool {
+	for _, r := range value {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isUpperAlphanumeric(value string) bool {
+	for _, r := range value {
+		if (r < 'A' || r > 'Z') && (r < '0' || r > '9') {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,127 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIBAN(t *testing.T) {
+	tests := []struct {
+		name       string
+		iban       string
+		wantErrMsg string
+	}{
+		{
+			name: "Successfully validates a GB iban",
+			iban: "GB33BUKB20201555555555",
+		},
+		{
+			name: "Successfully validates a DE iban",
+			iban: "DE89370400440532013000",
+		},
+		{
+			name:       "Failed to validate an iban with wrong check digits",
+			iban:       "GB34BUKB20201555555555",
+			wantErrMsg: `invalid iban "GB34BUKB20201555555555", the check digits do not match`,
+		},
+		{
+			name:       "Failed to validate an iban with the wrong length for the country",
+			iban:       "GB33BUKB2020155555555",
+			wantErrMsg: `invalid iban "GB33BUKB2020155555555", it must have 22 characters for GB`,
+		},
+		{
+			name:       "Failed to validate an iban with spaces",
+			iban:       "GB33 BUKB 2020 1555 5555 55",
+			wantErrMsg: `invalid iban "GB33 BUKB 2020 1555 5555 55", it must be a country code, 2 check digits and uppercase letters or digits`,
+		},
+		{
+			name:       "Failed to validate a short iban",
+			iban:       "GB33",
+			wantErrMsg: `invalid iban "GB33", it must have between 15 and 34 characters`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := IBAN(tt.iban)
+			if tt.wantErrMsg != "" {
+				assert.EqualError(t, err, tt.wantErrMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestBIC(t *testing.T) {
+	tests := []struct {
+		name       string
+		bic        string
+		wantErrMsg string
+	}{
+		{
+			name: "Successfully validates a bic without branch code",
+			bic:  "NWBKGB22",
+		},
+		{
+			name: "Successfully validates a bic with branch code",
+			bic:  "DEUTDEFF500",
+		},
+		{
+			name:       "Failed to validate a bic with the wrong length",
+			bic:        "NWBKGB2",
+			wantErrMsg: `invalid bic "NWBKGB2", it must have 8 or 11 characters`,
+		},
+		{
+			name:       "Failed to validate a bic with digits in the bank code",
+			bic:        "NW1KGB22",
+			wantErrMsg: `invalid bic "NW1KGB22", it must be a bank code, a country code, a location and an optional branch code`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := BIC(tt.bic)
+			if tt.wantErrMsg != "" {
+				assert.EqualError(t, err, tt.wantErrMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSortCode(t *testing.T) {
+	tests := []struct {
+		name       string
+		sortCode   string
+		wantErrMsg string
+	}{
+		{
+			name:     "Successfully validates a sort code",
+			sortCode: "400300",
+		},
+		{
+			name:       "Failed to validate a sort code with separators",
+			sortCode:   "40-03-00",
+			wantErrMsg: `invalid sort code "40-03-00", it must have 6 digits without separators`,
+		},
+		{
+			name:       "Failed to validate a short sort code",
+			sortCode:   "4003",
+			wantErrMsg: `invalid sort code "4003", it must have 6 digits without separators`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := SortCode(tt.sortCode)
+			if tt.wantErrMsg != "" {
+				assert.EqualError(t, err, tt.wantErrMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
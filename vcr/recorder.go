@@ -0,0 +1,255 @@
+// Package vcr records the http interactions with form3 into a cassette file and replays them offline, so the code
+// using the clients can be tested deterministically in the CI
+package vcr
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"sync"
+)
+
+// Mode tells if the recorder records the real interactions or replays the recorded ones
+type Mode int
+
+const (
+	// ModeReplay replays the interactions of the cassette without reaching the network
+	ModeReplay Mode = iota
+	// ModeRecord sends the requests and records the interactions, the cassette is written by Save
+	ModeRecord
+)
+
+// redacted replaces the values of the redacted headers
+const redacted = "REDACTED"
+
+// defaultRedactedHeaders are the headers carrying secrets, they are never written to the cassette
+var defaultRedactedHeaders = []string{"Authorization", "Signature", "Cookie", "Set-Cookie", "X-Api-Key"}
+
+// Cassette is the recorded interactions
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a request and its response
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is a recorded request
+type Request struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// Response is a recorded response
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Option configures the recorder
+type Option func(*Recorder)
+
+// WithRedactedHeaders adds headers to redact on top of Authorization, Signature, Cookie, Set-Cookie and X-Api-Key
+func WithRedactedHeaders(headers ...string) Option {
+	return func(r *Recorder) {
+		r.redactedHeaders = append(r.redactedHeaders, headers...)
+	}
+}
+
+// WithBodyRedactor sets a function removing the secrets of the request and response bodies before they are recorded
+func WithBodyRedactor(redact func([]byte) []byte) Option {
+	return func(r *Recorder) {
+		r.redactBody = redact
+	}
+}
+
+// Recorder records or replays the interactions of a cassette file
+type Recorder struct {
+	mode            Mode
+	path            string
+	redactedHeaders []string
+	redactBody      func([]byte) []byte
+
+	mu       sync.Mutex
+	cassette Cassette
+	replayed []bool
+}
+
+// New creates a recorder of the cassette file, in replay mode the cassette is loaded and must exist
+func New(path string, mode Mode, opts ...Option) (*Recorder, error) {
+	recorder := &Recorder{
+		mode:            mode,
+		path:            path,
+		redactedHeaders: append([]string{}, defaultRedactedHeaders...),
+		redactBody:      func(body []byte) []byte { return body },
+	}
+
+	for _, opt := range opts {
+		opt(recorder)
+	}
+
+	if mode == ModeReplay {
+		raw, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("%w; unable to load the cassette", err)
+		}
+
+		if err := json.Unmarshal(raw, &recorder.cassette); err != nil {
+			return nil, fmt.Errorf("%w; unable to decode the cassette", err)
+		}
+		recorder.replayed = make([]bool, len(recorder.cassette.Interactions))
+	}
+
+	return recorder, nil
+}
+
+// Wrap returns the round tripper recording or replaying the interactions, in record mode the requests are sent with
+// next, see httputils.WithRoundTripper
+func (r *Recorder) Wrap(next http.RoundTripper) http.RoundTripper {
+	return roundTripperFunc(func(request *http.Request) (*http.Response, error) {
+		if r.mode == ModeReplay {
+			return r.replay(request)
+		}
+		return r.record(next, request)
+	})
+}
+
+// Save writes the recorded interactions to the cassette file
+func (r *Recorder) Save() error {
+	if r.mode != ModeRecord {
+		return errors.New("vcr: only a recorder in record mode can be saved")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	raw, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return fmt.Errorf("%w; unable to encode the cassette", err)
+	}
+
+	if err := ioutil.WriteFile(r.path, raw, 0o644); err != nil {
+		return fmt.Errorf("%w; unable to write the cassette", err)
+	}
+
+	return nil
+}
+
+func (r *Recorder) record(next http.RoundTripper, request *http.Request) (*http.Response, error) {
+	requestBody, err := readBody(&request.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := next.RoundTrip(request)
+	if err != nil {
+		return nil, err
+	}
+
+	responseBody, err := readBody(&response.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request: Request{
+			Method: request.Method,
+			URL:    request.URL.String(),
+			Header: r.redactHeader(request.Header),
+			Body:   string(r.redactBody(requestBody)),
+		},
+		Response: Response{
+			StatusCode: response.StatusCode,
+			Header:     r.redactHeader(response.Header),
+			Body:       string(r.redactBody(responseBody)),
+		},
+	})
+
+	return response, nil
+}
+
+// replay returns the response of the first interaction not replayed yet with the same method, url and body
+func (r *Recorder) replay(request *http.Request) (*http.Response, error) {
+	requestBody, err := readBody(&request.Body)
+	if err != nil {
+		return nil, err
+	}
+	body := string(r.redactBody(requestBody))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, interaction := range r.cassette.Interactions {
+		recorded := interaction.Request
+		if r.replayed[i] || recorded.Method != request.Method || recorded.URL != request.URL.String() || recorded.Body != body {
+			continue
+		}
+
+		r.replayed[i] = true
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Header:        interaction.Response.Header.Clone(),
+			Body:          ioutil.NopCloser(bytes.NewBufferString(interaction.Response.Body)),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       request,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("vcr: no recorded interaction for %s %s", request.Method, request.URL)
+}
+
+func (r *Recorder) redactHeader(header http.Header) http.Header {
+	clone := header.Clone()
+	for _, name := range r.redactedHeaders {
+		if clone.Get(name) != "" {
+			clone.Set(name, redacted)
+		}
+	}
+
+	return clone
+}
+
+// readBody reads the body and replaces it with a copy, so it can still be read by the next round tripper or the caller
+func readBody(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+
+	raw, err := ioutil.ReadAll(*body)
+	_ = (*body).Close()
+	if err != nil {
+		return nil, fmt.Errorf("%w; unable to read the body", err)
+	}
+
+	*body = ioutil.NopCloser(bytes.NewReader(raw))
+	return raw, nil
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
+
+// Exists tells if the cassette file exists, useful to record it on the first run and replay it afterwards
+func Exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,78 @@
+package vcr
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"renatoaraujo/form3-account-api-client/httputils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Set-Cookie", "session=secret")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"data": {"id": "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc", "secret": "s3cr3t"}}`))
+	}))
+
+	cassette := filepath.Join(t.TempDir(), "cassette.json")
+	redactor := WithBodyRedactor(func(body []byte) []byte {
+		return bytes.ReplaceAll(body, []byte("s3cr3t"), []byte(redacted))
+	})
+
+	recorder, err := New(cassette, ModeRecord, redactor)
+	require.NoError(t, err)
+
+	client, err := httputils.NewClient(server.URL, 5*time.Second, httputils.WithRoundTripper(recorder.Wrap))
+	require.NoError(t, err)
+
+	recorded, err := client.Post(context.Background(), "/v1/organisation/accounts", []byte(`{"data": {}}`), http.Header{
+		"Authorization": []string{"Bearer token"},
+	})
+	require.NoError(t, err)
+	assert.Contains(t, string(recorded), "s3cr3t")
+	require.NoError(t, recorder.Save())
+	server.Close()
+
+	raw, err := ioutil.ReadFile(cassette)
+	require.NoError(t, err)
+	assert.NotContains(t, string(raw), "Bearer token")
+	assert.NotContains(t, string(raw), "session=secret")
+	assert.NotContains(t, string(raw), "s3cr3t")
+	assert.True(t, Exists(cassette))
+
+	replayer, err := New(cassette, ModeReplay, redactor)
+	require.NoError(t, err)
+
+	client, err = httputils.NewClient(server.URL, 5*time.Second, httputils.WithRoundTripper(replayer.Wrap))
+	require.NoError(t, err)
+
+	replayed, err := client.Post(context.Background(), "/v1/organisation/accounts", []byte(`{"data": {}}`), nil)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"data": {"id": "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc", "secret": "REDACTED"}}`, string(replayed))
+
+	_, err = client.Post(context.Background(), "/v1/organisation/accounts", []byte(`{"data": {}}`), nil)
+	assert.Error(t, err, "every interaction is replayed once")
+	assert.Contains(t, err.Error(), "vcr: no recorded interaction for POST "+server.URL+"/v1/organisation/accounts")
+}
+
+func TestNewWithoutCassette(t *testing.T) {
+	_, err := New(filepath.Join(t.TempDir(), "missing.json"), ModeReplay)
+	assert.Error(t, err)
+	assert.False(t, Exists(filepath.Join(t.TempDir(), "missing.json")))
+
+	recorder, err := New(filepath.Join(t.TempDir(), "missing.json"), ModeRecord)
+	require.NoError(t, err)
+	assert.NoError(t, recorder.Save())
+
+	replayer := &Recorder{mode: ModeReplay}
+	assert.EqualError(t, replayer.Save(), "vcr: only a recorder in record mode can be saved")
+}